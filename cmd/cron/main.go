@@ -6,6 +6,7 @@ import (
 	"office-booking-backend/pkg/bootstrapper"
 	"office-booking-backend/pkg/config"
 	"office-booking-backend/pkg/database/mysql"
+	"office-booking-backend/pkg/database/redis"
 	"office-booking-backend/pkg/utils/shutdown"
 	"time"
 
@@ -24,6 +25,13 @@ func main() {
 	}
 	time.Local = loc
 
+	rs := redis.InitRedis(
+		conf.GetString("service.redis.host"),
+		conf.GetString("service.redis.port"),
+		conf.GetString("service.redis.pass"),
+		conf.GetString("service.redis.db"),
+	)
+
 	db := mysql.InitDatabase(
 		conf.GetString("service.db.host"),
 		conf.GetString("service.db.port"),
@@ -34,7 +42,7 @@ func main() {
 
 	cron := gocron.NewScheduler(loc)
 
-	bootstrapper.InitCron(db, cron, conf)
+	bootstrapper.InitCron(db, rs, cron, conf)
 
 	wait := shutdown.GracefulShutdown(context.Background(), conf.GetDuration("server.shutdownTimeout"), map[string]shutdown.Operation{
 		"database": func(ctx context.Context) error {
@@ -44,6 +52,9 @@ func main() {
 			}
 			return DB.Close()
 		},
+		"redis": func(ctx context.Context) error {
+			return rs.Close()
+		},
 		"cron": func(ctx context.Context) error {
 			cron.Clear()
 			return nil
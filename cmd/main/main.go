@@ -57,9 +57,16 @@ func main() {
 		ZeroEmpty:         true,
 	})
 
-	bootstrapper.InitAPI(app, db, rs, conf)
+	// ctx stops the background jobs of the api on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	bootstrapper.InitAPI(ctx, app, db, rs, conf)
 
 	wait := shutdown.GracefulShutdown(context.Background(), conf.GetDuration("server.shutdownTimeout"), map[string]shutdown.Operation{
+		"search": func(ctx context.Context) error {
+			cancel()
+			return nil
+		},
+
 		"fiber": func(ctx context.Context) error {
 			return app.Shutdown()
 		},
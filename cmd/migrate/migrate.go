@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func main() {
@@ -54,6 +55,10 @@ func main() {
 		&entity.ProfilePicture{},
 		&entity.Category{},
 		&entity.Facility{},
+		&entity.OpeningHour{},
+		&entity.OpeningException{},
+		&entity.FloorPlan{},
+		&entity.FloorPlanHotspot{},
 		&entity.City{},
 		&entity.District{},
 		&entity.Picture{},
@@ -63,12 +68,42 @@ func main() {
 		&entity.Reservation{},
 		&entity.Transaction{},
 		&entity.Review{},
+		&entity.ReviewReport{},
+		&entity.ReviewReply{},
+		&entity.ReviewPicture{},
+		&entity.Favorite{},
+		&entity.Organization{},
+		&entity.OrganizationMember{},
+		&entity.OrganizationApprovalLog{},
+		&entity.ReservationTransfer{},
+		&entity.SavedSearch{},
+		&entity.SavedSearchResult{},
+		&entity.ReportSubscription{},
+		&entity.Notification{},
+		&entity.BuildingRevision{},
+		&entity.BuildingDailyStat{},
+		&entity.BuildingEventBatch{},
 	)
 
 	if err != nil {
 		log.Fatalf("Error migrating database: %v", err)
 	}
 
+	err = InitSpatialIndex(db)
+	if err != nil {
+		log.Fatalf("Error creating spatial index: %v", err)
+	}
+
+	err = InitPublishedAt(db)
+	if err != nil {
+		log.Fatalf("Error backfilling building publish time: %v", err)
+	}
+
+	err = InitOpeningHoursFlags(db)
+	if err != nil {
+		log.Fatalf("Error backfilling opening hours flags: %v", err)
+	}
+
 	err = InitAdmin(db)
 	if err != nil {
 		log.Fatalf("Error seeding admin: %v", err)
@@ -102,6 +137,35 @@ func main() {
 	log.Println("Database migration successful")
 }
 
+// InitSpatialIndex adds the building location point, generated from the latitude and longitude, and its spatial index
+func InitSpatialIndex(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&entity.Building{}, "location") {
+		err := db.Exec("ALTER TABLE `buildings` ADD COLUMN `location` POINT GENERATED ALWAYS AS (POINT(COALESCE(`longitude`, 0), COALESCE(`latitude`, 0))) STORED NOT NULL SRID 0").Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if !db.Migrator().HasIndex(&entity.Building{}, "idx_buildings_location") {
+		return db.Exec("CREATE SPATIAL INDEX `idx_buildings_location` ON `buildings` (`location`)").Error
+	}
+
+	return nil
+}
+
+// InitPublishedAt sets the publish time of the buildings published before it was recorded to their creation time,
+// the earliest time known for them
+func InitPublishedAt(db *gorm.DB) error {
+	return db.Exec("UPDATE `buildings` SET `published_at` = `created_at` WHERE `is_published` = ? AND `published_at` IS NULL", true).Error
+}
+
+// InitOpeningHoursFlags marks the buildings without opening hours as always open and open on weekends,
+// they were stored as closed before
+func InitOpeningHoursFlags(db *gorm.DB) error {
+	return db.Exec("UPDATE `buildings` SET `is_always_open` = ?, `is_open_on_weekends` = ? "+
+		"WHERE NOT EXISTS (SELECT 1 FROM `opening_hours` WHERE `opening_hours`.`building_id` = `buildings`.`id`)", true, true).Error
+}
+
 func InitAdmin(db *gorm.DB) error {
 	passFunc := password.NewPasswordFuncImpl()
 	pass, err := passFunc.GenerateFromPassword([]byte("admin123"), 10)
@@ -157,16 +221,21 @@ func InitStatus(db *gorm.DB) error {
 			ID:      constant.COMPLETED_STATUS,
 			Message: "Completed",
 		},
+		{
+			ID:      constant.PENDING_APPROVAL_STATUS,
+			Message: "Pending Internal Approval",
+		},
 	}
 
 	// check if status already exists
 	var count int64
 	db.Model(&entity.Status{}).Count(&count)
-	if count > 0 {
+	if count >= int64(len(status)) {
 		return nil
 	}
 
-	return db.Create(&status).Error
+	// only insert the missing statuses so newly added statuses are seeded on existing databases
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&status).Error
 }
 
 func InitCity(db *gorm.DB) error {
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"office-booking-backend/internal/analytics/dto"
+	"office-booking-backend/internal/analytics/service"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsController struct {
+	service   service.AnalyticsService
+	validator validator.Validator
+}
+
+func NewAnalyticsController(analyticsService service.AnalyticsService, validator validator.Validator) *AnalyticsController {
+	return &AnalyticsController{
+		service:   analyticsService,
+		validator: validator,
+	}
+}
+
+func (a *AnalyticsController) GetBuildingFunnel(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	filter := new(dto.AnalyticsQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	funnel, err := a.service.GetBuildingFunnel(c.Context(), buildingID, filter)
+	if err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrInvalidDateRange:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building analytics fetched successfully",
+		Data:    funnel,
+	})
+}
+
+func (a *AnalyticsController) GetBuildingLeaderboard(c *fiber.Ctx) error {
+	filter := new(dto.LeaderboardQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := a.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	leaderboard, err := a.service.GetBuildingLeaderboard(c.Context(), filter)
+	if err != nil {
+		if err == err2.ErrInvalidDateRange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building leaderboard fetched successfully",
+		Data:    leaderboard,
+	})
+}
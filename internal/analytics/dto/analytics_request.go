@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/custom"
+	err2 "office-booking-backend/pkg/errors"
+	"time"
+)
+
+const (
+	// defaultAnalyticsDays is the period of the reports when no date range is given
+	defaultAnalyticsDays  = 30
+	defaultLeaderboardLen = 10
+)
+
+type AnalyticsQueryParam struct {
+	StartDate custom.Date `query:"startDate"`
+	EndDate   custom.Date `query:"endDate"`
+}
+
+// DateRange returns the inclusive date range of the report, the last 30 days by default
+func (a *AnalyticsQueryParam) DateRange() (time.Time, time.Time, error) {
+	return dateRange(a.StartDate, a.EndDate)
+}
+
+func dateRange(start custom.Date, end custom.Date) (time.Time, time.Time, error) {
+	endDate := end.ToTime()
+	if endDate.IsZero() {
+		now := time.Now()
+		endDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	startDate := start.ToTime()
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, 0, -defaultAnalyticsDays+1)
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, err2.ErrInvalidDateRange
+	}
+
+	return startDate, endDate, nil
+}
+
+type LeaderboardQueryParam struct {
+	StartDate custom.Date `query:"startDate"`
+	EndDate   custom.Date `query:"endDate"`
+	SortBy    string      `query:"sortBy" validate:"omitempty,oneof=impressions views favorites reservationStarts reservationCompletions conversion"`
+	Limit     int         `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+// DateRange returns the inclusive date range of the leaderboard, the last 30 days by default
+func (l *LeaderboardQueryParam) DateRange() (time.Time, time.Time, error) {
+	return dateRange(l.StartDate, l.EndDate)
+}
+
+// SetDefault sorts the leaderboard by views and shows the top 10 when not specified
+func (l *LeaderboardQueryParam) SetDefault() {
+	if l.SortBy == "" {
+		l.SortBy = "views"
+	}
+
+	if l.Limit == 0 {
+		l.Limit = defaultLeaderboardLen
+	}
+}
@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/entity"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+type FunnelStageResponse struct {
+	Key   string `json:"key"`
+	Total int64  `json:"total"`
+	// Rate is the share of the previous stage that reached this one
+	Rate float64 `json:"rate"`
+	// OverallRate is the share of the impressions that reached this stage
+	OverallRate float64 `json:"overallRate"`
+}
+
+type DailyFunnelResponse struct {
+	Date                   string `json:"date"`
+	Impressions            int64  `json:"impressions"`
+	Views                  int64  `json:"views"`
+	Favorites              int64  `json:"favorites"`
+	ReservationStarts      int64  `json:"reservationStarts"`
+	ReservationCompletions int64  `json:"reservationCompletions"`
+}
+
+type BuildingFunnelResponse struct {
+	BuildingID string                `json:"buildingId"`
+	StartDate  string                `json:"startDate"`
+	EndDate    string                `json:"endDate"`
+	Funnel     []FunnelStageResponse `json:"funnel"`
+	// Conversion is the share of the detail views that ended in a completed reservation
+	Conversion float64               `json:"conversion"`
+	Daily      []DailyFunnelResponse `json:"daily"`
+}
+
+func rate(count int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+func newFunnelStages(stat *entity.BuildingFunnelStat) []FunnelStageResponse {
+	totals := []struct {
+		key   string
+		total int64
+	}{
+		{"impressions", stat.Impressions},
+		{"views", stat.Views},
+		{"favorites", stat.Favorites},
+		{"reservationStarts", stat.ReservationStarts},
+		{"reservationCompletions", stat.ReservationCompletions},
+	}
+
+	stages := make([]FunnelStageResponse, len(totals))
+	for i, stage := range totals {
+		stages[i] = FunnelStageResponse{
+			Key:         stage.key,
+			Total:       stage.total,
+			Rate:        1,
+			OverallRate: rate(stage.total, stat.Impressions),
+		}
+		if i > 0 {
+			stages[i].Rate = rate(stage.total, totals[i-1].total)
+		}
+	}
+	return stages
+}
+
+// NewBuildingFunnelResponse sums the daily stats of a building, the days without any event are filled with zeros
+func NewBuildingFunnelResponse(buildingID string, startDate time.Time, endDate time.Time, stats *entity.BuildingDailyStats) *BuildingFunnelResponse {
+	byDate := make(map[string]*entity.BuildingDailyStat, len(*stats))
+	for i := range *stats {
+		byDate[(*stats)[i].Date.Format(dateFormat)] = &(*stats)[i]
+	}
+
+	total := &entity.BuildingFunnelStat{BuildingID: buildingID}
+	daily := make([]DailyFunnelResponse, 0)
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		day := DailyFunnelResponse{Date: date.Format(dateFormat)}
+		if stat, ok := byDate[day.Date]; ok {
+			day.Impressions = stat.Impressions
+			day.Views = stat.Views
+			day.Favorites = stat.Favorites
+			day.ReservationStarts = stat.ReservationStarts
+			day.ReservationCompletions = stat.ReservationCompletions
+		}
+
+		total.Impressions += day.Impressions
+		total.Views += day.Views
+		total.Favorites += day.Favorites
+		total.ReservationStarts += day.ReservationStarts
+		total.ReservationCompletions += day.ReservationCompletions
+		daily = append(daily, day)
+	}
+
+	return &BuildingFunnelResponse{
+		BuildingID: buildingID,
+		StartDate:  startDate.Format(dateFormat),
+		EndDate:    endDate.Format(dateFormat),
+		Funnel:     newFunnelStages(total),
+		Conversion: rate(total.ReservationCompletions, total.Views),
+		Daily:      daily,
+	}
+}
+
+type LeaderboardEntryResponse struct {
+	Rank                   int     `json:"rank"`
+	BuildingID             string  `json:"buildingId"`
+	BuildingName           string  `json:"buildingName"`
+	Impressions            int64   `json:"impressions"`
+	Views                  int64   `json:"views"`
+	Favorites              int64   `json:"favorites"`
+	ReservationStarts      int64   `json:"reservationStarts"`
+	ReservationCompletions int64   `json:"reservationCompletions"`
+	Conversion             float64 `json:"conversion"`
+}
+
+type BuildingLeaderboardResponse struct {
+	StartDate string                     `json:"startDate"`
+	EndDate   string                     `json:"endDate"`
+	SortBy    string                     `json:"sortBy"`
+	Buildings []LeaderboardEntryResponse `json:"buildings"`
+}
+
+func NewBuildingLeaderboardResponse(startDate time.Time, endDate time.Time, sortBy string, stats *entity.BuildingFunnelStats) *BuildingLeaderboardResponse {
+	buildings := make([]LeaderboardEntryResponse, 0, len(*stats))
+	for i, stat := range *stats {
+		buildings = append(buildings, LeaderboardEntryResponse{
+			Rank:                   i + 1,
+			BuildingID:             stat.BuildingID,
+			BuildingName:           stat.BuildingName,
+			Impressions:            stat.Impressions,
+			Views:                  stat.Views,
+			Favorites:              stat.Favorites,
+			ReservationStarts:      stat.ReservationStarts,
+			ReservationCompletions: stat.ReservationCompletions,
+			Conversion:             rate(stat.ReservationCompletions, stat.Views),
+		})
+	}
+
+	return &BuildingLeaderboardResponse{
+		StartDate: startDate.Format(dateFormat),
+		EndDate:   endDate.Format(dateFormat),
+		SortBy:    sortBy,
+		Buildings: buildings,
+	}
+}
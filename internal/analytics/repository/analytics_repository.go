@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"office-booking-backend/pkg/entity"
+	"time"
+)
+
+type AnalyticsRepository interface {
+	AddBuildingDailyStats(ctx context.Context, batchID string, stats *entity.BuildingDailyStats) error
+	GetBuildingDailyStats(ctx context.Context, buildingID string, startDate time.Time, endDate time.Time) (*entity.BuildingDailyStats, error)
+	GetBuildingFunnelLeaderboard(ctx context.Context, startDate time.Time, endDate time.Time, sortBy string, limit int) (*entity.BuildingFunnelStats, error)
+}
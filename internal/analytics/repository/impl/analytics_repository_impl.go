@@ -0,0 +1,150 @@
+package impl
+
+import (
+	"context"
+	"office-booking-backend/internal/analytics/repository"
+	"office-booking-backend/pkg/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// leaderboardOrder maps the leaderboard sort keys to their order expression
+var leaderboardOrder = map[string]string{
+	"impressions":            "impressions DESC",
+	"views":                  "views DESC",
+	"favorites":              "favorites DESC",
+	"reservationStarts":      "reservation_starts DESC",
+	"reservationCompletions": "reservation_completions DESC",
+	"conversion":             "reservation_completions / NULLIF(views, 0) DESC, views DESC",
+}
+
+// buildingEventBatchRetention is the number of days the flushed batches are kept
+const buildingEventBatchRetention = 7
+
+type AnalyticsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepositoryImpl(db *gorm.DB) repository.AnalyticsRepository {
+	return &AnalyticsRepositoryImpl{
+		db: db,
+	}
+}
+
+// AddBuildingDailyStats adds the flushed counters to the daily stats, the counters of
+// buildings that were deleted for good are dropped. The batch is recorded in the same transaction,
+// a batch that was already added is skipped
+func (a *AnalyticsRepositoryImpl) AddBuildingDailyStats(ctx context.Context, batchID string, stats *entity.BuildingDailyStats) error {
+	if len(*stats) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(*stats))
+	for _, stat := range *stats {
+		ids = append(ids, stat.BuildingID)
+	}
+
+	var existingIDs []string
+	err := a.db.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Building{}).
+		Where("id IN ?", ids).
+		Pluck("id", &existingIDs).Error
+	if err != nil {
+		return err
+	}
+
+	exists := make(map[string]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		exists[id] = true
+	}
+
+	rows := make(entity.BuildingDailyStats, 0, len(*stats))
+	for _, stat := range *stats {
+		if exists[stat.BuildingID] {
+			rows = append(rows, stat)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.WithContext(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.BuildingEventBatch{ID: batchID, FlushedAt: now})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		// a batch is only retried until it's deleted from redis, older records aren't needed anymore
+		err := tx.WithContext(ctx).
+			Where("flushed_at < ?", now.AddDate(0, 0, -buildingEventBatchRetention)).
+			Delete(&entity.BuildingEventBatch{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.WithContext(ctx).
+			Omit("Building").
+			Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"impressions":             gorm.Expr("impressions + VALUES(impressions)"),
+					"views":                   gorm.Expr("views + VALUES(views)"),
+					"favorites":               gorm.Expr("favorites + VALUES(favorites)"),
+					"reservation_starts":      gorm.Expr("reservation_starts + VALUES(reservation_starts)"),
+					"reservation_completions": gorm.Expr("reservation_completions + VALUES(reservation_completions)"),
+				}),
+			}).
+			Create(&rows).Error
+	})
+}
+
+func (a *AnalyticsRepositoryImpl) GetBuildingDailyStats(ctx context.Context, buildingID string, startDate time.Time, endDate time.Time) (*entity.BuildingDailyStats, error) {
+	stats := new(entity.BuildingDailyStats)
+	err := a.db.WithContext(ctx).
+		Where("building_id = ?", buildingID).
+		Where("date BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Order("date ASC").
+		Find(stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (a *AnalyticsRepositoryImpl) GetBuildingFunnelLeaderboard(ctx context.Context, startDate time.Time, endDate time.Time, sortBy string, limit int) (*entity.BuildingFunnelStats, error) {
+	order, ok := leaderboardOrder[sortBy]
+	if !ok {
+		order = leaderboardOrder["views"]
+	}
+
+	stats := new(entity.BuildingFunnelStats)
+	err := a.db.WithContext(ctx).
+		Model(&entity.BuildingDailyStat{}).
+		Select("buildings.id AS building_id, buildings.name AS building_name, "+
+			"SUM(building_daily_stats.impressions) AS impressions, SUM(building_daily_stats.views) AS views, "+
+			"SUM(building_daily_stats.favorites) AS favorites, SUM(building_daily_stats.reservation_starts) AS reservation_starts, "+
+			"SUM(building_daily_stats.reservation_completions) AS reservation_completions").
+		Joins("JOIN buildings ON buildings.id = building_daily_stats.building_id").
+		Where("buildings.deleted_at IS NULL").
+		Where("building_daily_stats.date BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Group("buildings.id").
+		Order(order).
+		Limit(limit).
+		Scan(stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
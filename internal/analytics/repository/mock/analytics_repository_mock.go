@@ -0,0 +1,28 @@
+package mock
+
+import (
+	"context"
+	"office-booking-backend/pkg/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AnalyticsRepositoryMock struct {
+	mock.Mock
+}
+
+func (a *AnalyticsRepositoryMock) AddBuildingDailyStats(ctx context.Context, batchID string, stats *entity.BuildingDailyStats) error {
+	args := a.Called(ctx, batchID, stats)
+	return args.Error(0)
+}
+
+func (a *AnalyticsRepositoryMock) GetBuildingDailyStats(ctx context.Context, buildingID string, startDate time.Time, endDate time.Time) (*entity.BuildingDailyStats, error) {
+	args := a.Called(ctx, buildingID, startDate, endDate)
+	return args.Get(0).(*entity.BuildingDailyStats), args.Error(1)
+}
+
+func (a *AnalyticsRepositoryMock) GetBuildingFunnelLeaderboard(ctx context.Context, startDate time.Time, endDate time.Time, sortBy string, limit int) (*entity.BuildingFunnelStats, error) {
+	args := a.Called(ctx, startDate, endDate, sortBy, limit)
+	return args.Get(0).(*entity.BuildingFunnelStats), args.Error(1)
+}
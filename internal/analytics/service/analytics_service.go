@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"office-booking-backend/internal/analytics/dto"
+)
+
+type AnalyticsService interface {
+	TrackBuildingEvent(event string, buildingIDs ...string)
+	FlushBuildingEvents(ctx context.Context) error
+	GetBuildingFunnel(ctx context.Context, buildingID string, filter *dto.AnalyticsQueryParam) (*dto.BuildingFunnelResponse, error)
+	GetBuildingLeaderboard(ctx context.Context, filter *dto.LeaderboardQueryParam) (*dto.BuildingLeaderboardResponse, error)
+}
@@ -0,0 +1,233 @@
+package impl
+
+import (
+	"context"
+	"log"
+	"office-booking-backend/internal/analytics/dto"
+	"office-booking-backend/internal/analytics/repository"
+	"office-booking-backend/internal/analytics/service"
+	br "office-booking-backend/internal/building/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/database/redis"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+
+	redis2 "github.com/go-redis/redis/v9"
+	"github.com/google/uuid"
+)
+
+const (
+	// buildingEventsKey is the redis hash holding the counters that weren't flushed yet,
+	// its fields are "<date>|<building id>|<event>"
+	buildingEventsKey = "building-analytics"
+	// flushingEventsKey holds the counters of the flush in progress, so new events don't get lost while flushing
+	flushingEventsKey = "building-analytics:flushing"
+	// flushingBatchKey holds the id of the flush in progress, a retried flush reuses it
+	flushingBatchKey = "building-analytics:flushing:batch"
+	trackTimeout     = 5 * time.Second
+)
+
+type AnalyticsServiceImpl struct {
+	repo         repository.AnalyticsRepository
+	buildingRepo br.BuildingRepository
+	redisRepo    redis.RedisClient
+}
+
+func NewAnalyticsServiceImpl(repo repository.AnalyticsRepository, buildingRepo br.BuildingRepository, redisRepo redis.RedisClient) service.AnalyticsService {
+	return &AnalyticsServiceImpl{
+		repo:         repo,
+		buildingRepo: buildingRepo,
+		redisRepo:    redisRepo,
+	}
+}
+
+// TrackBuildingEvent counts an event of the buildings in the background, so the request never waits for redis
+func (a *AnalyticsServiceImpl) TrackBuildingEvent(event string, buildingIDs ...string) {
+	if len(buildingIDs) == 0 {
+		return
+	}
+
+	date := time.Now().Format("2006-01-02")
+	fields := make(map[string]int64, len(buildingIDs))
+	for _, buildingID := range buildingIDs {
+		fields[date+"|"+buildingID+"|"+event]++
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), trackTimeout)
+		defer cancel()
+
+		err := a.redisRepo.HIncrBy(ctx, buildingEventsKey, fields)
+		if err != nil {
+			log.Println("error when tracking building event: ", err)
+		}
+	}()
+}
+
+// FlushBuildingEvents moves the buffered counters to the daily stats
+func (a *AnalyticsServiceImpl) FlushBuildingEvents(ctx context.Context) error {
+	// a flush that failed halfway left its counters behind, they are flushed before the new ones
+	isFlushing, err := a.redisRepo.Exists(ctx, flushingEventsKey)
+	if err != nil {
+		log.Println("error when checking flushing building events: ", err)
+		return err
+	}
+
+	var batchID string
+	if !isFlushing {
+		hasEvents, err := a.redisRepo.Exists(ctx, buildingEventsKey)
+		if err != nil {
+			log.Println("error when checking building events: ", err)
+			return err
+		}
+
+		if !hasEvents {
+			return nil
+		}
+
+		// the id is set before the counters are moved, so the moved counters never carry the id of an older batch
+		batchID = uuid.New().String()
+		err = a.redisRepo.Set(ctx, flushingBatchKey, batchID, 0)
+		if err != nil {
+			log.Println("error when setting building events batch: ", err)
+			return err
+		}
+
+		err = a.redisRepo.Rename(ctx, buildingEventsKey, flushingEventsKey)
+		if err != nil {
+			log.Println("error when moving building events: ", err)
+			return err
+		}
+	} else {
+		batchID, err = a.redisRepo.Get(ctx, flushingBatchKey)
+		if err != nil && err != redis2.Nil {
+			log.Println("error when getting building events batch: ", err)
+			return err
+		}
+
+		// counters moved before the batches were tracked haven't been added under any id
+		if err == redis2.Nil {
+			batchID = uuid.New().String()
+			err = a.redisRepo.Set(ctx, flushingBatchKey, batchID, 0)
+			if err != nil {
+				log.Println("error when setting building events batch: ", err)
+				return err
+			}
+		}
+	}
+
+	counters, err := a.redisRepo.HGetAll(ctx, flushingEventsKey)
+	if err != nil {
+		log.Println("error when getting building events: ", err)
+		return err
+	}
+
+	// a retried batch that was already added is skipped by the repository
+	stats := newBuildingDailyStats(counters)
+	err = a.repo.AddBuildingDailyStats(ctx, batchID, stats)
+	if err != nil {
+		log.Println("error when adding building daily stats: ", err)
+		return err
+	}
+
+	err = a.redisRepo.Del(ctx, flushingEventsKey)
+	if err != nil {
+		log.Println("error when deleting flushed building events: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// newBuildingDailyStats groups the redis counters by building and date, malformed fields are skipped
+func newBuildingDailyStats(counters map[string]string) *entity.BuildingDailyStats {
+	byKey := make(map[string]*entity.BuildingDailyStat)
+	keys := make([]string, 0)
+	for field, value := range counters {
+		parts := strings.Split(field, "|")
+		if len(parts) != 3 {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", parts[0])
+		if err != nil {
+			continue
+		}
+
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		key := parts[0] + "|" + parts[1]
+		stat, ok := byKey[key]
+		if !ok {
+			stat = &entity.BuildingDailyStat{BuildingID: parts[1], Date: date}
+			byKey[key] = stat
+			keys = append(keys, key)
+		}
+
+		switch parts[2] {
+		case constant.BUILDING_IMPRESSION_EVENT:
+			stat.Impressions += count
+		case constant.BUILDING_VIEW_EVENT:
+			stat.Views += count
+		case constant.BUILDING_FAVORITE_EVENT:
+			stat.Favorites += count
+		case constant.RESERVATION_START_EVENT:
+			stat.ReservationStarts += count
+		case constant.RESERVATION_COMPLETION_EVENT:
+			stat.ReservationCompletions += count
+		}
+	}
+
+	stats := make(entity.BuildingDailyStats, 0, len(keys))
+	for _, key := range keys {
+		stats = append(stats, *byKey[key])
+	}
+	return &stats
+}
+
+func (a *AnalyticsServiceImpl) GetBuildingFunnel(ctx context.Context, buildingID string, filter *dto.AnalyticsQueryParam) (*dto.BuildingFunnelResponse, error) {
+	startDate, endDate, err := filter.DateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	isExist, err := a.buildingRepo.IsBuildingExist(ctx, buildingID)
+	if err != nil {
+		log.Println("error when checking building existence: ", err)
+		return nil, err
+	}
+
+	if !isExist {
+		return nil, err2.ErrBuildingNotFound
+	}
+
+	stats, err := a.repo.GetBuildingDailyStats(ctx, buildingID, startDate, endDate)
+	if err != nil {
+		log.Println("error when getting building daily stats: ", err)
+		return nil, err
+	}
+
+	return dto.NewBuildingFunnelResponse(buildingID, startDate, endDate, stats), nil
+}
+
+func (a *AnalyticsServiceImpl) GetBuildingLeaderboard(ctx context.Context, filter *dto.LeaderboardQueryParam) (*dto.BuildingLeaderboardResponse, error) {
+	startDate, endDate, err := filter.DateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	filter.SetDefault()
+	stats, err := a.repo.GetBuildingFunnelLeaderboard(ctx, startDate, endDate, filter.SortBy, filter.Limit)
+	if err != nil {
+		log.Println("error when getting building funnel leaderboard: ", err)
+		return nil, err
+	}
+
+	return dto.NewBuildingLeaderboardResponse(startDate, endDate, filter.SortBy, stats), nil
+}
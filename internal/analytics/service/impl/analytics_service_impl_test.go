@@ -0,0 +1,102 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	mockRepo "office-booking-backend/internal/analytics/repository/mock"
+	"office-booking-backend/internal/analytics/service"
+	"office-booking-backend/pkg/constant"
+	mockRedis "office-booking-backend/pkg/database/redis"
+	"office-booking-backend/pkg/entity"
+	"testing"
+
+	redis2 "github.com/go-redis/redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteAnalyticsService struct {
+	suite.Suite
+	mockRepo         *mockRepo.AnalyticsRepositoryMock
+	mockRedis        *mockRedis.RedisClientMock
+	analyticsService service.AnalyticsService
+}
+
+func (s *TestSuiteAnalyticsService) SetupTest() {
+	s.mockRepo = new(mockRepo.AnalyticsRepositoryMock)
+	s.mockRedis = new(mockRedis.RedisClientMock)
+	s.analyticsService = NewAnalyticsServiceImpl(s.mockRepo, nil, s.mockRedis)
+}
+
+func (s *TestSuiteAnalyticsService) TearDownTest() {
+	s.mockRepo = nil
+	s.mockRedis = nil
+	s.analyticsService = nil
+}
+
+func TestAnalyticsService(t *testing.T) {
+	suite.Run(t, new(TestSuiteAnalyticsService))
+}
+
+var counters = map[string]string{
+	"2023-05-10|building|" + constant.BUILDING_VIEW_EVENT: "3",
+}
+
+func (s *TestSuiteAnalyticsService) TestFlushBuildingEvents_NewBatch() {
+	var batchID string
+	s.mockRedis.On("Exists", mock.Anything, flushingEventsKey).Return(false, nil)
+	s.mockRedis.On("Exists", mock.Anything, buildingEventsKey).Return(true, nil)
+	s.mockRedis.On("Set", mock.Anything, flushingBatchKey, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		batchID = args.String(2)
+	}).Return(nil)
+	s.mockRedis.On("Rename", mock.Anything, buildingEventsKey, flushingEventsKey).Return(nil)
+	s.mockRedis.On("HGetAll", mock.Anything, flushingEventsKey).Return(counters, nil)
+	s.mockRepo.On("AddBuildingDailyStats", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRedis.On("Del", mock.Anything, flushingEventsKey).Return(nil)
+
+	err := s.analyticsService.FlushBuildingEvents(context.Background())
+	s.NoError(err)
+	s.NotEmpty(batchID)
+	s.mockRepo.AssertCalled(s.T(), "AddBuildingDailyStats", mock.Anything, batchID, mock.MatchedBy(func(stats *entity.BuildingDailyStats) bool {
+		return len(*stats) == 1 && (*stats)[0].Views == 3
+	}))
+	s.mockRedis.AssertCalled(s.T(), "Del", mock.Anything, flushingEventsKey)
+}
+
+func (s *TestSuiteAnalyticsService) TestFlushBuildingEvents_RetryReusesBatch() {
+	s.mockRedis.On("Exists", mock.Anything, flushingEventsKey).Return(true, nil)
+	s.mockRedis.On("Get", mock.Anything, flushingBatchKey).Return("batch", nil)
+	s.mockRedis.On("HGetAll", mock.Anything, flushingEventsKey).Return(counters, nil)
+	s.mockRepo.On("AddBuildingDailyStats", mock.Anything, "batch", mock.Anything).Return(nil)
+	s.mockRedis.On("Del", mock.Anything, flushingEventsKey).Return(nil)
+
+	err := s.analyticsService.FlushBuildingEvents(context.Background())
+	s.NoError(err)
+	s.mockRepo.AssertCalled(s.T(), "AddBuildingDailyStats", mock.Anything, "batch", mock.Anything)
+	s.mockRedis.AssertNotCalled(s.T(), "Rename", mock.Anything, mock.Anything, mock.Anything)
+	s.mockRedis.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteAnalyticsService) TestFlushBuildingEvents_RetryWithoutBatch() {
+	s.mockRedis.On("Exists", mock.Anything, flushingEventsKey).Return(true, nil)
+	s.mockRedis.On("Get", mock.Anything, flushingBatchKey).Return("", redis2.Nil)
+	s.mockRedis.On("Set", mock.Anything, flushingBatchKey, mock.Anything, mock.Anything).Return(nil)
+	s.mockRedis.On("HGetAll", mock.Anything, flushingEventsKey).Return(counters, nil)
+	s.mockRepo.On("AddBuildingDailyStats", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRedis.On("Del", mock.Anything, flushingEventsKey).Return(nil)
+
+	err := s.analyticsService.FlushBuildingEvents(context.Background())
+	s.NoError(err)
+	s.mockRedis.AssertCalled(s.T(), "Set", mock.Anything, flushingBatchKey, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteAnalyticsService) TestFlushBuildingEvents_KeepsCountersOnFailure() {
+	s.mockRedis.On("Exists", mock.Anything, flushingEventsKey).Return(true, nil)
+	s.mockRedis.On("Get", mock.Anything, flushingBatchKey).Return("batch", nil)
+	s.mockRedis.On("HGetAll", mock.Anything, flushingEventsKey).Return(counters, nil)
+	s.mockRepo.On("AddBuildingDailyStats", mock.Anything, "batch", mock.Anything).Return(errors.New("deadlock"))
+
+	err := s.analyticsService.FlushBuildingEvents(context.Background())
+	s.Error(err)
+	s.mockRedis.AssertNotCalled(s.T(), "Del", mock.Anything, mock.Anything)
+}
@@ -7,6 +7,8 @@ import (
 	"office-booking-backend/internal/building/service"
 	err2 "office-booking-backend/pkg/errors"
 	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/spreadsheet"
+	"office-booking-backend/pkg/utils/timeseries"
 	"office-booking-backend/pkg/utils/validator"
 	"reflect"
 	"strconv"
@@ -34,6 +36,10 @@ func (b *BuildingController) GetAllPublishedBuildings(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
+	if filter.Detect(c) {
+		filter.Page = 1
+	}
+
 	if errs := b.validator.ValidateQuery(filter); errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidQueryParams.Error(),
@@ -41,23 +47,30 @@ func (b *BuildingController) GetAllPublishedBuildings(c *fiber.Ctx) error {
 		})
 	}
 
-	buildings, total, err := b.buildingService.GetAllPublishedBuildings(c.Context(), filter)
+	buildings, total, facets, err := b.buildingService.GetAllPublishedBuildings(c.Context(), filter, optionalUserID(c))
 	if err != nil {
-		if errors.Is(err, err2.ErrInvalidDateRange) {
+		if errors.Is(err, err2.ErrInvalidDateRange) || errors.Is(err, err2.ErrInvalidCursor) || errors.Is(err, err2.ErrCursorNotSupported) {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	meta := fiber.Map{
+		"limit":  filter.Limit,
+		"page":   filter.Page,
+		"total":  total,
+		"facets": facets,
+	}
+	if filter.Enabled {
+		meta = filter.Meta(filter.Limit)
+		meta["facets"] = facets
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Message: "buildings fetched successfully",
 		Data:    buildings,
-		Meta: fiber.Map{
-			"limit": filter.Limit,
-			"page":  filter.Page,
-			"total": total,
-		},
+		Meta:    meta,
 	})
 }
 
@@ -67,6 +80,10 @@ func (b *BuildingController) GetAllBuildings(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
+	if filter.Detect(c) {
+		filter.Page = 1
+	}
+
 	if errs := b.validator.ValidateQuery(filter); errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidQueryParams.Error(),
@@ -76,27 +93,32 @@ func (b *BuildingController) GetAllBuildings(c *fiber.Ctx) error {
 
 	buildings, total, err := b.buildingService.GetAllBuildings(c.Context(), filter)
 	if err != nil {
-		if errors.Is(err, err2.ErrInvalidDateRange) {
+		if errors.Is(err, err2.ErrInvalidDateRange) || errors.Is(err, err2.ErrInvalidCursor) || errors.Is(err, err2.ErrCursorNotSupported) {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	meta := fiber.Map{
+		"limit": filter.Limit,
+		"page":  filter.Page,
+		"total": total,
+	}
+	if filter.Enabled {
+		meta = filter.Meta(filter.Limit)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Message: "buildings fetched successfully",
 		Data:    buildings,
-		Meta: fiber.Map{
-			"limit": filter.Limit,
-			"page":  filter.Page,
-			"total": total,
-		},
+		Meta:    meta,
 	})
 }
 
 func (b *BuildingController) GetPublishedBuildingDetailByID(c *fiber.Ctx) error {
 	id := c.Params("buildingID")
 
-	building, err := b.buildingService.GetPublishedBuildingDetailByID(c.Context(), id)
+	building, err := b.buildingService.GetPublishedBuildingDetailByID(c.Context(), id, optionalUserID(c))
 	if err != nil {
 		if errors.Is(err, err2.ErrBuildingNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
@@ -111,6 +133,64 @@ func (b *BuildingController) GetPublishedBuildingDetailByID(c *fiber.Ctx) error
 	})
 }
 
+func (b *BuildingController) GetSimilarBuildings(c *fiber.Ctx) error {
+	id := c.Params("buildingID")
+
+	filter := new(dto.SimilarBuildingQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := b.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	buildings, err := b.buildingService.GetSimilarBuildings(c.Context(), id, filter, optionalUserID(c))
+	if err != nil {
+		if errors.Is(err, err2.ErrBuildingNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "similar buildings fetched successfully",
+		Data:    buildings,
+	})
+}
+
+func (b *BuildingController) CompareBuildings(c *fiber.Ctx) error {
+	filter := new(dto.CompareBuildingQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := b.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	comparison, err := b.buildingService.CompareBuildings(c.Context(), filter)
+	if err != nil {
+		if errors.Is(err, err2.ErrBuildingNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "buildings compared successfully",
+		Data:    comparison,
+	})
+}
+
 func (b *BuildingController) GetBuildingDetailByID(c *fiber.Ctx) error {
 	id := c.Params("buildingID")
 
@@ -172,8 +252,24 @@ func (b *BuildingController) GetDistricts(c *fiber.Ctx) error {
 }
 
 func (b *BuildingController) GetBuildingTotal(c *fiber.Ctx) error {
-	total, err := b.buildingService.GetBuildingStatistics(c.Context())
+	filter := new(timeseries.Query)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := b.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	total, err := b.buildingService.GetBuildingStatistics(c.Context(), filter)
 	if err != nil {
+		if errors.Is(err, err2.ErrInvalidDateRange) || errors.Is(err, err2.ErrTooManyDataPoints) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -191,6 +287,10 @@ func (b *BuildingController) GetBuildingReviews(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
 	}
 
+	if filter.Detect(c) {
+		filter.Page = 1
+	}
+
 	if errs := b.validator.ValidateQuery(filter); errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidQueryParams.Error(),
@@ -200,12 +300,51 @@ func (b *BuildingController) GetBuildingReviews(c *fiber.Ctx) error {
 
 	reviews, total, err := b.buildingService.GetBuildingReviews(c.Context(), buildingID, filter)
 	if err != nil {
+		if errors.Is(err, err2.ErrInvalidCursor) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	meta := fiber.Map{
+		"limit": filter.Limit,
+		"page":  filter.Page,
+		"total": total,
+	}
+	if filter.Enabled {
+		meta = filter.Meta(filter.Limit)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Message: "building reviews fetched successfully",
 		Data:    reviews,
+		Meta:    meta,
+	})
+}
+
+func (b *BuildingController) GetBuildingReviewPictures(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	filter := new(dto.ReviewPictureQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := b.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	pictures, total, err := b.buildingService.GetBuildingReviewPictures(c.Context(), buildingID, filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building guest photos fetched successfully",
+		Data:    pictures,
 		Meta: fiber.Map{
 			"limit": filter.Limit,
 			"page":  filter.Page,
@@ -271,7 +410,11 @@ func (b *BuildingController) AddBuildingPicture(c *fiber.Ctx) error {
 	}
 	defer file.Close()
 
-	result, err := b.buildingService.AddBuildingPicture(c.Context(), buildingID, indexInt, altText, file)
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	editorID := claims["uid"].(string)
+
+	result, err := b.buildingService.AddBuildingPicture(c.Context(), buildingID, indexInt, altText, file, editorID)
 	if err != nil {
 		switch err {
 		case err2.ErrBuildingNotFound:
@@ -283,124 +426,154 @@ func (b *BuildingController) AddBuildingPicture(c *fiber.Ctx) error {
 		}
 	}
 
+	if result.Revision != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.BaseResponse{
+			Message: "building picture saved as draft revision",
+			Data:    result,
+		})
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
 		Message: "building picture uploaded successfully",
 		Data:    result,
 	})
 }
 
-func (b *BuildingController) AddBuildingFacilities(c *fiber.Ctx) error {
+func (b *BuildingController) GetPublishedBuildingFloorPlans(c *fiber.Ctx) error {
 	buildingID := c.Params("buildingID")
 
-	facilities := new(dto.AddFacilitiesRequest)
-	if err := c.BodyParser(facilities); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	floorPlans, err := b.buildingService.GetPublishedBuildingFloorPlans(c.Context(), buildingID)
+	if err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
 	}
 
-	if errs := b.validator.ValidateVar(facilities, "required,dive"); errs != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
-			Message: err2.ErrInvalidRequestBody.Error(),
-			Data:    errs,
-		})
-	}
-	if err := b.buildingService.AddBuildingFacility(c.Context(), buildingID, facilities); err != nil {
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "success getting building floor plans",
+		Data:    floorPlans,
+	})
+}
+
+func (b *BuildingController) GetBuildingFloorPlans(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	floorPlans, err := b.buildingService.GetBuildingFloorPlans(c.Context(), buildingID)
+	if err != nil {
 		switch err {
 		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
-		case err2.ErrInvalidCategoryID:
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
-		Message: "building facilities added successfully",
+		Message: "success getting building floor plans",
+		Data:    floorPlans,
 	})
 }
 
-func (b *BuildingController) UpdateBuilding(c *fiber.Ctx) error {
+func (b *BuildingController) AddBuildingFloorPlan(c *fiber.Ctx) error {
 	buildingID := c.Params("buildingID")
 
-	building := new(dto.UpdateBuildingRequest)
-	if err := c.BodyParser(building); err != nil {
+	name := c.FormValue("name", "")
+	floor, err := strconv.Atoi(c.FormValue("floor", "0"))
+	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
 	}
 
-	if errs := b.validator.ValidateJSON(building); errs != nil {
+	fileHeader, err := c.FormFile("picture")
+	validatorDto := struct {
+		Name    string                `json:"name" validate:"omitempty,max=100"`
+		Floor   int                   `json:"floor" validate:"gte=-10,lte=200"`
+		Picture *multipart.FileHeader `json:"picture" validate:"multipartImage"`
+	}{
+		Name:    name,
+		Floor:   floor,
+		Picture: fileHeader,
+	}
+
+	errs := b.validator.ValidateJSON(validatorDto)
+	if errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidRequestBody.Error(),
 			Data:    errs,
 		})
 	}
 
-	if reflect.DeepEqual(*building, dto.UpdateBuildingRequest{}) {
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
 	}
+	defer file.Close()
 
-	if err := b.buildingService.UpdateBuilding(c.Context(), building, buildingID); err != nil {
+	result, err := b.buildingService.AddBuildingFloorPlan(c.Context(), buildingID, floor, name, file)
+	if err != nil {
 		switch err {
 		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
-		case err2.ErrPictureNotFound:
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
-		case err2.ErrFacilityNotFound:
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err2.ErrFloorPlanLimitExceeded:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
-		Message: "building updated successfully",
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "building floor plan uploaded successfully",
+		Data:    result,
 	})
 }
 
-func (b *BuildingController) UpdateBuildingPublishState(c *fiber.Ctx) error {
+func (b *BuildingController) UpdateFloorPlanHotspots(c *fiber.Ctx) error {
 	buildingID := c.Params("buildingID")
+	floorPlanID := c.Params("floorPlanID")
 
-	publishState := new(dto.PublishRequest)
-	if err := c.BodyParser(publishState); err != nil {
+	hotspots := new(dto.FloorPlanHotspotsRequest)
+	if err := c.BodyParser(hotspots); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
 	}
 
-	if errs := b.validator.ValidateJSON(publishState); errs != nil {
+	if errs := b.validator.ValidateJSON(hotspots); errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidRequestBody.Error(),
 			Data:    errs,
 		})
 	}
 
-	if *publishState.IsPublished {
-		if errs, err := b.buildingService.ValidateBuilding(c.Context(), buildingID); err != nil {
-			return c.Status(fiber.StatusConflict).JSON(response.BaseResponse{
-				Message: err.Error(),
-				Data:    errs,
-			})
-		}
-	}
-
-	if err := b.buildingService.UpdateBuildingPublishState(c.Context(), publishState, buildingID); err != nil {
+	result, err := b.buildingService.UpdateFloorPlanHotspots(c.Context(), buildingID, floorPlanID, hotspots)
+	if err != nil {
 		switch err {
-		case err2.ErrBuildingNotFound:
+		case err2.ErrFloorPlanNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrFacilityNotFound:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
-		Message: "building publish state updated successfully",
+		Message: "floor plan hotspots updated successfully",
+		Data:    result,
 	})
 }
 
-func (b *BuildingController) DeleteBuildingPicture(c *fiber.Ctx) error {
+func (b *BuildingController) DeleteBuildingFloorPlan(c *fiber.Ctx) error {
 	buildingID := c.Params("buildingID")
-	pictureID := c.Params("pictureID")
+	floorPlanID := c.Params("floorPlanID")
 
-	if err := b.buildingService.DeleteBuildingPicture(c.Context(), buildingID, pictureID); err != nil {
+	if err := b.buildingService.DeleteBuildingFloorPlan(c.Context(), buildingID, floorPlanID); err != nil {
 		switch err {
-		case err2.ErrPictureNotFound:
+		case err2.ErrFloorPlanNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -408,42 +581,503 @@ func (b *BuildingController) DeleteBuildingPicture(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
-		Message: "building picture deleted successfully",
+		Message: "building floor plan deleted successfully",
 	})
 }
 
-func (b *BuildingController) DeleteBuildingFacility(c *fiber.Ctx) error {
+func (b *BuildingController) AddBuildingFacilities(c *fiber.Ctx) error {
 	buildingID := c.Params("buildingID")
-	facilityID := c.Params("facilityID")
-	facilityIDInt, err := strconv.Atoi(facilityID)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidFacilityID.Error())
+
+	facilities := new(dto.AddFacilitiesRequest)
+	if err := c.BodyParser(facilities); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := b.validator.ValidateVar(facilities, "required,dive"); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
 	}
 
-	if err := b.buildingService.DeleteBuildingFacility(c.Context(), buildingID, facilityIDInt); err != nil {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	editorID := claims["uid"].(string)
+
+	revision, err := b.buildingService.AddBuildingFacility(c.Context(), buildingID, facilities, editorID)
+	if err != nil {
 		switch err {
-		case err2.ErrFacilityNotFound:
+		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrInvalidCategoryID:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 	}
 
+	if revision != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.BaseResponse{
+			Message: "building facilities saved as draft revision",
+			Data:    revision,
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
-		Message: "building facility deleted successfully",
+		Message: "building facilities added successfully",
 	})
 }
 
-func (b *BuildingController) DeleteBuilding(c *fiber.Ctx) error {
+func (b *BuildingController) UpdateBuilding(c *fiber.Ctx) error {
 	buildingID := c.Params("buildingID")
 
-	if err := b.buildingService.DeleteBuilding(c.Context(), buildingID); err != nil {
+	building := new(dto.UpdateBuildingRequest)
+	if err := c.BodyParser(building); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := b.validator.ValidateJSON(building); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	if reflect.DeepEqual(*building, dto.UpdateBuildingRequest{}) {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	editorID := claims["uid"].(string)
+
+	revision, err := b.buildingService.UpdateBuilding(c.Context(), building, buildingID, editorID)
+	if err != nil {
 		switch err {
 		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
-		case err2.ErrBuildingHasReservation:
-			return fiber.NewError(fiber.StatusConflict, err.Error())
-		default:
+		case err2.ErrPictureNotFound:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err2.ErrFacilityNotFound:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err2.ErrInvalidDistrictID, err2.ErrDistrictNotInCity:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if revision != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.BaseResponse{
+			Message: "building changes saved as draft revision",
+			Data:    revision,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building updated successfully",
+	})
+}
+
+func (b *BuildingController) ImportBuildings(c *fiber.Ctx) error {
+	dryRun, err := strconv.ParseBool(c.Query("dryRun", "false"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	format, err := spreadsheet.Format(fileHeader.Filename)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+	defer file.Close()
+
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	creatorID := claims["uid"].(string)
+
+	result, err := b.buildingService.ImportBuildings(c.Context(), file, format, dryRun, creatorID)
+	if err != nil {
+		switch err {
+		case err2.ErrInvalidSpreadsheet, err2.ErrUnsupportedSpreadsheet:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err2.ErrImportRowLimitExceeded:
+			return fiber.NewError(fiber.StatusRequestEntityTooLarge, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		message := "spreadsheet has invalid rows, nothing was imported"
+		if result.Created+result.Updated > 0 {
+			message = "some rows failed to import"
+		}
+
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.BaseResponse{
+			Message: message,
+			Data:    result,
+		})
+	}
+
+	if dryRun {
+		return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+			Message: "spreadsheet is valid",
+			Data:    result,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "buildings imported successfully",
+		Data:    result,
+	})
+}
+
+func (b *BuildingController) ExportBuildings(c *fiber.Ctx) error {
+	format := c.Query("format", spreadsheet.XLSX)
+	if format != spreadsheet.CSV && format != spreadsheet.XLSX {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	content, err := b.buildingService.ExportBuildings(c.Context(), format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Attachment("buildings." + format)
+	c.Set(fiber.HeaderContentType, spreadsheet.ContentType(format))
+	return c.Status(fiber.StatusOK).Send(content)
+}
+
+func (b *BuildingController) GetBuildingRevisions(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	filter := new(dto.RevisionQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := b.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	revisions, total, err := b.buildingService.GetBuildingRevisions(c.Context(), buildingID, filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building revisions fetched successfully",
+		Data:    revisions,
+		Meta: fiber.Map{
+			"limit": filter.Limit,
+			"page":  filter.Page,
+			"total": total,
+		},
+	})
+}
+
+func (b *BuildingController) PreviewBuildingRevision(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+	revisionID := c.Params("revisionID")
+
+	preview, err := b.buildingService.PreviewBuildingRevision(c.Context(), buildingID, revisionID)
+	if err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound, err2.ErrRevisionNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building revision fetched successfully",
+		Data:    preview,
+	})
+}
+
+func (b *BuildingController) PublishBuildingRevision(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+	revisionID := c.Params("revisionID")
+
+	errs, err := b.buildingService.PublishBuildingRevision(c.Context(), buildingID, revisionID)
+	if err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound, err2.ErrRevisionNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrRevisionNotDraft:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case err2.ErrNotPublishWorthy:
+			return c.Status(fiber.StatusConflict).JSON(response.BaseResponse{
+				Message: err.Error(),
+				Data:    errs,
+			})
+		case err2.ErrInavalidCityID, err2.ErrInvalidDistrictID, err2.ErrInvalidCategoryID:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building revision published successfully",
+	})
+}
+
+func (b *BuildingController) DiscardBuildingRevision(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+	revisionID := c.Params("revisionID")
+
+	if err := b.buildingService.DiscardBuildingRevision(c.Context(), buildingID, revisionID); err != nil {
+		switch err {
+		case err2.ErrRevisionNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrRevisionNotDraft:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building revision discarded successfully",
+	})
+}
+
+func (b *BuildingController) RestoreBuildingRevision(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+	revisionID := c.Params("revisionID")
+
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	editorID := claims["uid"].(string)
+
+	revision, err := b.buildingService.RestoreBuildingRevision(c.Context(), buildingID, revisionID, editorID)
+	if err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound, err2.ErrRevisionNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrRevisionNotRestorable, err2.ErrRevisionNotDraft:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "building revision restored as draft successfully",
+		Data:    revision,
+	})
+}
+
+func (b *BuildingController) UpdateBuildingPublishState(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	publishState := new(dto.PublishRequest)
+	if err := c.BodyParser(publishState); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := b.validator.ValidateJSON(publishState); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	if *publishState.IsPublished {
+		if errs, err := b.buildingService.ValidateBuilding(c.Context(), buildingID); err != nil {
+			return c.Status(fiber.StatusConflict).JSON(response.BaseResponse{
+				Message: err.Error(),
+				Data:    errs,
+			})
+		}
+	}
+
+	if err := b.buildingService.UpdateBuildingPublishState(c.Context(), publishState, buildingID); err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building publish state updated successfully",
+	})
+}
+
+func (b *BuildingController) ScheduleBuildingPublishState(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	adminID := claims["uid"].(string)
+
+	schedule := new(dto.SchedulePublishRequest)
+	if err := c.BodyParser(schedule); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := b.validator.ValidateJSON(schedule); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	if err := b.buildingService.ScheduleBuildingPublishState(c.Context(), schedule, buildingID, adminID); err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrInvalidPublishSchedule:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building publish schedule updated successfully",
+	})
+}
+
+func (b *BuildingController) CancelBuildingPublishSchedule(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	if err := b.buildingService.CancelBuildingPublishSchedule(c.Context(), buildingID); err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building publish schedule cancelled successfully",
+	})
+}
+
+func (b *BuildingController) UpdateBuildingOpeningHours(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	hours := new(dto.OpeningHoursRequest)
+	if err := c.BodyParser(hours); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := b.validator.ValidateJSON(hours); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	if err := b.buildingService.UpdateBuildingOpeningHours(c.Context(), hours, buildingID); err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrInvalidOpeningHours, err2.ErrInvalidTimezone:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building opening hours updated successfully",
+	})
+}
+
+func (b *BuildingController) DeleteBuildingPicture(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+	pictureID := c.Params("pictureID")
+
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	editorID := claims["uid"].(string)
+
+	revision, err := b.buildingService.DeleteBuildingPicture(c.Context(), buildingID, pictureID, editorID)
+	if err != nil {
+		switch err {
+		case err2.ErrPictureNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if revision != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.BaseResponse{
+			Message: "building picture removal saved as draft revision",
+			Data:    revision,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building picture deleted successfully",
+	})
+}
+
+func (b *BuildingController) DeleteBuildingFacility(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+	facilityID := c.Params("facilityID")
+	facilityIDInt, err := strconv.Atoi(facilityID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidFacilityID.Error())
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	editorID := claims["uid"].(string)
+
+	revision, err := b.buildingService.DeleteBuildingFacility(c.Context(), buildingID, facilityIDInt, editorID)
+	if err != nil {
+		switch err {
+		case err2.ErrFacilityNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if revision != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.BaseResponse{
+			Message: "building facility removal saved as draft revision",
+			Data:    revision,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building facility deleted successfully",
+	})
+}
+
+func (b *BuildingController) DeleteBuilding(c *fiber.Ctx) error {
+	buildingID := c.Params("buildingID")
+
+	if err := b.buildingService.DeleteBuilding(c.Context(), buildingID); err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrBuildingHasReservation:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 	}
@@ -452,3 +1086,91 @@ func (b *BuildingController) DeleteBuilding(c *fiber.Ctx) error {
 		Message: "building deleted successfully",
 	})
 }
+
+// optionalUserID returns the id of the authenticated user, or an empty string for anonymous requests
+func optionalUserID(c *fiber.Ctx) string {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	userID, _ := claims["uid"].(string)
+	return userID
+}
+
+func (b *BuildingController) GetUserFavorites(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	filter := new(dto.FavoriteQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errs := b.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	buildings, total, err := b.buildingService.GetUserFavorites(c.Context(), userID, filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "favorite buildings fetched successfully",
+		Data:    buildings,
+		Meta: fiber.Map{
+			"limit": filter.Limit,
+			"page":  filter.Page,
+			"total": total,
+		},
+	})
+}
+
+func (b *BuildingController) AddFavorite(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	buildingID := c.Params("buildingID")
+	err := b.buildingService.AddFavorite(c.Context(), userID, buildingID)
+	if err != nil {
+		switch err {
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrFavoriteAlreadyExist:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "building added to favorites successfully",
+	})
+}
+
+func (b *BuildingController) RemoveFavorite(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	buildingID := c.Params("buildingID")
+	err := b.buildingService.RemoveFavorite(c.Context(), userID, buildingID)
+	if err != nil {
+		if errors.Is(err, err2.ErrFavoriteNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "building removed from favorites successfully",
+	})
+}
@@ -1,7 +1,15 @@
 package dto
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"office-booking-backend/pkg/custom"
 	"office-booking-backend/pkg/entity"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type AddFacilityRequest struct {
@@ -138,3 +146,684 @@ func (p *PublishRequest) ToEntity(buildingID string) *entity.Building {
 		IsPublished: p.IsPublished,
 	}
 }
+
+type SchedulePublishRequest struct {
+	PublishAt   *time.Time `json:"publishAt" validate:"required_without=UnpublishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt" validate:"required_without=PublishAt"`
+}
+
+func (s *SchedulePublishRequest) ToEntity(buildingID string, scheduledByID string) *entity.Building {
+	building := &entity.Building{
+		ID:            buildingID,
+		ScheduledByID: scheduledByID,
+	}
+
+	if s.PublishAt != nil {
+		building.PublishAt = sql.NullTime{Time: s.PublishAt.In(time.Local), Valid: true}
+	}
+
+	if s.UnpublishAt != nil {
+		building.UnpublishAt = sql.NullTime{Time: s.UnpublishAt.In(time.Local), Valid: true}
+	}
+
+	return building
+}
+
+// NewUpdateBuildingRequest returns an update request holding every editable field of the building,
+// it's stored as the snapshot of a published revision
+func NewUpdateBuildingRequest(building *entity.Building) *UpdateBuildingRequest {
+	request := &UpdateBuildingRequest{
+		Name:        building.Name,
+		Description: building.Description,
+		Facilities:  UpdateFacilitiesRequest{},
+		Pictures:    PicturesRequest{},
+		Capacity:    building.Capacity,
+		Size:        building.Size,
+		Prices: PriceRequest{
+			AnnualPrice:  building.AnnualPrice,
+			MonthlyPrice: building.MonthlyPrice,
+		},
+		Owner: building.Owner,
+		Locations: LocationRequest{
+			Address:    building.Address,
+			DistrictID: building.DistrictID,
+			CityID:     building.CityID,
+			Geo: Geo{
+				Longitude: building.Longitude,
+				Latitude:  building.Latitude,
+			},
+		},
+	}
+
+	for _, facility := range building.Facilities {
+		request.Facilities = append(request.Facilities, UpdateFacilityRequest{
+			ID:          facility.ID,
+			Name:        facility.Name,
+			IconID:      facility.CategoryID,
+			Description: facility.Description,
+		})
+	}
+
+	for _, picture := range building.Pictures {
+		request.Pictures = append(request.Pictures, PictureRequest{
+			Index:     *picture.Index,
+			PictureID: picture.ID,
+		})
+	}
+
+	return request
+}
+
+// Merge overwrites the fields set in the other request, facilities and pictures are merged by their id
+func (c *UpdateBuildingRequest) Merge(other *UpdateBuildingRequest) {
+	if other.Name != "" {
+		c.Name = other.Name
+	}
+	if other.Description != "" {
+		c.Description = other.Description
+	}
+	if other.Capacity != 0 {
+		c.Capacity = other.Capacity
+	}
+	if other.Size != 0 {
+		c.Size = other.Size
+	}
+	if other.Prices.AnnualPrice != 0 {
+		c.Prices.AnnualPrice = other.Prices.AnnualPrice
+	}
+	if other.Prices.MonthlyPrice != 0 {
+		c.Prices.MonthlyPrice = other.Prices.MonthlyPrice
+	}
+	if other.Owner != "" {
+		c.Owner = other.Owner
+	}
+	if other.Locations.Address != "" {
+		c.Locations.Address = other.Locations.Address
+	}
+	if other.Locations.CityID != 0 || other.Locations.DistrictID != 0 {
+		c.Locations.CityID = other.Locations.CityID
+		c.Locations.DistrictID = other.Locations.DistrictID
+	}
+	if other.Locations.Geo.Longitude != 0 {
+		c.Locations.Geo.Longitude = other.Locations.Geo.Longitude
+	}
+	if other.Locations.Geo.Latitude != 0 {
+		c.Locations.Geo.Latitude = other.Locations.Geo.Latitude
+	}
+
+	for _, facility := range other.Facilities {
+		merged := false
+		for i := range c.Facilities {
+			if c.Facilities[i].ID != facility.ID {
+				continue
+			}
+
+			if facility.Name != "" {
+				c.Facilities[i].Name = facility.Name
+			}
+			if facility.IconID != 0 {
+				c.Facilities[i].IconID = facility.IconID
+			}
+			if facility.Description != "" {
+				c.Facilities[i].Description = facility.Description
+			}
+			merged = true
+			break
+		}
+
+		if !merged {
+			c.Facilities = append(c.Facilities, facility)
+		}
+	}
+
+	for _, picture := range other.Pictures {
+		merged := false
+		for i := range c.Pictures {
+			if c.Pictures[i].PictureID == picture.PictureID {
+				c.Pictures[i].Index = picture.Index
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			c.Pictures = append(c.Pictures, picture)
+		}
+	}
+}
+
+// Apply returns a copy of the building with the request applied the same way the repository updates it,
+// empty fields are left untouched and unknown facilities or pictures are ignored
+func (c *UpdateBuildingRequest) Apply(building *entity.Building) *entity.Building {
+	updated := *building
+	updated.Facilities = append(entity.Facilities(nil), building.Facilities...)
+	updated.Pictures = append(entity.Pictures(nil), building.Pictures...)
+
+	if c.Name != "" {
+		updated.Name = c.Name
+	}
+	if c.Description != "" {
+		updated.Description = c.Description
+	}
+	if c.Capacity != 0 {
+		updated.Capacity = c.Capacity
+	}
+	if c.Size != 0 {
+		updated.Size = c.Size
+	}
+	if c.Prices.AnnualPrice != 0 {
+		updated.AnnualPrice = c.Prices.AnnualPrice
+	}
+	if c.Prices.MonthlyPrice != 0 {
+		updated.MonthlyPrice = c.Prices.MonthlyPrice
+	}
+	if c.Owner != "" {
+		updated.Owner = c.Owner
+	}
+	if c.Locations.Address != "" {
+		updated.Address = c.Locations.Address
+	}
+	if c.Locations.CityID != 0 {
+		updated.CityID = c.Locations.CityID
+	}
+	if c.Locations.DistrictID != 0 {
+		updated.DistrictID = c.Locations.DistrictID
+	}
+	if c.Locations.Geo.Longitude != 0 {
+		updated.Longitude = c.Locations.Geo.Longitude
+	}
+	if c.Locations.Geo.Latitude != 0 {
+		updated.Latitude = c.Locations.Geo.Latitude
+	}
+
+	for _, facility := range c.Facilities {
+		for i := range updated.Facilities {
+			if updated.Facilities[i].ID != facility.ID {
+				continue
+			}
+
+			if facility.Name != "" {
+				updated.Facilities[i].Name = facility.Name
+			}
+			if facility.IconID != 0 {
+				updated.Facilities[i].CategoryID = facility.IconID
+			}
+			if facility.Description != "" {
+				updated.Facilities[i].Description = facility.Description
+			}
+		}
+	}
+
+	for _, picture := range c.Pictures {
+		for i := range updated.Pictures {
+			if updated.Pictures[i].ID == picture.PictureID {
+				index := picture.Index
+				updated.Pictures[i].Index = &index
+			}
+		}
+	}
+
+	sort.SliceStable(updated.Pictures, func(i, j int) bool {
+		return *updated.Pictures[i].Index < *updated.Pictures[j].Index
+	})
+
+	return &updated
+}
+
+// RevisionPicture is a picture uploaded while the building is published, it's added to the building
+// when the revision is published
+type RevisionPicture struct {
+	ID           string `json:"id"`
+	Index        int    `json:"index"`
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnailUrl"`
+	Alt          string `json:"alt"`
+	Key          string `json:"key"`
+}
+
+func NewRevisionPicture(picture *entity.Picture) *RevisionPicture {
+	return &RevisionPicture{
+		ID:           picture.ID,
+		Index:        *picture.Index,
+		Url:          picture.Url,
+		ThumbnailUrl: picture.ThumbnailUrl,
+		Alt:          picture.Alt,
+		Key:          picture.Key,
+	}
+}
+
+func (p *RevisionPicture) ToEntity(buildingID string) *entity.Picture {
+	index := p.Index
+	return &entity.Picture{
+		ID:           p.ID,
+		BuildingID:   buildingID,
+		Index:        &index,
+		Url:          p.Url,
+		ThumbnailUrl: p.ThumbnailUrl,
+		Alt:          p.Alt,
+		Key:          p.Key,
+	}
+}
+
+type RevisionPictures []RevisionPicture
+
+func (p *RevisionPictures) ToEntity(buildingID string) *entity.Pictures {
+	pictures := entity.Pictures{}
+	for _, picture := range *p {
+		pictures = append(pictures, *picture.ToEntity(buildingID))
+	}
+	return &pictures
+}
+
+// RevisionChanges is the content of a draft revision, the updated fields plus the facilities and pictures
+// added or removed while the building is published
+type RevisionChanges struct {
+	UpdateBuildingRequest
+	AddedFacilities   AddFacilitiesRequest `json:"addedFacilities,omitempty"`
+	RemovedFacilities []int                `json:"removedFacilities,omitempty"`
+	AddedPictures     RevisionPictures     `json:"addedPictures,omitempty"`
+	RemovedPictures   []string             `json:"removedPictures,omitempty"`
+}
+
+// Merge adds the other changes on top of these ones
+func (c *RevisionChanges) Merge(other *RevisionChanges) {
+	c.UpdateBuildingRequest.Merge(&other.UpdateBuildingRequest)
+	c.AddedFacilities = append(c.AddedFacilities, other.AddedFacilities...)
+	c.RemovedFacilities = append(c.RemovedFacilities, other.RemovedFacilities...)
+	c.AddedPictures = append(c.AddedPictures, other.AddedPictures...)
+	c.RemovedPictures = append(c.RemovedPictures, other.RemovedPictures...)
+}
+
+// Apply returns a copy of the building with the changes applied the same way they're published,
+// added pictures can be removed by a later change of the same draft
+func (c *RevisionChanges) Apply(building *entity.Building) *entity.Building {
+	updated := c.UpdateBuildingRequest.Apply(building)
+
+	removedFacilities := map[int]bool{}
+	for _, id := range c.RemovedFacilities {
+		removedFacilities[id] = true
+	}
+
+	facilities := entity.Facilities{}
+	for _, facility := range updated.Facilities {
+		if !removedFacilities[facility.ID] {
+			facilities = append(facilities, facility)
+		}
+	}
+	facilities = append(facilities, *c.AddedFacilities.ToEntity(building.ID)...)
+	updated.Facilities = facilities
+
+	removedPictures := map[string]bool{}
+	for _, id := range c.RemovedPictures {
+		removedPictures[id] = true
+	}
+
+	pictures := entity.Pictures{}
+	for _, picture := range append(updated.Pictures, *c.AddedPictures.ToEntity(building.ID)...) {
+		if !removedPictures[picture.ID] {
+			pictures = append(pictures, picture)
+		}
+	}
+	sort.SliceStable(pictures, func(i, j int) bool {
+		return *pictures[i].Index < *pictures[j].Index
+	})
+	updated.Pictures = pictures
+
+	return updated
+}
+
+// BuildingSheetColumns are the columns of the building import and export spreadsheet,
+// is_published is only exported
+var BuildingSheetColumns = []string{
+	"id", "name", "description", "capacity", "size", "annual_price", "monthly_price", "owner",
+	"city_id", "district_id", "address", "latitude", "longitude", "facilities", "pictures", "is_published",
+}
+
+type ImportFacility struct {
+	CategoryID  int
+	Name        string
+	Description string
+}
+
+// BuildingImportRow is a spreadsheet row, an empty id creates a new building and
+// empty cells keep the current value of an existing building
+type BuildingImportRow struct {
+	Row          int
+	ID           string
+	Name         string
+	Description  string
+	Capacity     int
+	Size         int
+	AnnualPrice  int
+	MonthlyPrice int
+	Owner        string
+	CityID       int
+	DistrictID   int
+	Address      string
+	Latitude     float64
+	Longitude    float64
+	Facilities   []ImportFacility
+	PictureURLs  []string
+}
+
+// ParseBuildingSheet reads the rows below the header row, the columns are matched by name
+func ParseBuildingSheet(rows [][]string) ([]BuildingImportRow, ImportErrors) {
+	errs := ImportErrors{}
+	if len(rows) == 0 {
+		return nil, errs
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["name"]; !ok {
+		errs = append(errs, ImportError{Row: 1, Column: "name", Reason: "column is required"})
+		return nil, errs
+	}
+
+	importRows := make([]BuildingImportRow, 0, len(rows)-1)
+	for i, cells := range rows[1:] {
+		row := BuildingImportRow{Row: i + 2}
+		cell := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(cells) {
+				return ""
+			}
+			return strings.TrimSpace(cells[index])
+		}
+		number := func(column string) int {
+			value := cell(column)
+			if value == "" {
+				return 0
+			}
+
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, ImportError{Row: row.Row, Column: column, Reason: "must be a whole number"})
+			}
+			return parsed
+		}
+		decimal := func(column string) float64 {
+			value := cell(column)
+			if value == "" {
+				return 0
+			}
+
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, ImportError{Row: row.Row, Column: column, Reason: "must be a number"})
+			}
+			return parsed
+		}
+
+		if strings.TrimSpace(strings.Join(cells, "")) == "" {
+			continue
+		}
+
+		row.ID = cell("id")
+		row.Name = cell("name")
+		row.Description = cell("description")
+		row.Capacity = number("capacity")
+		row.Size = number("size")
+		row.AnnualPrice = number("annual_price")
+		row.MonthlyPrice = number("monthly_price")
+		row.Owner = cell("owner")
+		row.CityID = number("city_id")
+		row.DistrictID = number("district_id")
+		row.Address = cell("address")
+		row.Latitude = decimal("latitude")
+		row.Longitude = decimal("longitude")
+		row.PictureURLs = strings.Fields(cell("pictures"))
+
+		// facilities are written one per line as "category id | name | description"
+		for _, line := range strings.Split(cell("facilities"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			parts := strings.SplitN(line, "|", 3)
+			categoryID, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+			if err != nil || len(parts) < 2 {
+				errs = append(errs, ImportError{Row: row.Row, Column: "facilities", Reason: "must be written as \"category id | name | description\""})
+				continue
+			}
+
+			facility := ImportFacility{CategoryID: categoryID, Name: strings.TrimSpace(parts[1])}
+			if len(parts) == 3 {
+				facility.Description = strings.TrimSpace(parts[2])
+			}
+			row.Facilities = append(row.Facilities, facility)
+		}
+
+		importRows = append(importRows, row)
+	}
+
+	return importRows, errs
+}
+
+// ToUpdateRequest returns the building fields of the row, facilities and pictures are added separately
+func (r *BuildingImportRow) ToUpdateRequest() *UpdateBuildingRequest {
+	return &UpdateBuildingRequest{
+		Name:        r.Name,
+		Description: r.Description,
+		Capacity:    r.Capacity,
+		Size:        r.Size,
+		Prices: PriceRequest{
+			AnnualPrice:  r.AnnualPrice,
+			MonthlyPrice: r.MonthlyPrice,
+		},
+		Owner: r.Owner,
+		Locations: LocationRequest{
+			Address:    r.Address,
+			DistrictID: r.DistrictID,
+			CityID:     r.CityID,
+			Geo: Geo{
+				Longitude: r.Longitude,
+				Latitude:  r.Latitude,
+			},
+		},
+	}
+}
+
+func (r *BuildingImportRow) ToEntity(creatorID string) *entity.Building {
+	building := &entity.Building{
+		Name:         r.Name,
+		Description:  r.Description,
+		Capacity:     r.Capacity,
+		Size:         r.Size,
+		AnnualPrice:  r.AnnualPrice,
+		MonthlyPrice: r.MonthlyPrice,
+		Owner:        r.Owner,
+		CityID:       r.CityID,
+		DistrictID:   r.DistrictID,
+		Address:      r.Address,
+		Latitude:     r.Latitude,
+		Longitude:    r.Longitude,
+		CreatedByID:  creatorID,
+		IsPublished:  new(bool),
+	}
+
+	for _, facility := range r.Facilities {
+		building.Facilities = append(building.Facilities, entity.Facility{
+			CategoryID:  facility.CategoryID,
+			Name:        facility.Name,
+			Description: facility.Description,
+		})
+	}
+
+	for i, url := range r.PictureURLs {
+		index := i
+		building.Pictures = append(building.Pictures, entity.Picture{
+			Index: &index,
+			Url:   url,
+		})
+	}
+
+	return building
+}
+
+// NewBuildingSheet returns the rows of the building export, it can be imported back
+func NewBuildingSheet(buildings *entity.Buildings) [][]string {
+	rows := [][]string{BuildingSheetColumns}
+	for _, building := range *buildings {
+		facilities := make([]string, 0, len(building.Facilities))
+		for _, facility := range building.Facilities {
+			facilities = append(facilities, fmt.Sprintf("%d | %s | %s", facility.CategoryID, facility.Name, facility.Description))
+		}
+
+		pictures := make([]string, 0, len(building.Pictures))
+		for _, picture := range building.Pictures {
+			pictures = append(pictures, picture.Url)
+		}
+
+		rows = append(rows, []string{
+			building.ID,
+			building.Name,
+			building.Description,
+			strconv.Itoa(building.Capacity),
+			strconv.Itoa(building.Size),
+			strconv.Itoa(building.AnnualPrice),
+			strconv.Itoa(building.MonthlyPrice),
+			building.Owner,
+			strconv.Itoa(building.CityID),
+			strconv.Itoa(building.DistrictID),
+			building.Address,
+			strconv.FormatFloat(building.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(building.Longitude, 'f', -1, 64),
+			strings.Join(facilities, "\n"),
+			strings.Join(pictures, "\n"),
+			strconv.FormatBool(building.IsPublished != nil && *building.IsPublished),
+		})
+	}
+	return rows
+}
+
+// importColumns maps the validated fields of FullBuildingResponse to the spreadsheet columns
+var importColumns = []struct {
+	prefix string
+	column string
+}{
+	{"price.annual", "annual_price"},
+	{"price.monthly", "monthly_price"},
+	{"price", "annual_price"},
+	{"location.city", "city_id"},
+	{"location.district", "district_id"},
+	{"location.geo.lat", "latitude"},
+	{"location.geo.long", "longitude"},
+	{"location", "address"},
+	{"facilities", "facilities"},
+	{"pictures", "pictures"},
+}
+
+// ImportColumn returns the spreadsheet column of a validation error namespace
+func ImportColumn(namespace string) string {
+	// drop the struct name
+	if i := strings.Index(namespace, "."); i >= 0 {
+		namespace = namespace[i+1:]
+	}
+
+	for _, column := range importColumns {
+		if strings.HasPrefix(namespace, column.prefix) {
+			return column.column
+		}
+	}
+	return namespace
+}
+
+type OpeningPeriodRequest struct {
+	Weekday int    `json:"weekday" validate:"gte=0,lte=6"`
+	Open    string `json:"open" validate:"required,len=5"`
+	Close   string `json:"close" validate:"required,len=5"`
+}
+
+type OpeningExceptionRequest struct {
+	Date     custom.Date `json:"date" validate:"required"`
+	IsClosed bool        `json:"isClosed"`
+	Open     string      `json:"open" validate:"required_without=IsClosed,omitempty,len=5"`
+	Close    string      `json:"close" validate:"required_without=IsClosed,omitempty,len=5"`
+	Note     string      `json:"note" validate:"omitempty,max=255"`
+}
+
+// OpeningHoursRequest replaces the whole opening hours of a building, empty weekly hours remove the restriction
+type OpeningHoursRequest struct {
+	Timezone   string                    `json:"timezone" validate:"omitempty,timezone"`
+	Weekly     []OpeningPeriodRequest    `json:"weekly" validate:"dive"`
+	Exceptions []OpeningExceptionRequest `json:"exceptions" validate:"dive"`
+}
+
+func (o *OpeningHoursRequest) ToEntity(buildingID string) *entity.Building {
+	building := &entity.Building{
+		ID:                buildingID,
+		Timezone:          o.Timezone,
+		OpeningHours:      entity.OpeningHours{},
+		OpeningExceptions: entity.OpeningExceptions{},
+	}
+
+	for _, period := range o.Weekly {
+		building.OpeningHours = append(building.OpeningHours, entity.OpeningHour{
+			BuildingID: buildingID,
+			Weekday:    time.Weekday(period.Weekday),
+			OpenTime:   period.Open,
+			CloseTime:  period.Close,
+		})
+	}
+
+	for _, exception := range o.Exceptions {
+		isClosed := exception.IsClosed
+		openingException := entity.OpeningException{
+			BuildingID: buildingID,
+			Date:       exception.Date.ToTime(),
+			IsClosed:   &isClosed,
+			Note:       exception.Note,
+		}
+
+		if !isClosed {
+			openingException.OpenTime = exception.Open
+			openingException.CloseTime = exception.Close
+		}
+		building.OpeningExceptions = append(building.OpeningExceptions, openingException)
+	}
+
+	return building
+}
+
+type FloorPlanHotspotRequest struct {
+	Name       string `json:"name" validate:"required,max=100"`
+	FacilityID int    `json:"facilityId" validate:"omitempty,gte=1"`
+	// Polygon is a list of [x, y] points relative to the image size, from 0 to 1
+	Polygon [][]float64 `json:"polygon" validate:"min=3,max=100,dive,len=2,dive,gte=0,lte=1"`
+}
+
+// FloorPlanHotspotsRequest replaces every hotspot of a floor plan
+type FloorPlanHotspotsRequest struct {
+	Hotspots []FloorPlanHotspotRequest `json:"hotspots" validate:"max=100,dive"`
+}
+
+// FacilityIDs returns the distinct facilities linked by the hotspots
+func (f *FloorPlanHotspotsRequest) FacilityIDs() []int {
+	seen := map[int]bool{}
+	ids := []int{}
+	for _, hotspot := range f.Hotspots {
+		if hotspot.FacilityID != 0 && !seen[hotspot.FacilityID] {
+			seen[hotspot.FacilityID] = true
+			ids = append(ids, hotspot.FacilityID)
+		}
+	}
+	return ids
+}
+
+func (f *FloorPlanHotspotsRequest) ToEntity(floorPlanID string) *entity.FloorPlanHotspots {
+	hotspots := entity.FloorPlanHotspots{}
+	for _, hotspot := range f.Hotspots {
+		polygon, _ := json.Marshal(hotspot.Polygon)
+		hotspots = append(hotspots, entity.FloorPlanHotspot{
+			FloorPlanID: floorPlanID,
+			Name:        hotspot.Name,
+			FacilityID:  hotspot.FacilityID,
+			Polygon:     string(polygon),
+		})
+	}
+	return &hotspots
+}
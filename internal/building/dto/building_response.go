@@ -1,18 +1,32 @@
 package dto
 
 import (
+	"database/sql"
+	"encoding/json"
+	"math"
 	"office-booking-backend/pkg/constant"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/geo"
+	"office-booking-backend/pkg/utils/openinghours"
+	"office-booking-backend/pkg/utils/timeseries"
+	"reflect"
+	"sort"
+	"time"
 )
 
 type BriefPublishedBuildingResponse struct {
-	ID       string    `json:"id"`
-	Name     string    `json:"name"`
-	Pictures string    `json:"pictures"`
-	Review   *Review   `json:"review"`
-	Prices   *Price    `json:"price"`
-	Owner    string    `json:"owner"`
-	Location *Location `json:"location"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Pictures   string    `json:"pictures"`
+	Review     *Review   `json:"review"`
+	Prices     *Price    `json:"price"`
+	Owner      string    `json:"owner"`
+	Location   *Location `json:"location"`
+	IsFavorite bool      `json:"isFavorite"`
+	// Distance is the distance in km to the requested point
+	Distance *float64 `json:"distance,omitempty"`
+	// Highlights contains the fields matching the full-text query
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
 
 func NewBriefPublishedBuildingResponse(building *entity.Building) *BriefPublishedBuildingResponse {
@@ -161,6 +175,49 @@ func NewPictures(pictures *entity.Pictures) *Pictures {
 type Review struct {
 	Rating float64 `json:"rating"`
 	Count  int     `json:"count"`
+	// Breakdown is only returned on the building detail
+	Breakdown *RatingBreakdownResponse `json:"breakdown,omitempty"`
+}
+
+// SubRatingsResponse are the average sub-ratings, an aspect nobody rated is null
+type SubRatingsResponse struct {
+	Location     *float64 `json:"location"`
+	Cleanliness  *float64 `json:"cleanliness"`
+	Facilities   *float64 `json:"facilities"`
+	Value        *float64 `json:"value"`
+	Connectivity *float64 `json:"connectivity"`
+}
+
+type RatingBreakdownResponse struct {
+	SubRatings SubRatingsResponse `json:"subRatings"`
+	// Histogram is the number of reviews by star, from 1 to 5
+	Histogram map[int]int64 `json:"histogram"`
+}
+
+func NewRatingBreakdownResponse(breakdown *entity.RatingBreakdown) *RatingBreakdownResponse {
+	average := func(avg sql.NullFloat64) *float64 {
+		if !avg.Valid {
+			return nil
+		}
+		return &avg.Float64
+	}
+
+	return &RatingBreakdownResponse{
+		SubRatings: SubRatingsResponse{
+			Location:     average(breakdown.Location),
+			Cleanliness:  average(breakdown.Cleanliness),
+			Facilities:   average(breakdown.Facilities),
+			Value:        average(breakdown.Value),
+			Connectivity: average(breakdown.Connectivity),
+		},
+		Histogram: map[int]int64{
+			1: breakdown.OneStar,
+			2: breakdown.TwoStars,
+			3: breakdown.ThreeStars,
+			4: breakdown.FourStars,
+			5: breakdown.FiveStars,
+		},
+	}
 }
 
 type Location struct {
@@ -228,6 +285,8 @@ type FullPublishedBuildingResponse struct {
 	Owner        string                     `json:"owner"`
 	Locations    *FullLocation              `json:"location"`
 	Agent        *Agent                     `json:"agent"`
+	IsFavorite   bool                       `json:"isFavorite"`
+	OpeningHours *OpeningHoursResponse      `json:"openingHours,omitempty"`
 }
 
 func NewFullPublishedBuildingResponse(building *entity.Building) *FullPublishedBuildingResponse {
@@ -258,25 +317,29 @@ func NewFullPublishedBuildingResponse(building *entity.Building) *FullPublishedB
 				Latitude:  building.Latitude,
 			},
 		},
-		Agent: NewAgent(&building.CreatedBy),
+		Agent:        NewAgent(&building.CreatedBy),
+		OpeningHours: NewOpeningHoursResponse(building),
 	}
 }
 
 type FullBuildingResponse struct {
-	ID           string                     `json:"id" validate:"required,uuid"`
-	Name         string                     `json:"name" validate:"required,min=3,max=100"`
-	Pictures     *Pictures                  `json:"pictures" validate:"required,min=1,dive"`
-	Description  string                     `json:"description" validate:"required,min=3,max=10000"`
-	Facilities   *Facilities                `json:"facilities" validate:"required,min=1,dive"`
-	Capacity     int                        `json:"capacity" validate:"required,min=1"`
-	Size         int                        `json:"size" validate:"required,gte=1"`
-	Reservations *BriefReservationsResponse `json:"reservations,omitempty"`
-	Review       *Review                    `json:"review"`
-	Prices       *Price                     `json:"price" validate:"required,dive"`
-	Owner        string                     `json:"owner" validate:"required"`
-	Locations    *FullLocation              `json:"location" validate:"required,dive"`
-	Agent        *Agent                     `json:"agent,omitempty"`
-	IsPublished  bool                       `json:"isPublished" `
+	ID            string                     `json:"id" validate:"required,uuid"`
+	Name          string                     `json:"name" validate:"required,min=3,max=100"`
+	Pictures      *Pictures                  `json:"pictures" validate:"required,min=1,dive"`
+	Description   string                     `json:"description" validate:"required,min=3,max=10000"`
+	Facilities    *Facilities                `json:"facilities" validate:"required,min=1,dive"`
+	Capacity      int                        `json:"capacity" validate:"required,min=1"`
+	Size          int                        `json:"size" validate:"required,gte=1"`
+	Reservations  *BriefReservationsResponse `json:"reservations,omitempty"`
+	Review        *Review                    `json:"review"`
+	Prices        *Price                     `json:"price" validate:"required,dive"`
+	Owner         string                     `json:"owner" validate:"required"`
+	Locations     *FullLocation              `json:"location" validate:"required,dive"`
+	Agent         *Agent                     `json:"agent,omitempty"`
+	IsPublished   bool                       `json:"isPublished" `
+	Schedule      *PublishSchedule           `json:"schedule,omitempty"`
+	OpeningHours  *OpeningHoursResponse      `json:"openingHours,omitempty"`
+	FavoriteCount int64                      `json:"favoriteCount"`
 }
 
 func NewFullBuildingResponse(building *entity.Building) *FullBuildingResponse {
@@ -307,9 +370,362 @@ func NewFullBuildingResponse(building *entity.Building) *FullBuildingResponse {
 				Latitude:  building.Latitude,
 			},
 		},
-		Agent:       NewAgent(&building.CreatedBy),
-		IsPublished: *building.IsPublished,
+		Agent:        NewAgent(&building.CreatedBy),
+		IsPublished:  *building.IsPublished,
+		Schedule:     NewPublishSchedule(building),
+		OpeningHours: NewOpeningHoursResponse(building),
+	}
+}
+
+type PublishSchedule struct {
+	PublishAt   string `json:"publishAt,omitempty"`
+	UnpublishAt string `json:"unpublishAt,omitempty"`
+}
+
+func NewPublishSchedule(building *entity.Building) *PublishSchedule {
+	if !building.PublishAt.Valid && !building.UnpublishAt.Valid {
+		return nil
+	}
+
+	schedule := new(PublishSchedule)
+	if building.PublishAt.Valid {
+		schedule.PublishAt = building.PublishAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+	if building.UnpublishAt.Valid {
+		schedule.UnpublishAt = building.UnpublishAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+	return schedule
+}
+
+type ComparedBuilding struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Pictures string    `json:"pictures"`
+	Location *Location `json:"location"`
+}
+
+// ComparisonRow is one compared attribute, Normalized goes from 0 for the worst to 1 for the best value
+// and a nil value means the attribute doesn't apply to the building
+type ComparisonRow struct {
+	Key        string     `json:"key"`
+	Values     []*float64 `json:"values"`
+	Normalized []*float64 `json:"normalized"`
+	Best       []int      `json:"best"`
+}
+
+type ComparedFacility struct {
+	CategoryID int    `json:"categoryId"`
+	Name       string `json:"name"`
+	Icon       string `json:"icon"`
+	Values     []bool `json:"values"`
+}
+
+// BuildingComparisonResponse has one column per building, in the requested order
+type BuildingComparisonResponse struct {
+	Buildings  []ComparedBuilding `json:"buildings"`
+	Rows       []ComparisonRow    `json:"rows"`
+	Facilities []ComparedFacility `json:"facilities"`
+	// Distances is the distance in km between every pair of buildings
+	Distances [][]float64 `json:"distances"`
+	// Availability is only set when a date range is requested
+	Availability []bool `json:"availability,omitempty"`
+}
+
+// comparisonAttributes are the compared numbers, lowerIsBetter marks the costs
+var comparisonAttributes = []struct {
+	key           string
+	lowerIsBetter bool
+	value         func(building *entity.Building) *float64
+}{
+	{"monthlyPrice", true, func(b *entity.Building) *float64 { return positive(float64(b.MonthlyPrice)) }},
+	{"annualPrice", true, func(b *entity.Building) *float64 { return positive(float64(b.AnnualPrice)) }},
+	{"capacity", false, func(b *entity.Building) *float64 { return positive(float64(b.Capacity)) }},
+	{"size", false, func(b *entity.Building) *float64 { return positive(float64(b.Size)) }},
+	{"monthlyPricePerSqm", true, func(b *entity.Building) *float64 { return per(b.MonthlyPrice, b.Size) }},
+	{"annualPricePerSqm", true, func(b *entity.Building) *float64 { return per(b.AnnualPrice, b.Size) }},
+	{"monthlyPricePerSeat", true, func(b *entity.Building) *float64 { return per(b.MonthlyPrice, b.Capacity) }},
+	{"annualPricePerSeat", true, func(b *entity.Building) *float64 { return per(b.AnnualPrice, b.Capacity) }},
+	{"rating", false, func(b *entity.Building) *float64 { return number(b.Rating) }},
+	{"reviewCount", false, func(b *entity.Building) *float64 { return number(float64(b.ReviewCount)) }},
+}
+
+func number(value float64) *float64 {
+	return &value
+}
+
+func positive(value float64) *float64 {
+	if value <= 0 {
+		return nil
 	}
+	return &value
+}
+
+func per(price int, unit int) *float64 {
+	if price <= 0 || unit <= 0 {
+		return nil
+	}
+	value := math.Round(float64(price)/float64(unit)*100) / 100
+	return &value
+}
+
+// NewBuildingComparisonResponse compares the buildings in the given order, availability can be nil
+func NewBuildingComparisonResponse(buildings []*entity.Building, availability []bool) *BuildingComparisonResponse {
+	response := &BuildingComparisonResponse{
+		Buildings:    make([]ComparedBuilding, 0, len(buildings)),
+		Rows:         make([]ComparisonRow, 0, len(comparisonAttributes)),
+		Facilities:   []ComparedFacility{},
+		Distances:    make([][]float64, len(buildings)),
+		Availability: availability,
+	}
+
+	for _, building := range buildings {
+		brief := NewBriefPublishedBuildingResponse(building)
+		response.Buildings = append(response.Buildings, ComparedBuilding{
+			ID:       building.ID,
+			Name:     building.Name,
+			Pictures: brief.Pictures,
+			Location: brief.Location,
+		})
+	}
+
+	for _, attribute := range comparisonAttributes {
+		values := make([]*float64, len(buildings))
+		for i, building := range buildings {
+			values[i] = attribute.value(building)
+		}
+		response.Rows = append(response.Rows, newComparisonRow(attribute.key, values, attribute.lowerIsBetter))
+	}
+
+	// facilities are compared by category, in the order they first appear
+	categories := map[int]int{}
+	for i, building := range buildings {
+		for _, facility := range building.Facilities {
+			index, ok := categories[facility.CategoryID]
+			if !ok {
+				index = len(response.Facilities)
+				categories[facility.CategoryID] = index
+				response.Facilities = append(response.Facilities, ComparedFacility{
+					CategoryID: facility.CategoryID,
+					Name:       facility.Category.Name,
+					Icon:       facility.Category.Url,
+					Values:     make([]bool, len(buildings)),
+				})
+			}
+			response.Facilities[index].Values[i] = true
+		}
+	}
+
+	for i, from := range buildings {
+		response.Distances[i] = make([]float64, len(buildings))
+		for j, to := range buildings {
+			distance := geo.Haversine(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+			response.Distances[i][j] = math.Round(distance*100) / 100
+		}
+	}
+
+	return response
+}
+
+func newComparisonRow(key string, values []*float64, lowerIsBetter bool) ComparisonRow {
+	row := ComparisonRow{
+		Key:        key,
+		Values:     values,
+		Normalized: make([]*float64, len(values)),
+		Best:       []int{},
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if value != nil {
+			min = math.Min(min, *value)
+			max = math.Max(max, *value)
+		}
+	}
+
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		normalized := 1.0
+		if max > min {
+			normalized = (*value - min) / (max - min)
+			if lowerIsBetter {
+				normalized = 1 - normalized
+			}
+			normalized = math.Round(normalized*1000) / 1000
+		}
+
+		row.Normalized[i] = &normalized
+		if normalized == 1 {
+			row.Best = append(row.Best, i)
+		}
+	}
+
+	return row
+}
+
+type FloorPlanHotspotResponse struct {
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	Facility *Facility   `json:"facility,omitempty"`
+	Polygon  [][]float64 `json:"polygon"`
+}
+
+type FloorPlanResponse struct {
+	ID           string                     `json:"id"`
+	Floor        int                        `json:"floor"`
+	Name         string                     `json:"name"`
+	Url          string                     `json:"url"`
+	ThumbnailUrl string                     `json:"thumbnailUrl"`
+	Width        int                        `json:"width"`
+	Height       int                        `json:"height"`
+	Hotspots     []FloorPlanHotspotResponse `json:"hotspots"`
+}
+
+func NewFloorPlanResponse(floorPlan *entity.FloorPlan) *FloorPlanResponse {
+	response := &FloorPlanResponse{
+		ID:           floorPlan.ID,
+		Floor:        floorPlan.Floor,
+		Name:         floorPlan.Name,
+		Url:          floorPlan.Url,
+		ThumbnailUrl: floorPlan.ThumbnailUrl,
+		Width:        floorPlan.Width,
+		Height:       floorPlan.Height,
+		Hotspots:     make([]FloorPlanHotspotResponse, 0, len(floorPlan.Hotspots)),
+	}
+
+	for _, hotspot := range floorPlan.Hotspots {
+		polygon := [][]float64{}
+		_ = json.Unmarshal([]byte(hotspot.Polygon), &polygon)
+
+		hotspotResponse := FloorPlanHotspotResponse{
+			ID:      hotspot.ID,
+			Name:    hotspot.Name,
+			Polygon: polygon,
+		}
+
+		if hotspot.FacilityID != 0 {
+			hotspotResponse.Facility = NewFacility(&hotspot.Facility)
+		}
+
+		response.Hotspots = append(response.Hotspots, hotspotResponse)
+	}
+
+	return response
+}
+
+type FloorPlansResponse []FloorPlanResponse
+
+func NewFloorPlansResponse(floorPlans *entity.FloorPlans) *FloorPlansResponse {
+	response := FloorPlansResponse{}
+	for _, floorPlan := range *floorPlans {
+		response = append(response, *NewFloorPlanResponse(&floorPlan))
+	}
+	return &response
+}
+
+type OpeningPeriod struct {
+	Weekday int    `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+type OpeningExceptionResponse struct {
+	Date     string `json:"date"`
+	IsClosed bool   `json:"isClosed"`
+	Open     string `json:"open,omitempty"`
+	Close    string `json:"close,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+type OpeningHoursResponse struct {
+	Timezone         string                     `json:"timezone"`
+	IsOpenNow        bool                       `json:"isOpenNow"`
+	IsAlwaysOpen     bool                       `json:"isAlwaysOpen"`
+	IsOpenOnWeekends bool                       `json:"isOpenOnWeekends"`
+	Weekly           []OpeningPeriod            `json:"weekly"`
+	Exceptions       []OpeningExceptionResponse `json:"exceptions"`
+}
+
+// NewOpeningHoursResponse returns nil when the building has no opening hours, it's open whenever it's booked
+func NewOpeningHoursResponse(building *entity.Building) *OpeningHoursResponse {
+	if len(building.OpeningHours) == 0 {
+		return nil
+	}
+
+	schedule, err := NewOpeningSchedule(building)
+	if err != nil {
+		return nil
+	}
+
+	response := &OpeningHoursResponse{
+		Timezone:         schedule.Location.String(),
+		IsOpenNow:        schedule.IsOpenAt(time.Now()),
+		IsAlwaysOpen:     schedule.IsAlwaysOpen(),
+		IsOpenOnWeekends: schedule.IsOpenOnWeekends(),
+		Weekly:           make([]OpeningPeriod, 0, len(building.OpeningHours)),
+		Exceptions:       make([]OpeningExceptionResponse, 0, len(building.OpeningExceptions)),
+	}
+
+	for _, hour := range building.OpeningHours {
+		response.Weekly = append(response.Weekly, OpeningPeriod{
+			Weekday: int(hour.Weekday),
+			Open:    hour.OpenTime,
+			Close:   hour.CloseTime,
+		})
+	}
+
+	for _, exception := range building.OpeningExceptions {
+		response.Exceptions = append(response.Exceptions, OpeningExceptionResponse{
+			Date:     exception.Date.Format(openinghours.DateFormat),
+			IsClosed: exception.IsClosed != nil && *exception.IsClosed,
+			Open:     exception.OpenTime,
+			Close:    exception.CloseTime,
+			Note:     exception.Note,
+		})
+	}
+
+	return response
+}
+
+// NewOpeningSchedule returns the opening schedule of a building, it fails when the stored hours are invalid
+func NewOpeningSchedule(building *entity.Building) (*openinghours.Schedule, error) {
+	schedule, err := openinghours.NewSchedule(building.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hour := range building.OpeningHours {
+		period, err := openinghours.NewPeriod(hour.OpenTime, hour.CloseTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := schedule.AddWeekly(hour.Weekday, period); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, exception := range building.OpeningExceptions {
+		if exception.IsClosed != nil && *exception.IsClosed {
+			if err := schedule.AddException(exception.Date, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		period, err := openinghours.NewPeriod(exception.OpenTime, exception.CloseTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := schedule.AddException(exception.Date, &period); err != nil {
+			return nil, err
+		}
+	}
+
+	return schedule, nil
 }
 
 type FacilityCategoryResponse struct {
@@ -340,6 +756,8 @@ type AddPictureResponse struct {
 	ID  string `json:"id"`
 	URL string `json:"url"`
 	Alt string `json:"alt"`
+	// Revision is the draft holding the picture of a published building
+	Revision *BuildingRevisionResponse `json:"revision,omitempty"`
 }
 
 func NewAddPictureResponse(picture *entity.Picture) *AddPictureResponse {
@@ -415,8 +833,33 @@ func NewAgent(agent *entity.User) *Agent {
 }
 
 type BuildingStatResponse struct {
-	ByCity      *TotalByCities    `json:"byCity"`
-	ByTimeFrame *TotalByTimeFrame `json:"byTimeFrame"`
+	ByCity        *TotalByCities     `json:"byCity"`
+	ByTime        *timeseries.Series `json:"byTime"`
+	MostFavorited *TotalByFavorites  `json:"mostFavorited"`
+}
+
+type TotalByFavorite struct {
+	BuildingID   string `json:"buildingId"`
+	BuildingName string `json:"buildingName"`
+	Total        int64  `json:"total"`
+}
+
+func NewTotalByFavorite(stat *entity.FavoriteStat) *TotalByFavorite {
+	return &TotalByFavorite{
+		BuildingID:   stat.BuildingID,
+		BuildingName: stat.BuildingName,
+		Total:        stat.Total,
+	}
+}
+
+type TotalByFavorites []TotalByFavorite
+
+func NewTotalByFavorites(stats *entity.FavoritesStat) *TotalByFavorites {
+	totalByFavorites := TotalByFavorites{}
+	for _, stat := range *stats {
+		totalByFavorites = append(totalByFavorites, *NewTotalByFavorite(&stat))
+	}
+	return &totalByFavorites
 }
 
 type TotalByCity struct {
@@ -443,40 +886,87 @@ func NewTotalByCities(stats *entity.CitiesStat) *TotalByCities {
 	return &totalByCities
 }
 
-type TotalByTimeFrame struct {
-	Today     int64 `json:"today"`
-	ThisWeek  int64 `json:"thisWeek"`
-	ThisMonth int64 `json:"thisMonth"`
-	ThisYear  int64 `json:"thisYear"`
-	AllTime   int64 `json:"allTime"`
-}
-
-func NewTimeframeStat(stats *entity.TimeframeStat) *TotalByTimeFrame {
-	return &TotalByTimeFrame{
-		Today:     stats.Day.Int64,
-		ThisWeek:  stats.Week.Int64,
-		ThisMonth: stats.Month.Int64,
-		ThisYear:  stats.Year.Int64,
-		AllTime:   stats.All.Int64,
-	}
-}
-
 type BriefBuildingReviewResponse struct {
-	ID        string            `json:"id"`
-	User      BriefUserResponse `json:"user"`
-	Rating    int               `json:"rating"`
-	Message   string            `json:"message"`
-	CreatedAt string            `json:"createdAt"`
+	ID         string                            `json:"id"`
+	User       BriefUserResponse                 `json:"user"`
+	Rating     int                               `json:"rating"`
+	SubRatings ReviewSubRatingsResponse          `json:"subRatings"`
+	Message    string                            `json:"message"`
+	Reply      *BriefBuildingReviewReplyResponse `json:"reply,omitempty"`
+	Pictures   ReviewPicturesResponse            `json:"pictures"`
+	CreatedAt  string                            `json:"createdAt"`
 }
 
 func NewBriefBuildingReviewResponse(review *entity.Review) *BriefBuildingReviewResponse {
-	return &BriefBuildingReviewResponse{
-		ID:        review.ID,
-		Rating:    review.Rating,
+	response := &BriefBuildingReviewResponse{
+		ID:     review.ID,
+		Rating: review.Rating,
+		SubRatings: ReviewSubRatingsResponse{
+			Location:     review.LocationRating,
+			Cleanliness:  review.CleanlinessRating,
+			Facilities:   review.FacilitiesRating,
+			Value:        review.ValueRating,
+			Connectivity: review.ConnectivityRating,
+		},
 		Message:   review.Message,
 		User:      *NewBriefUserResponse(&review.User),
+		Pictures:  *NewReviewPicturesResponse(&review.Pictures),
 		CreatedAt: review.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
 	}
+
+	if review.Reply != nil {
+		response.Reply = &BriefBuildingReviewReplyResponse{
+			Message:   review.Reply.Message,
+			CreatedAt: review.Reply.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+			UpdatedAt: review.Reply.UpdatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+		}
+	}
+
+	return response
+}
+
+// ReviewSubRatingsResponse are the sub-ratings of a review, the ones the reviewer skipped are null
+type ReviewSubRatingsResponse struct {
+	Location     *int `json:"location"`
+	Cleanliness  *int `json:"cleanliness"`
+	Facilities   *int `json:"facilities"`
+	Value        *int `json:"value"`
+	Connectivity *int `json:"connectivity"`
+}
+
+type ReviewPictureResponse struct {
+	ID           string `json:"id"`
+	ReviewID     string `json:"reviewId"`
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnailUrl"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+func NewReviewPictureResponse(picture *entity.ReviewPicture) *ReviewPictureResponse {
+	return &ReviewPictureResponse{
+		ID:           picture.ID,
+		ReviewID:     picture.ReviewID,
+		Url:          picture.Url,
+		ThumbnailUrl: picture.ThumbnailUrl,
+		CreatedAt:    picture.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ReviewPicturesResponse []ReviewPictureResponse
+
+func NewReviewPicturesResponse(pictures *entity.ReviewPictures) *ReviewPicturesResponse {
+	response := make(ReviewPicturesResponse, 0, len(*pictures))
+	for _, picture := range *pictures {
+		response = append(response, *NewReviewPictureResponse(&picture))
+	}
+	return &response
+}
+
+// BriefBuildingReviewReplyResponse is the reply of the owner, the author isn't shown publicly
+type BriefBuildingReviewReplyResponse struct {
+	Message   string `json:"message"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
 }
 
 type BriefBuildingReviewsResponse []BriefBuildingReviewResponse
@@ -506,3 +996,163 @@ func NewBriefUserResponse(user *entity.User) *BriefUserResponse {
 		Picture: url,
 	}
 }
+
+type FacetCount struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Total int64  `json:"total"`
+}
+
+type FacetCounts []FacetCount
+
+func NewFacetCounts(counts entity.FacetCounts) FacetCounts {
+	facetCounts := FacetCounts{}
+	for _, count := range counts {
+		facetCounts = append(facetCounts, FacetCount{
+			ID:    count.ID,
+			Name:  count.Name,
+			Total: count.Total,
+		})
+	}
+	return facetCounts
+}
+
+type SearchFacetsResponse struct {
+	Cities             FacetCounts `json:"cities"`
+	Districts          FacetCounts `json:"districts"`
+	PriceBands         FacetCounts `json:"priceBands"`
+	FacilityCategories FacetCounts `json:"facilityCategories"`
+}
+
+func NewSearchFacetsResponse(facets *entity.BuildingFacets) *SearchFacetsResponse {
+	return &SearchFacetsResponse{
+		Cities:             NewFacetCounts(facets.Cities),
+		Districts:          NewFacetCounts(facets.Districts),
+		PriceBands:         NewFacetCounts(facets.PriceBands),
+		FacilityCategories: NewFacetCounts(facets.FacilityCategories),
+	}
+}
+
+type BuildingRevisionResponse struct {
+	ID          string `json:"id"`
+	BuildingID  string `json:"buildingId"`
+	Version     int    `json:"version"`
+	Status      string `json:"status"`
+	CreatedBy   *Agent `json:"createdBy,omitempty"`
+	PublishedAt string `json:"publishedAt,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+func NewBuildingRevisionResponse(revision *entity.BuildingRevision) *BuildingRevisionResponse {
+	response := &BuildingRevisionResponse{
+		ID:         revision.ID,
+		BuildingID: revision.BuildingID,
+		Version:    revision.Version,
+		Status:     revision.Status,
+		CreatedAt:  revision.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+		UpdatedAt:  revision.UpdatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+
+	if revision.CreatedBy.ID != "" {
+		response.CreatedBy = NewAgent(&revision.CreatedBy)
+	}
+
+	if revision.PublishedAt.Valid {
+		response.PublishedAt = revision.PublishedAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	return response
+}
+
+type BuildingRevisionsResponse []BuildingRevisionResponse
+
+func NewBuildingRevisionsResponse(revisions *entity.BuildingRevisions) *BuildingRevisionsResponse {
+	response := new(BuildingRevisionsResponse)
+	for _, revision := range *revisions {
+		*response = append(*response, *NewBuildingRevisionResponse(&revision))
+	}
+	return response
+}
+
+type FieldChange struct {
+	Field    string      `json:"field"`
+	Current  interface{} `json:"current"`
+	Proposed interface{} `json:"proposed"`
+}
+
+type FieldChanges []FieldChange
+
+// NewFieldChanges compares two snapshots of a building field by field, nested objects are flattened
+// into dotted field names (e.g. price.monthly) while facilities and pictures are compared as a whole
+func NewFieldChanges(current *UpdateBuildingRequest, proposed *UpdateBuildingRequest) FieldChanges {
+	currentFields := flattenFields(current)
+	proposedFields := flattenFields(proposed)
+
+	fields := make([]string, 0, len(proposedFields))
+	for field := range proposedFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	changes := FieldChanges{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(currentFields[field], proposedFields[field]) {
+			changes = append(changes, FieldChange{
+				Field:    field,
+				Current:  currentFields[field],
+				Proposed: proposedFields[field],
+			})
+		}
+	}
+	return changes
+}
+
+func flattenFields(request *UpdateBuildingRequest) map[string]interface{} {
+	fields := map[string]interface{}{}
+
+	// the json round trip gives the field names used by the api
+	encoded, _ := json.Marshal(request)
+	_ = json.Unmarshal(encoded, &fields)
+
+	return flattenNested(fields)
+}
+
+func flattenNested(fields map[string]interface{}) map[string]interface{} {
+	flattened := map[string]interface{}{}
+	for name, value := range fields {
+		if nested, ok := value.(map[string]interface{}); ok {
+			for nestedName, nestedValue := range flattenNested(nested) {
+				flattened[name+"."+nestedName] = nestedValue
+			}
+			continue
+		}
+
+		flattened[name] = value
+	}
+	return flattened
+}
+
+type BuildingRevisionPreviewResponse struct {
+	Revision *BuildingRevisionResponse `json:"revision"`
+	Building *FullBuildingResponse     `json:"building"`
+	Changes  FieldChanges              `json:"changes"`
+}
+
+type ImportError struct {
+	Row    int    `json:"row"`
+	Column string `json:"column,omitempty"`
+	Reason string `json:"reason"`
+}
+
+type ImportErrors []ImportError
+
+// ImportBuildingsResponse is the result of an import, a row failing to be written after the validation
+// passed doesn't undo the other rows, Created and Updated count the rows that were written
+type ImportBuildingsResponse struct {
+	DryRun  bool         `json:"dryRun"`
+	Total   int          `json:"total"`
+	Created int          `json:"created"`
+	Updated int          `json:"updated"`
+	Errors  ImportErrors `json:"errors"`
+}
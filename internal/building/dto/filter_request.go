@@ -2,32 +2,93 @@ package dto
 
 import (
 	"office-booking-backend/pkg/custom"
+	"office-booking-backend/pkg/utils/cursor"
 	"time"
 )
 
 type SearchBuildingQueryParam struct {
-	BuildingName    string      `query:"buildingName" validate:"omitempty,min=3"`
-	CityID          int         `query:"cityId" validate:"omitempty"`
-	DistrictID      int         `query:"districtId" validate:"omitempty"`
-	AnnualPriceMin  int         `query:"annualPriceMin" validate:"omitempty,gte=0"`
-	AnnualPriceMax  int         `query:"annualPriceMax" validate:"omitempty,gte=0"`
-	MonthlyPriceMin int         `query:"monthlyPriceMin" validate:"omitempty,gte=0"`
-	MonthlyPriceMax int         `query:"monthlyPriceMax" validate:"omitempty,gte=0"`
-	CapacityMin     int         `query:"capacityMin" validate:"omitempty,gte=0"`
-	CapacityMax     int         `query:"capacityMax" validate:"omitempty,gte=0"`
-	Latitude        float64     `query:"latitude" validate:"required_if=SortBy pinpoint"`
-	Longitude       float64     `query:"longitude" validate:"required_if=SortBy pinpoint"`
-	StartDate       custom.Date `query:"startDate" validate:"required_with=Duration"`
-	Duration        int         `query:"duration" validate:"required_with=StartDate,gte=0"`
-	EndDate         time.Time   `query:"-"`
-	SortBy          string      `query:"sortBy" validate:"omitempty,oneof=annual_price monthly_price capacity pinpoint"`
-	Order           string      `query:"order" validate:"omitempty,oneof=asc desc"`
-	Page            int         `query:"page" validate:"gte=1"`
-	Limit           int         `query:"limit" validate:"gte=1"`
-	Offset          int         `query:"-" validate:"isdefault"`
+	Query           string   `query:"q" validate:"omitempty,min=2,max=100"`
+	IDs             []string `query:"-"`
+	BuildingName    string   `query:"buildingName" validate:"omitempty,min=3"`
+	CityID          int      `query:"cityId" validate:"omitempty"`
+	DistrictID      int      `query:"districtId" validate:"omitempty"`
+	AnnualPriceMin  int      `query:"annualPriceMin" validate:"omitempty,gte=0"`
+	AnnualPriceMax  int      `query:"annualPriceMax" validate:"omitempty,gte=0"`
+	MonthlyPriceMin int      `query:"monthlyPriceMin" validate:"omitempty,gte=0"`
+	MonthlyPriceMax int      `query:"monthlyPriceMax" validate:"omitempty,gte=0"`
+	CapacityMin     int      `query:"capacityMin" validate:"omitempty,gte=0"`
+	CapacityMax     int      `query:"capacityMax" validate:"omitempty,gte=0"`
+	Latitude        *float64 `query:"latitude" validate:"required_if=SortBy pinpoint,required_with=RadiusKm Longitude,omitempty,gte=-90,lte=90"`
+	Longitude       *float64 `query:"longitude" validate:"required_if=SortBy pinpoint,required_with=RadiusKm Latitude,omitempty,gte=-180,lte=180"`
+	RadiusKm        float64  `query:"radiusKm" validate:"omitempty,gt=0,lte=500"`
+	MinLatitude     *float64 `query:"minLat" validate:"required_with=MaxLatitude MinLongitude MaxLongitude,omitempty,gte=-90,lte=90"`
+	MinLongitude    *float64 `query:"minLng" validate:"required_with=MinLatitude MaxLatitude MaxLongitude,omitempty,gte=-180,lte=180"`
+	MaxLatitude     *float64 `query:"maxLat" validate:"required_with=MinLatitude MinLongitude MaxLongitude,omitempty,gte=-90,lte=90,gtfield=MinLatitude"`
+	// MaxLongitude is lower than MinLongitude when the box crosses the antimeridian
+	MaxLongitude        *float64    `query:"maxLng" validate:"required_with=MinLatitude MinLongitude MaxLatitude,omitempty,gte=-180,lte=180,nefield=MinLongitude"`
+	FacilityCategoryIDs []int       `query:"facilityCategoryIds" validate:"omitempty,dive,gte=1"`
+	FacilityMatch       string      `query:"facilityMatch" validate:"omitempty,oneof=all any"`
+	RatingMin           float64     `query:"ratingMin" validate:"omitempty,gte=0,lte=5"`
+	ReviewCountMin      int         `query:"reviewCountMin" validate:"omitempty,gte=0"`
+	AlwaysOpen          bool        `query:"alwaysOpen"`
+	OpenOnWeekends      bool        `query:"openOnWeekends"`
+	StartDate           custom.Date `query:"startDate" validate:"required_with=Duration"`
+	Duration            int         `query:"duration" validate:"required_with=StartDate,gte=0"`
+	EndDate             time.Time   `query:"-"`
+	SortBy              string      `query:"sortBy" validate:"omitempty,oneof=annual_price monthly_price capacity pinpoint"`
+	Order               string      `query:"order" validate:"omitempty,oneof=asc desc"`
+	Page                int         `query:"page" validate:"gte=1"`
+	Limit               int         `query:"limit" validate:"gte=1"`
+	Offset              int         `query:"-" validate:"isdefault"`
+	cursor.Pagination
+}
+
+// HasLocation reports whether the distance to a point should be computed
+func (f *SearchBuildingQueryParam) HasLocation() bool {
+	return f.Latitude != nil && f.Longitude != nil
+}
+
+// HasBoundingBox reports whether the results should be limited to the map viewport
+func (f *SearchBuildingQueryParam) HasBoundingBox() bool {
+	return f.MinLatitude != nil && f.MinLongitude != nil && f.MaxLatitude != nil && f.MaxLongitude != nil
+}
+
+type SimilarBuildingQueryParam struct {
+	StartDate custom.Date `query:"startDate" validate:"required_with=Duration"`
+	Duration  int         `query:"duration" validate:"required_with=StartDate,gte=0"`
+	Limit     int         `query:"limit" validate:"omitempty,gte=1,lte=20"`
+}
+
+type CompareBuildingQueryParam struct {
+	IDs       []string    `query:"ids" validate:"min=2,max=4,unique,dive,uuid"`
+	StartDate custom.Date `query:"startDate" validate:"required_with=Duration"`
+	Duration  int         `query:"duration" validate:"required_with=StartDate,gte=0"`
 }
 
 type GetBuildingReviewsQueryParam struct {
+	Page   int    `query:"page" validate:"gte=1"`
+	Limit  int    `query:"limit" validate:"gte=1"`
+	Offset int    `query:"-" validate:"isdefault"`
+	SortBy string `query:"sortBy" validate:"omitempty,oneof=created_at rating"`
+	Order  string `query:"order" validate:"omitempty,oneof=asc desc"`
+	// Rating keeps the reviews with the given number of stars only
+	Rating int `query:"rating" validate:"omitempty,gte=1,lte=5"`
+	cursor.Pagination
+}
+
+type ReviewPictureQueryParam struct {
+	Page   int `query:"page" validate:"gte=1"`
+	Limit  int `query:"limit" validate:"gte=1,lte=100"`
+	Offset int `query:"-" validate:"isdefault"`
+}
+
+type FavoriteQueryParam struct {
+	Page   int `query:"page" validate:"gte=1"`
+	Limit  int `query:"limit" validate:"gte=1"`
+	Offset int `query:"-" validate:"isdefault"`
+}
+
+type RevisionQueryParam struct {
 	Page   int `query:"page" validate:"gte=1"`
 	Limit  int `query:"limit" validate:"gte=1"`
 	Offset int `query:"-" validate:"isdefault"`
@@ -4,26 +4,59 @@ import (
 	"context"
 	"office-booking-backend/internal/building/dto"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
+	"time"
 )
 
 type BuildingRepository interface {
 	GetAllBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam, isPublishedOnly bool) (*entity.Buildings, int64, error)
+	GetBuildingFacets(ctx context.Context, filter *dto.SearchBuildingQueryParam, isPublishedOnly bool) (*entity.BuildingFacets, error)
+	GetSearchableBuildings(ctx context.Context) (*entity.Buildings, error)
+	GetSearchableBuildingByID(ctx context.Context, buildingID string) (*entity.Building, error)
+	GetBuildingsForExport(ctx context.Context) (*entity.Buildings, error)
 	GetBuildingDetailByID(ctx context.Context, id string, isPublishedOnly bool) (*entity.Building, error)
+	GetBuildingDetailsByIDs(ctx context.Context, ids []string, isPublishedOnly bool) (*entity.Buildings, error)
 	GetFacilityCategories(ctx context.Context) (*entity.Categories, error)
 	GetCities(ctx context.Context) (*entity.Cities, error)
 	GetDistrictsByCityID(ctx context.Context, cityID int) (*entity.Districts, error)
 	GetDistrictByID(ctx context.Context, districtID int) (*entity.District, error)
 	GetBuildingReviewsByID(ctx context.Context, buildingID string, filter *dto.GetBuildingReviewsQueryParam) (*entity.Reviews, error)
 	GetBuildingCountByCity(ctx context.Context) (*entity.CitiesStat, error)
-	GetBuildingCountByTime(ctx context.Context) (*entity.TimeframeStat, error)
+	GetBuildingSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error)
+	GetUserFavoriteBuildings(ctx context.Context, userID string, offset int, limit int) (*entity.Buildings, int64, error)
+	GetFavoritedBuildingIDs(ctx context.Context, userID string, buildingIDs []string) (map[string]bool, error)
+	CountBuildingFavoritesByID(ctx context.Context, buildingID string) (int64, error)
+	GetMostFavoritedBuildings(ctx context.Context, limit int) (*entity.FavoritesStat, error)
+	AddFavorite(ctx context.Context, favorite *entity.Favorite) error
+	DeleteFavorite(ctx context.Context, userID string, buildingID string) error
 	AddPicture(ctx context.Context, picture *entity.Picture) error
 	AddFacility(ctx context.Context, facility *entity.Facilities) error
 	CreateBuilding(ctx context.Context, building *entity.Building) error
 	UpdateBuildingByID(ctx context.Context, building *entity.Building) error
 	CountBuildingPicturesByID(ctx context.Context, buildingID string) (int64, error)
-	CountBuildingReviewsByID(ctx context.Context, buildingID string) (int64, error)
+	CountBuildingReviewsByID(ctx context.Context, buildingID string, rating int) (int64, error)
+	GetBuildingRatingBreakdown(ctx context.Context, buildingID string) (*entity.RatingBreakdown, error)
+	GetBuildingReviewPictures(ctx context.Context, buildingID string, offset int, limit int) (*entity.ReviewPictures, int64, error)
 	IsBuildingExist(ctx context.Context, buildingID string) (bool, error)
 	DeleteBuildingPicturesByID(ctx context.Context, buildingID string, pictureID string) error
 	DeleteBuildingFacilityByID(ctx context.Context, buildingID string, facilityID int) error
 	DeleteBuildingByID(ctx context.Context, buildingID string) error
+	GetBuildingRevisions(ctx context.Context, buildingID string, offset int, limit int) (*entity.BuildingRevisions, int64, error)
+	GetBuildingRevisionByID(ctx context.Context, buildingID string, revisionID string) (*entity.BuildingRevision, error)
+	GetDraftBuildingRevision(ctx context.Context, buildingID string) (*entity.BuildingRevision, error)
+	SaveDraftBuildingRevision(ctx context.Context, initial *entity.BuildingRevision, draft *entity.BuildingRevision, merge func(pending string) (string, error)) error
+	ReplaceDraftBuildingRevision(ctx context.Context, initial *entity.BuildingRevision, draft *entity.BuildingRevision) (*entity.BuildingRevision, error)
+	UpdateBuildingRevisionStatus(ctx context.Context, revisionID string, status string) error
+	PublishBuildingRevision(ctx context.Context, buildingID string, revisionID string, publish func(pending *entity.BuildingRevision) (*dto.RevisionChanges, error)) error
+	UpdateBuildingSchedule(ctx context.Context, building *entity.Building) error
+	GetScheduledBuildings(ctx context.Context, until time.Time) (*entity.Buildings, error)
+	GetScheduledBuildingByID(ctx context.Context, buildingID string) (*entity.Building, error)
+	UpdateBuildingOpeningHours(ctx context.Context, building *entity.Building) error
+	GetBuildingFloorPlans(ctx context.Context, buildingID string) (*entity.FloorPlans, error)
+	GetFloorPlanByID(ctx context.Context, buildingID string, floorPlanID string) (*entity.FloorPlan, error)
+	CountBuildingFloorPlansByID(ctx context.Context, buildingID string) (int64, error)
+	CountBuildingFacilitiesByIDs(ctx context.Context, buildingID string, facilityIDs []int) (int64, error)
+	AddFloorPlan(ctx context.Context, floorPlan *entity.FloorPlan) error
+	ReplaceFloorPlanHotspots(ctx context.Context, floorPlanID string, hotspots *entity.FloorPlanHotspots) error
+	DeleteFloorPlanByID(ctx context.Context, buildingID string, floorPlanID string) error
 }
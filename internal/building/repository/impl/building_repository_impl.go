@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"office-booking-backend/internal/building/dto"
 	"office-booking-backend/internal/building/repository"
@@ -9,6 +10,8 @@ import (
 	"office-booking-backend/pkg/custom"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/geo"
+	"office-booking-backend/pkg/utils/timeseries"
 	"strings"
 	"time"
 
@@ -38,10 +41,111 @@ func (b *BuildingRepositoryImpl) GetAllBuildings(ctx context.Context, filter *dt
 		Joins("District").
 		Joins("City").
 		Model(&entity.Building{})
+	query = applySearchFilter(query, filter, isPublishedOnly)
+
+	if filter.Enabled {
+		column, desc := BuildingCursorSort(filter.SortBy, filter.Order)
+		if condition, args := filter.Where(column, "`buildings`.`id`", desc); condition != "" {
+			query = query.Where(condition, args...)
+		}
+
+		for _, order := range filter.OrderBy(column, "`buildings`.`id`", desc) {
+			query = query.Order(order)
+		}
+
+		// one more row tells whether there's a next page, the total isn't counted in cursor mode
+		err := query.Limit(filter.Limit + 1).Find(buildings).Error
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return buildings, 0, nil
+	}
+
+	if filter.SortBy != "" {
+		if filter.SortBy == "pinpoint" {
+			query = query.Clauses(clause.OrderBy{
+				Expression: clause.Expr{
+					SQL:                fmt.Sprintf("ST_Distance_Sphere(`buildings`.`location`, POINT(?, ?)) %s", filter.Order),
+					Vars:               []interface{}{*filter.Longitude, *filter.Latitude},
+					WithoutParentheses: true,
+				},
+			})
+		} else {
+			query = query.Order(fmt.Sprintf("`buildings`.`%s` %s", filter.SortBy, filter.Order))
+		}
+	} else if len(filter.IDs) > 0 {
+		// keep the relevance order of the full-text search
+		vars := make([]interface{}, 0, len(filter.IDs))
+		for _, id := range filter.IDs {
+			vars = append(vars, id)
+		}
+
+		query = query.Clauses(clause.OrderBy{
+			Expression: clause.Expr{
+				SQL:                "FIELD(`buildings`.`id`" + strings.Repeat(", ?", len(vars)) + ")",
+				Vars:               vars,
+				WithoutParentheses: true,
+			},
+		})
+	}
+
+	err := query.
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(buildings).
+		Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return buildings, count, nil
+}
+
+// BuildingCursorSort returns the sort column and direction used by cursor pagination,
+// buildings are sorted from the newest when no sort is requested
+func BuildingCursorSort(sortBy string, order string) (string, bool) {
+	if sortBy == "" {
+		return "`buildings`.`created_at`", true
+	}
+
+	return fmt.Sprintf("`buildings`.`%s`", sortBy), order == "desc"
+}
+
+// applySearchFilter adds the search filter conditions to a query joining the `City` and `District` tables
+func applySearchFilter(query *gorm.DB, filter *dto.SearchBuildingQueryParam, isPublishedOnly bool) *gorm.DB {
+	if filter.IDs != nil {
+		query = query.Where("`buildings`.`id` IN ?", filter.IDs)
+	}
+
 	if filter.BuildingName != "" {
 		query = query.Where("`buildings`.`name` LIKE ?", "%"+filter.BuildingName+"%")
 	}
 
+	// the bounding boxes are matched against the spatial index of `location` (x is longitude, y is latitude)
+	if filter.RadiusKm > 0 && filter.HasLocation() {
+		box := geo.NewBoundingBox(*filter.Latitude, *filter.Longitude, filter.RadiusKm)
+		query = query.
+			Where("MBRContains(ST_GeomFromText(?), `buildings`.`location`)", box.WKT()).
+			Where("ST_Distance_Sphere(`buildings`.`location`, POINT(?, ?)) <= ?", *filter.Longitude, *filter.Latitude, filter.RadiusKm*1000)
+	}
+
+	if filter.HasBoundingBox() {
+		box := &geo.BoundingBox{
+			MinLat: *filter.MinLatitude,
+			MinLng: *filter.MinLongitude,
+			MaxLat: *filter.MaxLatitude,
+			MaxLng: *filter.MaxLongitude,
+		}
+
+		// a box crossing the antimeridian is matched as its halves on both sides
+		conditions := query.Session(&gorm.Session{NewDB: true})
+		for _, half := range box.Split() {
+			conditions = conditions.Or("MBRContains(ST_GeomFromText(?), `buildings`.`location`)", half.WKT())
+		}
+		query = query.Where(conditions)
+	}
+
 	if filter.CityID != 0 {
 		query = query.Where("`City`.`id` = ?", filter.CityID)
 	}
@@ -76,45 +180,196 @@ func (b *BuildingRepositoryImpl) GetAllBuildings(ctx context.Context, filter *dt
 		query = query.Where("`buildings`.`capacity` >= ?", filter.CapacityMin)
 	}
 
+	if categoryIDs := uniqueInts(filter.FacilityCategoryIDs); len(categoryIDs) > 0 {
+		if filter.FacilityMatch == "any" {
+			query = query.Where("EXISTS (SELECT 1 FROM `facilities` WHERE `facilities`.`building_id` = `buildings`.`id` AND `facilities`.`category_id` IN ?)", categoryIDs)
+		} else {
+			// every requested category must be present
+			query = query.Where("(SELECT COUNT(DISTINCT `facilities`.`category_id`) FROM `facilities` WHERE `facilities`.`building_id` = `buildings`.`id` AND `facilities`.`category_id` IN ?) = ?", categoryIDs, len(categoryIDs))
+		}
+	}
+
+	if filter.RatingMin != 0 {
+		query = query.Where("`buildings`.`rating` >= ?", filter.RatingMin)
+	}
+
+	if filter.ReviewCountMin != 0 {
+		query = query.Where("`buildings`.`review_count` >= ?", filter.ReviewCountMin)
+	}
+
 	if filter.CapacityMax != 0 {
 		query = query.Where("`buildings`.`capacity` <= ?", filter.CapacityMax)
 	}
 
-	if filter.SortBy != "" {
-		// POW(69.1 * (latitude - [startlat]), 2) +
-		// POW(69.1 * ([startlng] - longitude) * COS(latitude / 57.3), 2))
-		if filter.SortBy == "pinpoint" {
-			query = query.Clauses(clause.OrderBy{
-				Expression: clause.Expr{
-					SQL:                fmt.Sprintf("POW(69.1 * (`buildings`.`latitude` - ?), 2) + POW(69.1 * (? - `buildings`.`longitude`) * COS(`buildings`.`latitude` / 57.3), 2) %s", filter.Order),
-					Vars:               []interface{}{filter.Latitude, filter.Longitude},
-					WithoutParentheses: true,
-				},
-			})
-		} else {
-			query = query.Order(fmt.Sprintf("`buildings`.`%s` %s", filter.SortBy, filter.Order))
-		}
+	if filter.AlwaysOpen {
+		query = query.Where("`buildings`.`is_always_open` = ?", true)
+	}
+
+	if filter.OpenOnWeekends {
+		query = query.Where("`buildings`.`is_open_on_weekends` = ?", true)
 	}
 
 	if isPublishedOnly {
 		query = query.Where("`buildings`.`is_published` = ?", true)
 	}
 
-	err := query.
-		Limit(filter.Limit).
-		Offset(filter.Offset).
-		Find(buildings).
-		Count(&count).Error
+	return query
+}
+
+func (b *BuildingRepositoryImpl) GetBuildingFacets(ctx context.Context, filter *dto.SearchBuildingQueryParam, isPublishedOnly bool) (*entity.BuildingFacets, error) {
+	base := func() *gorm.DB {
+		query := b.db.WithContext(ctx).
+			Model(&entity.Building{}).
+			Joins("LEFT JOIN `cities` `City` ON `City`.`id` = `buildings`.`city_id`").
+			Joins("LEFT JOIN `districts` `District` ON `District`.`id` = `buildings`.`district_id`")
+		return applySearchFilter(query, filter, isPublishedOnly)
+	}
+
+	facets := &entity.BuildingFacets{
+		Cities:             entity.FacetCounts{},
+		Districts:          entity.FacetCounts{},
+		PriceBands:         entity.FacetCounts{},
+		FacilityCategories: entity.FacetCounts{},
+	}
+
+	err := base().
+		Select("`City`.`id` AS id, `City`.`name` AS name, COUNT(*) AS total").
+		Where("`City`.`id` IS NOT NULL").
+		Group("`City`.`id`, `City`.`name`").
+		Order("total DESC").
+		Scan(&facets.Cities).Error
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return buildings, count, nil
+	err = base().
+		Select("`District`.`id` AS id, `District`.`name` AS name, COUNT(*) AS total").
+		Where("`District`.`id` IS NOT NULL").
+		Group("`District`.`id`, `District`.`name`").
+		Order("total DESC").
+		Scan(&facets.Districts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = base().
+		Select("`categories`.`id` AS id, `categories`.`name` AS name, COUNT(DISTINCT `buildings`.`id`) AS total").
+		Joins("JOIN `facilities` ON `facilities`.`building_id` = `buildings`.`id`").
+		Joins("JOIN `categories` ON `categories`.`id` = `facilities`.`category_id`").
+		Group("`categories`.`id`, `categories`.`name`").
+		Order("total DESC").
+		Scan(&facets.FacilityCategories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// bucket the monthly price into the bands defined by MONTHLY_PRICE_BANDS
+	bandCase := strings.Builder{}
+	bandCase.WriteString("CASE")
+	vars := make([]interface{}, 0, len(constant.MONTHLY_PRICE_BANDS))
+	for i, limit := range constant.MONTHLY_PRICE_BANDS {
+		bandCase.WriteString(fmt.Sprintf(" WHEN `buildings`.`monthly_price` < ? THEN %d", i))
+		vars = append(vars, limit)
+	}
+	bandCase.WriteString(fmt.Sprintf(" ELSE %d END", len(constant.MONTHLY_PRICE_BANDS)))
+
+	bands := entity.FacetCounts{}
+	err = base().
+		Select(bandCase.String()+" AS id, COUNT(*) AS total", vars...).
+		Group("id").
+		Order("id ASC").
+		Scan(&bands).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, band := range bands {
+		band.Name = priceBandName(int(band.ID))
+		facets.PriceBands = append(facets.PriceBands, band)
+	}
+
+	return facets, nil
 }
 
-func (b *BuildingRepositoryImpl) GetBuildingDetailByID(ctx context.Context, id string, isPublishedOnly bool) (*entity.Building, error) {
+func uniqueInts(values []int) []int {
+	seen := map[int]bool{}
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// priceBandName returns the label of the price band, e.g. "5000000-10000000" or "25000000+"
+func priceBandName(band int) string {
+	bands := constant.MONTHLY_PRICE_BANDS
+	switch {
+	case band == 0:
+		return fmt.Sprintf("0-%d", bands[0])
+	case band >= len(bands):
+		return fmt.Sprintf("%d+", bands[len(bands)-1])
+	default:
+		return fmt.Sprintf("%d-%d", bands[band-1], bands[band])
+	}
+}
+
+func (b *BuildingRepositoryImpl) GetSearchableBuildings(ctx context.Context) (*entity.Buildings, error) {
+	buildings := &entity.Buildings{}
+	err := b.db.WithContext(ctx).
+		Preload("Facilities").
+		Joins("District").
+		Joins("City").
+		Where("`buildings`.`is_published` = ?", true).
+		Find(buildings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return buildings, nil
+}
+
+func (b *BuildingRepositoryImpl) GetSearchableBuildingByID(ctx context.Context, buildingID string) (*entity.Building, error) {
 	building := &entity.Building{}
+	err := b.db.WithContext(ctx).
+		Preload("Facilities").
+		Joins("District").
+		Joins("City").
+		Where("`buildings`.`id` = ? AND `buildings`.`is_published` = ?", buildingID, true).
+		First(building).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrBuildingNotFound
+		}
+
+		return nil, err
+	}
+
+	return building, nil
+}
+
+func (b *BuildingRepositoryImpl) GetBuildingsForExport(ctx context.Context) (*entity.Buildings, error) {
+	buildings := &entity.Buildings{}
+	err := b.db.WithContext(ctx).
+		Preload("Pictures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`pictures`.`index` ASC")
+		}).
+		Preload("Facilities").
+		Model(&entity.Building{}).
+		Order("`buildings`.`created_at` ASC").
+		Find(buildings).Error
+	if err != nil {
+		return nil, err
+	}
 
+	return buildings, nil
+}
+
+// buildingDetailQuery loads a building with everything shown on its detail page
+func (b *BuildingRepositoryImpl) buildingDetailQuery(ctx context.Context, isPublishedOnly bool) *gorm.DB {
 	// TODO: Optimize this query (maybe use raw query instead of gorm)
 	query := b.db.WithContext(ctx).
 		Preload("Pictures", func(db *gorm.DB) *gorm.DB {
@@ -126,18 +381,31 @@ func (b *BuildingRepositoryImpl) GetBuildingDetailByID(ctx context.Context, id s
 		Preload("Reservations", func(db *gorm.DB) *gorm.DB {
 			return db.Where("`reservations`.`end_date` >= ?", time.Now().Format("2006-01-02")).Joins("Status").Order("`reservations`.`start_date` ASC")
 		}).
+		Preload("OpeningHours", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`opening_hours`.`weekday` ASC, `opening_hours`.`open_time` ASC")
+		}).
+		Preload("OpeningExceptions", func(db *gorm.DB) *gorm.DB {
+			return db.Where("`opening_exceptions`.`date` >= ?", time.Now().Format("2006-01-02")).Order("`opening_exceptions`.`date` ASC")
+		}).
 		Preload("CreatedBy.Detail").
 		Preload("CreatedBy.Detail.Picture").
 		Joins("District").
 		Joins("City").
-		Model(&entity.Building{}).
-		Where("`buildings`.`id` = ?", id)
+		Model(&entity.Building{})
 
 	if isPublishedOnly {
 		query = query.Where("`buildings`.`is_published` = ?", true)
 	}
 
-	err := query.First(building).Error
+	return query
+}
+
+func (b *BuildingRepositoryImpl) GetBuildingDetailByID(ctx context.Context, id string, isPublishedOnly bool) (*entity.Building, error) {
+	building := &entity.Building{}
+
+	err := b.buildingDetailQuery(ctx, isPublishedOnly).
+		Where("`buildings`.`id` = ?", id).
+		First(building).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, err2.ErrBuildingNotFound
@@ -149,6 +417,21 @@ func (b *BuildingRepositoryImpl) GetBuildingDetailByID(ctx context.Context, id s
 	return building, nil
 }
 
+// GetBuildingDetailsByIDs loads the details of several buildings in one query per relation,
+// the buildings that aren't found are left out
+func (b *BuildingRepositoryImpl) GetBuildingDetailsByIDs(ctx context.Context, ids []string, isPublishedOnly bool) (*entity.Buildings, error) {
+	buildings := &entity.Buildings{}
+
+	err := b.buildingDetailQuery(ctx, isPublishedOnly).
+		Where("`buildings`.`id` IN ?", ids).
+		Find(buildings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return buildings, nil
+}
+
 func (b *BuildingRepositoryImpl) GetFacilityCategories(ctx context.Context) (*entity.Categories, error) {
 	categories := &entity.Categories{}
 	err := b.db.WithContext(ctx).
@@ -192,6 +475,10 @@ func (b *BuildingRepositoryImpl) GetDistrictByID(ctx context.Context, districtID
 		Where("id = ?", districtID).
 		First(district).Error
 	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrInvalidDistrictID
+		}
+
 		return nil, err
 	}
 
@@ -227,32 +514,19 @@ func (b *BuildingRepositoryImpl) GetBuildingCountByCity(ctx context.Context) (*e
 	return &citiesStat, nil
 }
 
-func (b *BuildingRepositoryImpl) GetBuildingCountByTime(ctx context.Context) (*entity.TimeframeStat, error) {
-	rows, err := b.db.WithContext(ctx).
-		Table(
-			"(?) AS today, (?) AS thisWeek, (?) AS thisMonth, (?) AS thisYear, (?) AS allTime",
-			b.db.Table("buildings").Select("count(*)").Where("DATE(created_at) = DATE(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			b.db.Table("buildings").Select("count(*)").Where("YEARWEEK(created_at) = YEARWEEK(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			b.db.Table("buildings").Select("count(*)").Where("MONTH(created_at) = MONTH(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			b.db.Table("buildings").Select("count(*)").Where("YEAR(created_at) = YEAR(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			b.db.Table("buildings").Select("count(*)").Where("deleted_at IS NULL"),
-		).Rows()
+func (b *BuildingRepositoryImpl) GetBuildingSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error) {
+	stats := new(entity.TimeBucketStats)
+	err := b.db.WithContext(ctx).
+		Model(&entity.Building{}).
+		Select(r.Bucket("created_at")+" AS bucket, COUNT(*) AS total").
+		Where("created_at >= ? AND created_at < ?", r.Start, r.End).
+		Group("bucket").
+		Scan(stats).Error
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = rows.Close()
-	}()
-
-	stat := new(entity.TimeframeStat)
-	for rows.Next() {
-		err = rows.Scan(&stat.Day, &stat.Week, &stat.Month, &stat.Year, &stat.All)
-		if err != nil {
-			return nil, err
-		}
-	}
 
-	return stat, nil
+	return stats, nil
 }
 
 func (b *BuildingRepositoryImpl) CreateBuilding(ctx context.Context, building *entity.Building) error {
@@ -282,48 +556,65 @@ func (b *BuildingRepositoryImpl) UpdateBuildingByID(ctx context.Context, buildin
 	// return nil
 
 	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.WithContext(ctx).
+		return updateBuilding(ctx, tx, building)
+	})
+}
+
+// updateBuilding updates the building with its pictures and facilities inside the given transaction
+func updateBuilding(ctx context.Context, tx *gorm.DB, building *entity.Building) error {
+	err := tx.WithContext(ctx).
+		Model(&entity.Building{}).
+		Where("id = ?", building.ID).
+		Updates(building).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_buildings_city`"):
+			return err2.ErrInavalidCityID
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_buildings_district`"):
+			return err2.ErrInvalidDistrictID
+		default:
+			return err
+		}
+	}
+
+	// the first publish is kept, a building published again stays available since then
+	if building.IsPublished != nil && *building.IsPublished {
+		err = tx.WithContext(ctx).
 			Model(&entity.Building{}).
 			Where("id = ?", building.ID).
-			Updates(building).Error
+			Where("published_at IS NULL").
+			Update("published_at", time.Now()).Error
 		if err != nil {
-			switch {
-			case strings.Contains(err.Error(), "CONSTRAINT `fk_buildings_city`"):
-				return err2.ErrInavalidCityID
-			case strings.Contains(err.Error(), "CONSTRAINT `fk_buildings_district`"):
-				return err2.ErrInvalidDistrictID
-			default:
-				return err
-			}
+			return err
 		}
+	}
 
-		for _, picture := range building.Pictures {
-			err := tx.WithContext(ctx).
-				Model(&entity.Picture{}).
-				Where("id = ?", picture.ID).
-				Where("building_id = ?", building.ID).
-				Updates(picture).Error
-			if err != nil {
-				return err
-			}
+	for _, picture := range building.Pictures {
+		err := tx.WithContext(ctx).
+			Model(&entity.Picture{}).
+			Where("id = ?", picture.ID).
+			Where("building_id = ?", building.ID).
+			Updates(picture).Error
+		if err != nil {
+			return err
 		}
+	}
 
-		for _, facility := range building.Facilities {
-			err := tx.WithContext(ctx).
-				Model(&entity.Facility{}).
-				Where("id = ?", facility.ID).
-				Where("building_id = ?", building.ID).
-				Updates(facility).Error
-			if err != nil {
-				if strings.Contains(err.Error(), "CONSTRAINT `fk_facilities_category` FOREIGN KEY (`category_id`)") {
-					return err2.ErrInvalidCategoryID
-				}
-				return err
+	for _, facility := range building.Facilities {
+		err := tx.WithContext(ctx).
+			Model(&entity.Facility{}).
+			Where("id = ?", facility.ID).
+			Where("building_id = ?", building.ID).
+			Updates(facility).Error
+		if err != nil {
+			if strings.Contains(err.Error(), "CONSTRAINT `fk_facilities_category` FOREIGN KEY (`category_id`)") {
+				return err2.ErrInvalidCategoryID
 			}
+			return err
 		}
+	}
 
-		return nil
-	})
+	return nil
 }
 
 func (b *BuildingRepositoryImpl) IsBuildingExist(ctx context.Context, buildingId string) (bool, error) {
@@ -461,20 +752,31 @@ func changeNextPictureToIndexZero(ctx context.Context, tx *gorm.DB, buildingID s
 }
 
 func (b *BuildingRepositoryImpl) DeleteBuildingFacilityByID(ctx context.Context, buildingID string, facilityID int) error {
-	res := b.db.WithContext(ctx).
-		Model(&entity.Facility{}).
-		Where("id = ?", facilityID).
-		Where("building_id = ?", buildingID).
-		Delete(&entity.Facility{})
-	if res.Error != nil {
-		return res.Error
-	}
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.WithContext(ctx).
+			Model(&entity.Facility{}).
+			Where("id = ?", facilityID).
+			Where("building_id = ?", buildingID).
+			Delete(&entity.Facility{})
+		if res.Error != nil {
+			return res.Error
+		}
 
-	if res.RowsAffected == 0 {
-		return err2.ErrFacilityNotFound
-	}
+		if res.RowsAffected == 0 {
+			return err2.ErrFacilityNotFound
+		}
 
-	return nil
+		return clearFacilityHotspots(ctx, tx, []int{facilityID})
+	})
+}
+
+// clearFacilityHotspots unlinks the floor-plan hotspots of deleted facilities inside the given transaction,
+// the hotspots stay on the floor plan without a facility
+func clearFacilityHotspots(ctx context.Context, tx *gorm.DB, facilityIDs []int) error {
+	return tx.WithContext(ctx).
+		Model(&entity.FloorPlanHotspot{}).
+		Where("facility_id IN ?", facilityIDs).
+		Update("facility_id", nil).Error
 }
 
 func (b *BuildingRepositoryImpl) DeleteBuildingByID(ctx context.Context, buildingID string) error {
@@ -498,16 +800,42 @@ func (b *BuildingRepositoryImpl) GetBuildingReviewsByID(ctx context.Context, bui
 		return nil, err
 	}
 
-	rows, err := squirrel.Select("r.id", "r.building_id", "r.user_id", "r.rating", "r.message", "r.created_at", "r.updated_at", "u.id", "ud.name", "p.url").
+	query := squirrel.Select("r.id", "r.building_id", "r.user_id", "r.rating", "r.location_rating", "r.cleanliness_rating", "r.facilities_rating", "r.value_rating", "r.connectivity_rating", "r.message", "r.created_at", "r.updated_at", "u.id", "ud.name", "p.url", "rr.message", "rr.created_at", "rr.updated_at").
 		From("reviews r").
 		Join("users u ON u.id = r.user_id").
 		Join("user_details ud ON ud.user_id = u.id").
 		Join("profile_pictures p ON p.id = ud.picture_id").
+		LeftJoin("review_replies rr ON rr.review_id = r.id").
 		Where("r.building_id = ?", buildingID).
-		Where("r.deleted_at IS NULL").
-		OrderBy("r.created_at DESC").
-		Limit(uint64(filter.Limit)).
-		Offset(uint64(filter.Offset)).
+		Where(squirrel.Eq{"r.status": entity.VisibleReviewStatus}).
+		Where("r.deleted_at IS NULL")
+
+	if filter.Rating != 0 {
+		query = query.Where("r.rating = ?", filter.Rating)
+	}
+
+	column, desc := ReviewCursorSort(filter.SortBy, filter.Order)
+	if filter.Enabled {
+		if condition, args := filter.Where(column, "r.id", desc); condition != "" {
+			query = query.Where(condition, args...)
+		}
+
+		query = query.
+			OrderBy(filter.OrderBy(column, "r.id", desc)...).
+			Limit(uint64(filter.Limit + 1))
+	} else {
+		direction := "ASC"
+		if desc {
+			direction = "DESC"
+		}
+
+		query = query.
+			OrderBy(column+" "+direction, "r.created_at DESC").
+			Limit(uint64(filter.Limit)).
+			Offset(uint64(filter.Offset))
+	}
+
+	rows, err := query.
 		RunWith(db).
 		QueryContext(ctx)
 	if err != nil {
@@ -523,27 +851,773 @@ func (b *BuildingRepositoryImpl) GetBuildingReviewsByID(ctx context.Context, bui
 		var review entity.Review
 		var user entity.User
 		var picture entity.ProfilePicture
-		err := rows.Scan(&review.ID, &review.BuildingID, &review.UserID, &review.Rating, &review.Message, &review.CreatedAt, &review.UpdatedAt, &user.ID, &user.Detail.Name, &picture.Url)
+		var replyMessage sql.NullString
+		var replyCreatedAt, replyUpdatedAt sql.NullTime
+		err := rows.Scan(&review.ID, &review.BuildingID, &review.UserID, &review.Rating, &review.LocationRating, &review.CleanlinessRating, &review.FacilitiesRating, &review.ValueRating, &review.ConnectivityRating, &review.Message, &review.CreatedAt, &review.UpdatedAt, &user.ID, &user.Detail.Name, &picture.Url, &replyMessage, &replyCreatedAt, &replyUpdatedAt)
 		if err != nil {
 			return nil, err
 		}
 		user.Detail.Picture = picture
 		review.User = user
+		if replyMessage.Valid {
+			review.Reply = &entity.ReviewReply{
+				ReviewID:  review.ID,
+				Message:   replyMessage.String,
+				CreatedAt: replyCreatedAt.Time,
+				UpdatedAt: replyUpdatedAt.Time,
+			}
+		}
 		reviews = append(reviews, review)
 	}
 
-	return &reviews, nil
-}
+	if len(reviews) == 0 {
+		return &reviews, nil
+	}
 
-func (b *BuildingRepositoryImpl) CountBuildingReviewsByID(ctx context.Context, buildingID string) (int64, error) {
-	var count int64
-	err := b.db.WithContext(ctx).
-		Model(&entity.Review{}).
-		Where("building_id = ?", buildingID).
-		Count(&count).Error
+	reviewIDs := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+
+	pictures := new(entity.ReviewPictures)
+	err = b.db.WithContext(ctx).
+		Where("review_id IN ?", reviewIDs).
+		Order("created_at ASC").
+		Find(pictures).Error
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return count, nil
+	for i := range reviews {
+		for _, picture := range *pictures {
+			if picture.ReviewID == reviews[i].ID {
+				reviews[i].Pictures = append(reviews[i].Pictures, picture)
+			}
+		}
+	}
+
+	return &reviews, nil
+}
+
+// ReviewCursorSort returns the sort column and direction of the building reviews, the newest first when no sort is requested
+func ReviewCursorSort(sortBy string, order string) (string, bool) {
+	if sortBy == "rating" {
+		return "r.rating", order != "asc"
+	}
+
+	return "r.created_at", order != "asc"
+}
+
+// GetBuildingReviewPictures returns the guest photos of a building, newest first, from its visible reviews only
+func (b *BuildingRepositoryImpl) GetBuildingReviewPictures(ctx context.Context, buildingID string, offset int, limit int) (*entity.ReviewPictures, int64, error) {
+	pictures := new(entity.ReviewPictures)
+	var count int64
+
+	query := b.db.WithContext(ctx).
+		Model(&entity.ReviewPicture{}).
+		Joins("JOIN `reviews` ON `reviews`.`id` = `review_pictures`.`review_id`").
+		Where("`review_pictures`.`building_id` = ?", buildingID).
+		Where("`reviews`.`status` IN ? AND `reviews`.`deleted_at` IS NULL", entity.VisibleReviewStatus)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.
+		Order("`review_pictures`.`created_at` DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(pictures).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pictures, count, nil
+}
+
+func (b *BuildingRepositoryImpl) CountBuildingReviewsByID(ctx context.Context, buildingID string, rating int) (int64, error) {
+	var count int64
+	query := b.db.WithContext(ctx).
+		Model(&entity.Review{}).
+		Where("building_id = ? AND status IN ?", buildingID, entity.VisibleReviewStatus)
+
+	if rating != 0 {
+		query = query.Where("rating = ?", rating)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetBuildingRatingBreakdown averages the sub-ratings of the visible reviews and counts them by star
+func (b *BuildingRepositoryImpl) GetBuildingRatingBreakdown(ctx context.Context, buildingID string) (*entity.RatingBreakdown, error) {
+	breakdown := new(entity.RatingBreakdown)
+	err := b.db.WithContext(ctx).
+		Model(&entity.Review{}).
+		Select("AVG(location_rating) AS location, AVG(cleanliness_rating) AS cleanliness, "+
+			"AVG(facilities_rating) AS facilities, AVG(value_rating) AS value, AVG(connectivity_rating) AS connectivity, "+
+			"COUNT(CASE WHEN rating = 1 THEN 1 END) AS one_star, COUNT(CASE WHEN rating = 2 THEN 1 END) AS two_stars, "+
+			"COUNT(CASE WHEN rating = 3 THEN 1 END) AS three_stars, COUNT(CASE WHEN rating = 4 THEN 1 END) AS four_stars, "+
+			"COUNT(CASE WHEN rating = 5 THEN 1 END) AS five_stars").
+		Where("building_id = ? AND status IN ?", buildingID, entity.VisibleReviewStatus).
+		Scan(breakdown).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return breakdown, nil
+}
+
+func (b *BuildingRepositoryImpl) GetUserFavoriteBuildings(ctx context.Context, userID string, offset int, limit int) (*entity.Buildings, int64, error) {
+	buildings := &entity.Buildings{}
+	var count int64
+
+	query := b.db.WithContext(ctx).
+		Preload("Pictures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`pictures`.`index` ASC")
+		}).
+		Joins("District").
+		Joins("City").
+		Joins("JOIN `favorites` ON `favorites`.`building_id` = `buildings`.`id`").
+		Model(&entity.Building{}).
+		Where("`favorites`.`user_id` = ?", userID).
+		Where("`buildings`.`is_published` = ?", true)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.
+		Order("`favorites`.`created_at` DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(buildings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return buildings, count, nil
+}
+
+func (b *BuildingRepositoryImpl) GetFavoritedBuildingIDs(ctx context.Context, userID string, buildingIDs []string) (map[string]bool, error) {
+	favorited := make(map[string]bool)
+	if len(buildingIDs) == 0 {
+		return favorited, nil
+	}
+
+	var ids []string
+	err := b.db.WithContext(ctx).
+		Model(&entity.Favorite{}).
+		Where("user_id = ?", userID).
+		Where("building_id IN ?", buildingIDs).
+		Pluck("building_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		favorited[id] = true
+	}
+
+	return favorited, nil
+}
+
+func (b *BuildingRepositoryImpl) CountBuildingFavoritesByID(ctx context.Context, buildingID string) (int64, error) {
+	var count int64
+	err := b.db.WithContext(ctx).
+		Model(&entity.Favorite{}).
+		Where("building_id = ?", buildingID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (b *BuildingRepositoryImpl) GetMostFavoritedBuildings(ctx context.Context, limit int) (*entity.FavoritesStat, error) {
+	rows, err := b.db.WithContext(ctx).
+		Model(&entity.Favorite{}).
+		Select("buildings.id, buildings.name, COUNT(favorites.user_id) as total").
+		Joins("JOIN buildings ON buildings.id = favorites.building_id").
+		Where("buildings.deleted_at IS NULL").
+		Group("buildings.id").
+		Order("total DESC").
+		Limit(limit).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var favoritesStat entity.FavoritesStat
+	for rows.Next() {
+		var favoriteStat entity.FavoriteStat
+		err = rows.Scan(&favoriteStat.BuildingID, &favoriteStat.BuildingName, &favoriteStat.Total)
+		if err != nil {
+			return nil, err
+		}
+
+		favoritesStat = append(favoritesStat, favoriteStat)
+	}
+
+	return &favoritesStat, nil
+}
+
+func (b *BuildingRepositoryImpl) AddFavorite(ctx context.Context, favorite *entity.Favorite) error {
+	var count int64
+	err := b.db.WithContext(ctx).
+		Model(&entity.Building{}).
+		Where("id = ? AND is_published = ?", favorite.BuildingID, true).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	// only published buildings can be added to favorites
+	if count == 0 {
+		return err2.ErrBuildingNotFound
+	}
+
+	err = b.db.WithContext(ctx).Create(favorite).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "Duplicate entry"):
+			return err2.ErrFavoriteAlreadyExist
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_favorites_building`"):
+			return err2.ErrBuildingNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *BuildingRepositoryImpl) DeleteFavorite(ctx context.Context, userID string, buildingID string) error {
+	res := b.db.WithContext(ctx).
+		Where("user_id = ? AND building_id = ?", userID, buildingID).
+		Delete(&entity.Favorite{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrFavoriteNotFound
+	}
+
+	return nil
+}
+
+func (b *BuildingRepositoryImpl) GetBuildingRevisions(ctx context.Context, buildingID string, offset int, limit int) (*entity.BuildingRevisions, int64, error) {
+	revisions := new(entity.BuildingRevisions)
+	var count int64
+
+	query := b.db.WithContext(ctx).
+		Model(&entity.BuildingRevision{}).
+		Where("building_id = ?", buildingID)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.
+		Preload("CreatedBy.Detail.Picture").
+		Order("version DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(revisions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, count, nil
+}
+
+func (b *BuildingRepositoryImpl) GetBuildingRevisionByID(ctx context.Context, buildingID string, revisionID string) (*entity.BuildingRevision, error) {
+	revision := new(entity.BuildingRevision)
+	err := b.db.WithContext(ctx).
+		Preload("CreatedBy.Detail.Picture").
+		Where("id = ?", revisionID).
+		Where("building_id = ?", buildingID).
+		First(revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (b *BuildingRepositoryImpl) GetDraftBuildingRevision(ctx context.Context, buildingID string) (*entity.BuildingRevision, error) {
+	revision := new(entity.BuildingRevision)
+	err := b.db.WithContext(ctx).
+		Preload("CreatedBy.Detail.Picture").
+		Where("building_id = ?", buildingID).
+		Where("status = ?", constant.REVISION_DRAFT_STATUS).
+		First(revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// SaveDraftBuildingRevision merges the changes into the open draft of the building, or adds the draft when there is
+// none. The building row is locked so a building has a single open draft and concurrent edits aren't lost
+func (b *BuildingRepositoryImpl) SaveDraftBuildingRevision(ctx context.Context, initial *entity.BuildingRevision, draft *entity.BuildingRevision, merge func(pending string) (string, error)) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pending, err := lockDraftBuildingRevision(ctx, tx, draft.BuildingID)
+		if err != nil {
+			return err
+		}
+
+		if pending == nil {
+			return addDraftBuildingRevision(ctx, tx, initial, draft)
+		}
+
+		changes, err := merge(pending.Changes)
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).
+			Model(&entity.BuildingRevision{}).
+			Where("id = ?", pending.ID).
+			Updates(map[string]interface{}{
+				"changes":       changes,
+				"created_by_id": draft.CreatedByID,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		draft.ID = pending.ID
+		draft.Version = pending.Version
+		draft.Changes = changes
+		return nil
+	})
+}
+
+// ReplaceDraftBuildingRevision discards the open draft of the building and adds the given draft,
+// it returns the discarded draft
+func (b *BuildingRepositoryImpl) ReplaceDraftBuildingRevision(ctx context.Context, initial *entity.BuildingRevision, draft *entity.BuildingRevision) (*entity.BuildingRevision, error) {
+	var discarded *entity.BuildingRevision
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pending, err := lockDraftBuildingRevision(ctx, tx, draft.BuildingID)
+		if err != nil {
+			return err
+		}
+
+		if pending != nil {
+			err = tx.WithContext(ctx).
+				Model(&entity.BuildingRevision{}).
+				Where("id = ?", pending.ID).
+				Update("status", constant.REVISION_DISCARDED_STATUS).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		discarded = pending
+		return addDraftBuildingRevision(ctx, tx, initial, draft)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return discarded, nil
+}
+
+// lockDraftBuildingRevision locks the building row and returns its open draft, or nil when there is none
+func lockDraftBuildingRevision(ctx context.Context, tx *gorm.DB, buildingID string) (*entity.BuildingRevision, error) {
+	building := new(entity.Building)
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", buildingID).
+		First(building).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrBuildingNotFound
+		}
+		return nil, err
+	}
+
+	revision := new(entity.BuildingRevision)
+	err = tx.WithContext(ctx).
+		Where("building_id = ?", buildingID).
+		Where("status = ?", constant.REVISION_DRAFT_STATUS).
+		First(revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// addDraftBuildingRevision adds the draft as the next version, the first draft of a building is preceded
+// by the initial revision holding the live version
+func addDraftBuildingRevision(ctx context.Context, tx *gorm.DB, initial *entity.BuildingRevision, draft *entity.BuildingRevision) error {
+	var version int
+	err := tx.WithContext(ctx).
+		Model(&entity.BuildingRevision{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("building_id = ?", draft.BuildingID).
+		Scan(&version).Error
+	if err != nil {
+		return err
+	}
+
+	if version == 0 {
+		version++
+		initial.Version = version
+		err = tx.WithContext(ctx).Create(initial).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	draft.Version = version + 1
+	return tx.WithContext(ctx).Create(draft).Error
+}
+
+func (b *BuildingRepositoryImpl) UpdateBuildingRevisionStatus(ctx context.Context, revisionID string, status string) error {
+	res := b.db.WithContext(ctx).
+		Model(&entity.BuildingRevision{}).
+		Where("id = ?", revisionID).
+		Where("status = ?", constant.REVISION_DRAFT_STATUS).
+		Update("status", status)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrRevisionNotDraft
+	}
+
+	return nil
+}
+
+// PublishBuildingRevision applies a draft to the live building, the facilities and pictures added in the draft are
+// created before the removed ones are deleted so a picture added and removed in the same draft is left out.
+// The draft is read under the building row lock and handed to publish, which returns the changes to apply,
+// so edits saved to the draft meanwhile are either published or wait for the lock
+func (b *BuildingRepositoryImpl) PublishBuildingRevision(ctx context.Context, buildingID string, revisionID string, publish func(pending *entity.BuildingRevision) (*dto.RevisionChanges, error)) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pending, err := lockDraftBuildingRevision(ctx, tx, buildingID)
+		if err != nil {
+			return err
+		}
+
+		if pending == nil || pending.ID != revisionID {
+			return err2.ErrRevisionNotDraft
+		}
+
+		changes, err := publish(pending)
+		if err != nil {
+			return err
+		}
+
+		// the status check guards against publishing the same draft twice
+		res := tx.WithContext(ctx).
+			Model(&entity.BuildingRevision{}).
+			Where("id = ?", pending.ID).
+			Where("status = ?", constant.REVISION_DRAFT_STATUS).
+			Updates(map[string]interface{}{
+				"status":       constant.REVISION_PUBLISHED_STATUS,
+				"snapshot":     pending.Snapshot,
+				"published_at": pending.PublishedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return err2.ErrRevisionNotDraft
+		}
+
+		building := changes.ToEntity(buildingID)
+		addedFacilities := changes.AddedFacilities.ToEntity(buildingID)
+		removedFacilities := changes.RemovedFacilities
+		addedPictures := changes.AddedPictures.ToEntity(buildingID)
+		removedPictures := changes.RemovedPictures
+
+		err = updateBuilding(ctx, tx, building)
+		if err != nil {
+			return err
+		}
+
+		if len(*addedFacilities) > 0 {
+			err = tx.WithContext(ctx).Create(addedFacilities).Error
+			if err != nil {
+				if strings.Contains(err.Error(), "CONSTRAINT `fk_facilities_category` FOREIGN KEY (`category_id`)") {
+					return err2.ErrInvalidCategoryID
+				}
+				return err
+			}
+		}
+
+		if len(removedFacilities) > 0 {
+			err = tx.WithContext(ctx).
+				Where("id IN ?", removedFacilities).
+				Where("building_id = ?", building.ID).
+				Delete(&entity.Facility{}).Error
+			if err != nil {
+				return err
+			}
+
+			err = clearFacilityHotspots(ctx, tx, removedFacilities)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(*addedPictures) > 0 {
+			err = tx.WithContext(ctx).Create(addedPictures).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(removedPictures) > 0 {
+			err = tx.WithContext(ctx).
+				Unscoped().
+				Where("id IN ?", removedPictures).
+				Where("building_id = ?", building.ID).
+				Delete(&entity.Picture{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *BuildingRepositoryImpl) UpdateBuildingSchedule(ctx context.Context, building *entity.Building) error {
+	// a map is used so cleared schedules are written as null
+	schedule := map[string]interface{}{
+		"publish_at":      building.PublishAt,
+		"unpublish_at":    building.UnpublishAt,
+		"scheduled_by_id": nil,
+	}
+	if building.ScheduledByID != "" {
+		schedule["scheduled_by_id"] = building.ScheduledByID
+	}
+
+	err := b.db.WithContext(ctx).
+		Model(&entity.Building{}).
+		Where("id = ?", building.ID).
+		Updates(schedule).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *BuildingRepositoryImpl) GetScheduledBuildings(ctx context.Context, until time.Time) (*entity.Buildings, error) {
+	buildings := new(entity.Buildings)
+	err := b.db.WithContext(ctx).
+		Preload("ScheduledBy.Detail").
+		Where("publish_at <= ? OR unpublish_at <= ?", until, until).
+		Find(buildings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return buildings, nil
+}
+
+func (b *BuildingRepositoryImpl) GetScheduledBuildingByID(ctx context.Context, buildingID string) (*entity.Building, error) {
+	building := new(entity.Building)
+	err := b.db.WithContext(ctx).
+		Preload("ScheduledBy.Detail").
+		Where("id = ?", buildingID).
+		First(building).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrBuildingNotFound
+		}
+		return nil, err
+	}
+
+	return building, nil
+}
+
+func (b *BuildingRepositoryImpl) UpdateBuildingOpeningHours(ctx context.Context, building *entity.Building) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).
+			Model(&entity.Building{}).
+			Where("id = ?", building.ID).
+			Updates(map[string]interface{}{
+				"timezone":            building.Timezone,
+				"is_always_open":      building.IsAlwaysOpen,
+				"is_open_on_weekends": building.IsOpenOnWeekends,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// the hours are replaced as a whole
+		err = tx.Where("building_id = ?", building.ID).Delete(&entity.OpeningHour{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("building_id = ?", building.ID).Delete(&entity.OpeningException{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(building.OpeningHours) > 0 {
+			err = tx.Create(&building.OpeningHours).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(building.OpeningExceptions) > 0 {
+			err = tx.Create(&building.OpeningExceptions).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *BuildingRepositoryImpl) GetBuildingFloorPlans(ctx context.Context, buildingID string) (*entity.FloorPlans, error) {
+	floorPlans := &entity.FloorPlans{}
+	err := b.db.WithContext(ctx).
+		Preload("Hotspots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`floor_plan_hotspots`.`id` ASC")
+		}).
+		Preload("Hotspots.Facility.Category").
+		Model(&entity.FloorPlan{}).
+		Where("building_id = ?", buildingID).
+		Order("floor ASC, created_at ASC").
+		Find(floorPlans).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return floorPlans, nil
+}
+
+func (b *BuildingRepositoryImpl) GetFloorPlanByID(ctx context.Context, buildingID string, floorPlanID string) (*entity.FloorPlan, error) {
+	floorPlan := &entity.FloorPlan{}
+	err := b.db.WithContext(ctx).
+		Preload("Hotspots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`floor_plan_hotspots`.`id` ASC")
+		}).
+		Preload("Hotspots.Facility.Category").
+		Model(&entity.FloorPlan{}).
+		Where("id = ?", floorPlanID).
+		Where("building_id = ?", buildingID).
+		First(floorPlan).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, err2.ErrFloorPlanNotFound
+		}
+
+		return nil, err
+	}
+
+	return floorPlan, nil
+}
+
+func (b *BuildingRepositoryImpl) CountBuildingFloorPlansByID(ctx context.Context, buildingID string) (int64, error) {
+	var count int64
+	err := b.db.WithContext(ctx).
+		Model(&entity.FloorPlan{}).
+		Where("building_id = ?", buildingID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (b *BuildingRepositoryImpl) CountBuildingFacilitiesByIDs(ctx context.Context, buildingID string, facilityIDs []int) (int64, error) {
+	var count int64
+	err := b.db.WithContext(ctx).
+		Model(&entity.Facility{}).
+		Where("building_id = ?", buildingID).
+		Where("id IN ?", facilityIDs).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (b *BuildingRepositoryImpl) AddFloorPlan(ctx context.Context, floorPlan *entity.FloorPlan) error {
+	err := b.db.WithContext(ctx).
+		Model(&entity.FloorPlan{}).
+		Create(floorPlan).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *BuildingRepositoryImpl) ReplaceFloorPlanHotspots(ctx context.Context, floorPlanID string, hotspots *entity.FloorPlanHotspots) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("floor_plan_id = ?", floorPlanID).Delete(&entity.FloorPlanHotspot{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(*hotspots) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Facility").Create(hotspots).Error
+	})
+}
+
+func (b *BuildingRepositoryImpl) DeleteFloorPlanByID(ctx context.Context, buildingID string, floorPlanID string) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var floorPlan entity.FloorPlan
+		err := tx.Where("id = ?", floorPlanID).
+			Where("building_id = ?", buildingID).
+			First(&floorPlan).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return err2.ErrFloorPlanNotFound
+			}
+			return err
+		}
+
+		err = tx.Where("floor_plan_id = ?", floorPlanID).Delete(&entity.FloorPlanHotspot{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&floorPlan).Error
+	})
 }
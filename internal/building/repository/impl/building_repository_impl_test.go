@@ -0,0 +1,71 @@
+package impl
+
+import (
+	"context"
+	err2 "office-booking-backend/pkg/errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type TestSuiteBuildingRepository struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	DB   *gorm.DB
+	repo *BuildingRepositoryImpl
+}
+
+func TestBuildingRepository(t *testing.T) {
+	suite.Run(t, new(TestSuiteBuildingRepository))
+}
+
+func (s *TestSuiteBuildingRepository) SetupTest() {
+	mockConn, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+
+	s.mock = mock
+	s.DB, err = gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockConn,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	s.Require().NoError(err)
+
+	s.repo = &BuildingRepositoryImpl{db: s.DB}
+}
+
+func (s *TestSuiteBuildingRepository) TearDownTest() {
+	s.mock = nil
+	s.repo = nil
+}
+
+func (s *TestSuiteBuildingRepository) TestDeleteBuildingFacilityByID() {
+	deleteFacility := regexp.QuoteMeta("DELETE FROM `facilities` WHERE id = ? AND building_id = ?")
+	clearHotspots := regexp.QuoteMeta("UPDATE `floor_plan_hotspots` SET `facility_id`=? WHERE facility_id IN (?)")
+
+	s.Run("Success", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(deleteFacility).WithArgs(1, "building").WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(clearHotspots).WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteBuildingFacilityByID(context.Background(), "building", 1)
+		s.NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("Facility Not Found", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(deleteFacility).WithArgs(1, "building").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectRollback()
+
+		err := s.repo.DeleteBuildingFacilityByID(context.Background(), "building", 1)
+		s.Equal(err2.ErrFacilityNotFound, err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
@@ -1 +1,279 @@
 package mock
+
+import (
+	"context"
+	"office-booking-backend/internal/building/dto"
+	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BuildingRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *BuildingRepositoryMock) GetAllBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam, isPublishedOnly bool) (*entity.Buildings, int64, error) {
+	args := b.Called(ctx, filter, isPublishedOnly)
+	return args.Get(0).(*entity.Buildings), args.Get(1).(int64), args.Error(2)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingFacets(ctx context.Context, filter *dto.SearchBuildingQueryParam, isPublishedOnly bool) (*entity.BuildingFacets, error) {
+	args := b.Called(ctx, filter, isPublishedOnly)
+	return args.Get(0).(*entity.BuildingFacets), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetSearchableBuildings(ctx context.Context) (*entity.Buildings, error) {
+	args := b.Called(ctx)
+	return args.Get(0).(*entity.Buildings), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetSearchableBuildingByID(ctx context.Context, buildingID string) (*entity.Building, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(*entity.Building), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingsForExport(ctx context.Context) (*entity.Buildings, error) {
+	args := b.Called(ctx)
+	return args.Get(0).(*entity.Buildings), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingDetailByID(ctx context.Context, id string, isPublishedOnly bool) (*entity.Building, error) {
+	args := b.Called(ctx, id, isPublishedOnly)
+	return args.Get(0).(*entity.Building), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingDetailsByIDs(ctx context.Context, ids []string, isPublishedOnly bool) (*entity.Buildings, error) {
+	args := b.Called(ctx, ids, isPublishedOnly)
+	return args.Get(0).(*entity.Buildings), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetFacilityCategories(ctx context.Context) (*entity.Categories, error) {
+	args := b.Called(ctx)
+	return args.Get(0).(*entity.Categories), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetCities(ctx context.Context) (*entity.Cities, error) {
+	args := b.Called(ctx)
+	return args.Get(0).(*entity.Cities), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetDistrictsByCityID(ctx context.Context, cityID int) (*entity.Districts, error) {
+	args := b.Called(ctx, cityID)
+	return args.Get(0).(*entity.Districts), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetDistrictByID(ctx context.Context, districtID int) (*entity.District, error) {
+	args := b.Called(ctx, districtID)
+	return args.Get(0).(*entity.District), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingReviewsByID(ctx context.Context, buildingID string, filter *dto.GetBuildingReviewsQueryParam) (*entity.Reviews, error) {
+	args := b.Called(ctx, buildingID, filter)
+	return args.Get(0).(*entity.Reviews), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingCountByCity(ctx context.Context) (*entity.CitiesStat, error) {
+	args := b.Called(ctx)
+	return args.Get(0).(*entity.CitiesStat), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error) {
+	args := b.Called(ctx, r)
+	return args.Get(0).(*entity.TimeBucketStats), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetUserFavoriteBuildings(ctx context.Context, userID string, offset int, limit int) (*entity.Buildings, int64, error) {
+	args := b.Called(ctx, userID, offset, limit)
+	return args.Get(0).(*entity.Buildings), args.Get(1).(int64), args.Error(2)
+}
+
+func (b *BuildingRepositoryMock) GetFavoritedBuildingIDs(ctx context.Context, userID string, buildingIDs []string) (map[string]bool, error) {
+	args := b.Called(ctx, userID, buildingIDs)
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) CountBuildingFavoritesByID(ctx context.Context, buildingID string) (int64, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetMostFavoritedBuildings(ctx context.Context, limit int) (*entity.FavoritesStat, error) {
+	args := b.Called(ctx, limit)
+	return args.Get(0).(*entity.FavoritesStat), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) AddFavorite(ctx context.Context, favorite *entity.Favorite) error {
+	args := b.Called(ctx, favorite)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) DeleteFavorite(ctx context.Context, userID string, buildingID string) error {
+	args := b.Called(ctx, userID, buildingID)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) AddPicture(ctx context.Context, picture *entity.Picture) error {
+	args := b.Called(ctx, picture)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) AddFacility(ctx context.Context, facility *entity.Facilities) error {
+	args := b.Called(ctx, facility)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) CreateBuilding(ctx context.Context, building *entity.Building) error {
+	args := b.Called(ctx, building)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) UpdateBuildingByID(ctx context.Context, building *entity.Building) error {
+	args := b.Called(ctx, building)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) CountBuildingPicturesByID(ctx context.Context, buildingID string) (int64, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) CountBuildingReviewsByID(ctx context.Context, buildingID string, rating int) (int64, error) {
+	args := b.Called(ctx, buildingID, rating)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingRatingBreakdown(ctx context.Context, buildingID string) (*entity.RatingBreakdown, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(*entity.RatingBreakdown), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingReviewPictures(ctx context.Context, buildingID string, offset int, limit int) (*entity.ReviewPictures, int64, error) {
+	args := b.Called(ctx, buildingID, offset, limit)
+	return args.Get(0).(*entity.ReviewPictures), args.Get(1).(int64), args.Error(2)
+}
+
+func (b *BuildingRepositoryMock) IsBuildingExist(ctx context.Context, buildingID string) (bool, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) DeleteBuildingPicturesByID(ctx context.Context, buildingID string, pictureID string) error {
+	args := b.Called(ctx, buildingID, pictureID)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) DeleteBuildingFacilityByID(ctx context.Context, buildingID string, facilityID int) error {
+	args := b.Called(ctx, buildingID, facilityID)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) DeleteBuildingByID(ctx context.Context, buildingID string) error {
+	args := b.Called(ctx, buildingID)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingRevisions(ctx context.Context, buildingID string, offset int, limit int) (*entity.BuildingRevisions, int64, error) {
+	args := b.Called(ctx, buildingID, offset, limit)
+	return args.Get(0).(*entity.BuildingRevisions), args.Get(1).(int64), args.Error(2)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingRevisionByID(ctx context.Context, buildingID string, revisionID string) (*entity.BuildingRevision, error) {
+	args := b.Called(ctx, buildingID, revisionID)
+	return args.Get(0).(*entity.BuildingRevision), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetDraftBuildingRevision(ctx context.Context, buildingID string) (*entity.BuildingRevision, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(*entity.BuildingRevision), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) SaveDraftBuildingRevision(ctx context.Context, initial *entity.BuildingRevision, draft *entity.BuildingRevision, merge func(pending string) (string, error)) error {
+	args := b.Called(ctx, initial, draft)
+	// the changes of the open draft returned by the mock are merged like the repository does
+	if pending := args.String(0); pending != "" {
+		changes, err := merge(pending)
+		if err != nil {
+			return err
+		}
+		draft.Changes = changes
+	}
+	return args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) ReplaceDraftBuildingRevision(ctx context.Context, initial *entity.BuildingRevision, draft *entity.BuildingRevision) (*entity.BuildingRevision, error) {
+	args := b.Called(ctx, initial, draft)
+	return args.Get(0).(*entity.BuildingRevision), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) UpdateBuildingRevisionStatus(ctx context.Context, revisionID string, status string) error {
+	args := b.Called(ctx, revisionID, status)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) PublishBuildingRevision(ctx context.Context, buildingID string, revisionID string, publish func(pending *entity.BuildingRevision) (*dto.RevisionChanges, error)) error {
+	args := b.Called(ctx, buildingID, revisionID)
+	// the locked draft returned by the mock is published like the repository does
+	if pending, ok := args.Get(0).(*entity.BuildingRevision); ok && pending != nil {
+		if _, err := publish(pending); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) UpdateBuildingSchedule(ctx context.Context, building *entity.Building) error {
+	args := b.Called(ctx, building)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) GetScheduledBuildings(ctx context.Context, until time.Time) (*entity.Buildings, error) {
+	args := b.Called(ctx, until)
+	return args.Get(0).(*entity.Buildings), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetScheduledBuildingByID(ctx context.Context, buildingID string) (*entity.Building, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(*entity.Building), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) UpdateBuildingOpeningHours(ctx context.Context, building *entity.Building) error {
+	args := b.Called(ctx, building)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) GetBuildingFloorPlans(ctx context.Context, buildingID string) (*entity.FloorPlans, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(*entity.FloorPlans), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) GetFloorPlanByID(ctx context.Context, buildingID string, floorPlanID string) (*entity.FloorPlan, error) {
+	args := b.Called(ctx, buildingID, floorPlanID)
+	return args.Get(0).(*entity.FloorPlan), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) CountBuildingFloorPlansByID(ctx context.Context, buildingID string) (int64, error) {
+	args := b.Called(ctx, buildingID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) CountBuildingFacilitiesByIDs(ctx context.Context, buildingID string, facilityIDs []int) (int64, error) {
+	args := b.Called(ctx, buildingID, facilityIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (b *BuildingRepositoryMock) AddFloorPlan(ctx context.Context, floorPlan *entity.FloorPlan) error {
+	args := b.Called(ctx, floorPlan)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) ReplaceFloorPlanHotspots(ctx context.Context, floorPlanID string, hotspots *entity.FloorPlanHotspots) error {
+	args := b.Called(ctx, floorPlanID, hotspots)
+	return args.Error(0)
+}
+
+func (b *BuildingRepositoryMock) DeleteFloorPlanByID(ctx context.Context, buildingID string, floorPlanID string) error {
+	args := b.Called(ctx, buildingID, floorPlanID)
+	return args.Error(0)
+}
@@ -4,26 +4,49 @@ import (
 	"context"
 	"io"
 	"office-booking-backend/internal/building/dto"
+	"office-booking-backend/pkg/utils/timeseries"
 	"office-booking-backend/pkg/utils/validator"
 )
 
 type BuildingService interface {
-	GetAllPublishedBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam) (*dto.BriefPublishedBuildingsResponse, int64, error)
+	GetAllPublishedBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam, userID string) (*dto.BriefPublishedBuildingsResponse, int64, *dto.SearchFacetsResponse, error)
+	RebuildSearchIndex(ctx context.Context) error
+	GetSimilarBuildings(ctx context.Context, buildingID string, filter *dto.SimilarBuildingQueryParam, userID string) (*dto.BriefPublishedBuildingsResponse, error)
+	CompareBuildings(ctx context.Context, filter *dto.CompareBuildingQueryParam) (*dto.BuildingComparisonResponse, error)
 	GetAllBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam) (*dto.BriefBuildingsResponse, int64, error)
-	GetPublishedBuildingDetailByID(ctx context.Context, id string) (*dto.FullPublishedBuildingResponse, error)
+	GetPublishedBuildingDetailByID(ctx context.Context, id string, userID string) (*dto.FullPublishedBuildingResponse, error)
 	GetBuildingDetailByID(ctx context.Context, id string) (*dto.FullBuildingResponse, error)
 	GetFacilityCategories(ctx context.Context) (*dto.FacilityCategoriesResponse, error)
 	GetCities(ctx context.Context) (*dto.CitiesResponse, error)
 	GetDistrictsByCityID(ctx context.Context, cityID int) (*dto.DistrictsResponse, error)
 	GetBuildingReviews(ctx context.Context, buildingID string, filter *dto.GetBuildingReviewsQueryParam) (*dto.BriefBuildingReviewsResponse, int64, error)
-	GetBuildingStatistics(ctx context.Context) (*dto.BuildingStatResponse, error)
+	GetBuildingReviewPictures(ctx context.Context, buildingID string, filter *dto.ReviewPictureQueryParam) (*dto.ReviewPicturesResponse, int64, error)
+	GetBuildingStatistics(ctx context.Context, filter *timeseries.Query) (*dto.BuildingStatResponse, error)
+	GetUserFavorites(ctx context.Context, userID string, filter *dto.FavoriteQueryParam) (*dto.BriefPublishedBuildingsResponse, int64, error)
+	AddFavorite(ctx context.Context, userID string, buildingID string) error
+	RemoveFavorite(ctx context.Context, userID string, buildingID string) error
 	CreateEmptyBuilding(ctx context.Context, creatorID string) (string, error)
-	UpdateBuilding(ctx context.Context, building *dto.UpdateBuildingRequest, buildingID string) error
+	UpdateBuilding(ctx context.Context, building *dto.UpdateBuildingRequest, buildingID string, editorID string) (*dto.BuildingRevisionResponse, error)
 	UpdateBuildingPublishState(ctx context.Context, building *dto.PublishRequest, buildingID string) error
-	AddBuildingPicture(ctx context.Context, buildingID string, index int, alt string, picture io.Reader) (*dto.AddPictureResponse, error)
-	AddBuildingFacility(ctx context.Context, buildingID string, facilities *dto.AddFacilitiesRequest) error
+	ScheduleBuildingPublishState(ctx context.Context, schedule *dto.SchedulePublishRequest, buildingID string, scheduledByID string) error
+	CancelBuildingPublishSchedule(ctx context.Context, buildingID string) error
+	UpdateBuildingOpeningHours(ctx context.Context, hours *dto.OpeningHoursRequest, buildingID string) error
+	AddBuildingPicture(ctx context.Context, buildingID string, index int, alt string, picture io.Reader, editorID string) (*dto.AddPictureResponse, error)
+	AddBuildingFacility(ctx context.Context, buildingID string, facilities *dto.AddFacilitiesRequest, editorID string) (*dto.BuildingRevisionResponse, error)
+	GetPublishedBuildingFloorPlans(ctx context.Context, buildingID string) (*dto.FloorPlansResponse, error)
+	GetBuildingFloorPlans(ctx context.Context, buildingID string) (*dto.FloorPlansResponse, error)
+	AddBuildingFloorPlan(ctx context.Context, buildingID string, floor int, name string, picture io.Reader) (*dto.FloorPlanResponse, error)
+	UpdateFloorPlanHotspots(ctx context.Context, buildingID string, floorPlanID string, hotspots *dto.FloorPlanHotspotsRequest) (*dto.FloorPlanResponse, error)
+	DeleteBuildingFloorPlan(ctx context.Context, buildingID string, floorPlanID string) error
 	ValidateBuilding(ctx context.Context, buildingID string) (*validator.ErrorsResponse, error)
-	DeleteBuildingPicture(ctx context.Context, buildingID string, pictureID string) error
-	DeleteBuildingFacility(ctx context.Context, buildingID string, facilityID int) error
+	DeleteBuildingPicture(ctx context.Context, buildingID string, pictureID string, editorID string) (*dto.BuildingRevisionResponse, error)
+	DeleteBuildingFacility(ctx context.Context, buildingID string, facilityID int, editorID string) (*dto.BuildingRevisionResponse, error)
 	DeleteBuilding(ctx context.Context, buildingID string) error
+	GetBuildingRevisions(ctx context.Context, buildingID string, filter *dto.RevisionQueryParam) (*dto.BuildingRevisionsResponse, int64, error)
+	PreviewBuildingRevision(ctx context.Context, buildingID string, revisionID string) (*dto.BuildingRevisionPreviewResponse, error)
+	PublishBuildingRevision(ctx context.Context, buildingID string, revisionID string) (*validator.ErrorsResponse, error)
+	DiscardBuildingRevision(ctx context.Context, buildingID string, revisionID string) error
+	RestoreBuildingRevision(ctx context.Context, buildingID string, revisionID string, editorID string) (*dto.BuildingRevisionResponse, error)
+	ImportBuildings(ctx context.Context, file io.Reader, format string, dryRun bool, creatorID string) (*dto.ImportBuildingsResponse, error)
+	ExportBuildings(ctx context.Context, format string) ([]byte, error)
 }
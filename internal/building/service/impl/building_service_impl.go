@@ -2,53 +2,460 @@ package impl
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"math"
+	service2 "office-booking-backend/internal/analytics/service"
 	"office-booking-backend/internal/building/dto"
 	"office-booking-backend/internal/building/repository"
 	"office-booking-backend/internal/building/service"
 	repository2 "office-booking-backend/internal/reservation/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/custom"
+	"office-booking-backend/pkg/database/redis"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/cursor"
+	"office-booking-backend/pkg/utils/geo"
 	"office-booking-backend/pkg/utils/imagekit"
+	"office-booking-backend/pkg/utils/search"
+	"office-booking-backend/pkg/utils/similarity"
+	"office-booking-backend/pkg/utils/spreadsheet"
+	"office-booking-backend/pkg/utils/timeseries"
 	"office-booking-backend/pkg/utils/validator"
+	"reflect"
+	"strings"
+	"time"
 
+	redis2 "github.com/go-redis/redis/v9"
+	"github.com/spf13/viper"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/google/uuid"
 )
 
+// searchFieldWeights sets how much a match in each building field counts towards the relevance
+var searchFieldWeights = map[string]float64{
+	"name":        3,
+	"city":        2,
+	"district":    2,
+	"facilities":  1.5,
+	"address":     1.5,
+	"description": 1,
+}
+
 type BuildingServiceImpl struct {
 	repo            repository.BuildingRepository
 	reservationRepo repository2.ReservationRepository
 	imgKitService   imagekit.ImgKitService
 	validator       validator.Validator
+	redisRepo       redis.RedisClient
+	analytics       service2.AnalyticsService
+	config          *viper.Viper
+	index           *search.Index
 }
 
-func NewBuildingServiceImpl(repo repository.BuildingRepository, reservationRepo repository2.ReservationRepository, imgKitService imagekit.ImgKitService, validator validator.Validator) service.BuildingService {
+func NewBuildingServiceImpl(repo repository.BuildingRepository, reservationRepo repository2.ReservationRepository, imgKitService imagekit.ImgKitService, validator validator.Validator, redisRepo redis.RedisClient, analytics service2.AnalyticsService, config *viper.Viper) service.BuildingService {
 	return &BuildingServiceImpl{
 		repo:            repo,
 		reservationRepo: reservationRepo,
 		imgKitService:   imgKitService,
 		validator:       validator,
+		redisRepo:       redisRepo,
+		analytics:       analytics,
+		config:          config,
+		index:           search.NewIndex(searchFieldWeights),
 	}
 }
 
-func (b *BuildingServiceImpl) GetAllPublishedBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam) (*dto.BriefPublishedBuildingsResponse, int64, error) {
+func (b *BuildingServiceImpl) GetAllPublishedBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam, userID string) (*dto.BriefPublishedBuildingsResponse, int64, *dto.SearchFacetsResponse, error) {
 	filter.EndDate = filter.StartDate.ToTime().AddDate(0, filter.Duration, 0)
 
+	err := prepareBuildingCursor(filter)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	hits := map[string]search.Hit{}
+	if filter.Query != "" {
+		results := b.index.Search(filter.Query)
+		filter.IDs = make([]string, 0, len(results))
+		for _, hit := range results {
+			filter.IDs = append(filter.IDs, hit.ID)
+			hits[hit.ID] = hit
+		}
+	}
+
 	count := int64(0)
+	buildings := new(entity.Buildings)
+	facets := new(entity.BuildingFacets)
 
 	filter.Offset = (filter.Page - 1) * filter.Limit
-	//	get all buildings
-	buildings, count, err := b.repo.GetAllBuildings(ctx, filter, true)
-	if err != nil {
-		log.Println("error when getting all buildings: ", err)
-		return nil, 0, err
+	errGroup, c := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		//	get all buildings
+		result, total, err := b.repo.GetAllBuildings(c, filter, true)
+		if err != nil {
+			log.Println("error when getting all buildings: ", err)
+			return err
+		}
+
+		buildings = result
+		count = total
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		result, err := b.repo.GetBuildingFacets(c, filter, true)
+		if err != nil {
+			log.Println("error when getting building facets: ", err)
+			return err
+		}
+
+		facets = result
+		return nil
+	})
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, 0, nil, err
+	}
+
+	if filter.Enabled {
+		*buildings = cursor.Paginate(&filter.Pagination, *buildings, filter.Limit, buildingCursorKey(filter.SortBy))
 	}
 
 	buildingsResponse := dto.NewBriefPublishedBuildingsResponse(buildings)
-	return buildingsResponse, count, nil
+	for i, building := range *buildings {
+		(*buildingsResponse)[i].Highlights = hits[building.ID].Highlights
+		if filter.HasLocation() {
+			distance := math.Round(geo.Haversine(*filter.Latitude, *filter.Longitude, building.Latitude, building.Longitude)*100) / 100
+			(*buildingsResponse)[i].Distance = &distance
+		}
+	}
+
+	err = b.markFavorites(ctx, userID, buildingsResponse)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	ids := make([]string, 0, len(*buildings))
+	for _, building := range *buildings {
+		ids = append(ids, building.ID)
+	}
+	b.analytics.TrackBuildingEvent(constant.BUILDING_IMPRESSION_EVENT, ids...)
+
+	return buildingsResponse, count, dto.NewSearchFacetsResponse(facets), nil
+}
+
+// prepareBuildingCursor decodes the cursor, the distance and relevance sorts can't be paginated with a cursor
+func prepareBuildingCursor(filter *dto.SearchBuildingQueryParam) error {
+	if !filter.Enabled {
+		return nil
+	}
+
+	if filter.SortBy == "pinpoint" || (filter.SortBy == "" && filter.Query != "") {
+		return err2.ErrCursorNotSupported
+	}
+
+	return filter.Decode()
+}
+
+// buildingCursorKey returns the sort value and id of a building, matching repository.BuildingCursorSort
+func buildingCursorKey(sortBy string) func(building *entity.Building) (string, string) {
+	return func(building *entity.Building) (string, string) {
+		switch sortBy {
+		case "annual_price":
+			return cursor.Int(building.AnnualPrice), building.ID
+		case "monthly_price":
+			return cursor.Int(building.MonthlyPrice), building.ID
+		case "capacity":
+			return cursor.Int(building.Capacity), building.ID
+		default:
+			return cursor.Time(building.CreatedAt), building.ID
+		}
+	}
+}
+
+// reviewCursorKey returns the sort value and id of a review, matching repository.ReviewCursorSort
+func reviewCursorKey(sortBy string) func(review *entity.Review) (string, string) {
+	return func(review *entity.Review) (string, string) {
+		if sortBy == "rating" {
+			return cursor.Int(review.Rating), review.ID
+		}
+
+		return cursor.Time(review.CreatedAt), review.ID
+	}
+}
+
+// RebuildSearchIndex reloads every published building into the full-text search index
+func (b *BuildingServiceImpl) RebuildSearchIndex(ctx context.Context) error {
+	buildings, err := b.repo.GetSearchableBuildings(ctx)
+	if err != nil {
+		log.Println("error when getting searchable buildings: ", err)
+		return err
+	}
+
+	docs := make([]search.Document, 0, len(*buildings))
+	for _, building := range *buildings {
+		docs = append(docs, *newSearchDocument(&building))
+	}
+
+	b.index.Replace(docs)
+	return nil
+}
+
+// reindexBuilding refreshes a single building in the search index, unpublished and deleted buildings are removed
+func (b *BuildingServiceImpl) reindexBuilding(ctx context.Context, buildingID string) {
+	building, err := b.repo.GetSearchableBuildingByID(ctx, buildingID)
+	if err != nil {
+		if err == err2.ErrBuildingNotFound {
+			b.index.Remove(buildingID)
+			return
+		}
+
+		log.Println("error when reindexing building: ", err)
+		return
+	}
+
+	b.index.Add(newSearchDocument(building))
+}
+
+func newSearchDocument(building *entity.Building) *search.Document {
+	facilities := make([]string, 0, len(building.Facilities))
+	for _, facility := range building.Facilities {
+		facilities = append(facilities, facility.Name)
+	}
+
+	return &search.Document{
+		ID: building.ID,
+		Fields: map[string]string{
+			"name":        building.Name,
+			"description": building.Description,
+			"address":     building.Address,
+			"city":        building.City.Name,
+			"district":    building.District.Name,
+			"facilities":  strings.Join(facilities, ", "),
+		},
+	}
+}
+
+// markFavorites sets the isFavorite flag on the given buildings, it does nothing for anonymous users
+func (b *BuildingServiceImpl) markFavorites(ctx context.Context, userID string, buildings *dto.BriefPublishedBuildingsResponse) error {
+	if userID == "" || buildings == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(*buildings))
+	for _, building := range *buildings {
+		ids = append(ids, building.ID)
+	}
+
+	favorited, err := b.repo.GetFavoritedBuildingIDs(ctx, userID, ids)
+	if err != nil {
+		log.Println("error when getting favorited building ids: ", err)
+		return err
+	}
+
+	for i := range *buildings {
+		(*buildings)[i].IsFavorite = favorited[(*buildings)[i].ID]
+	}
+
+	return nil
+}
+
+const (
+	// defaultSimilarBuildingsLimit is the number of recommendations when no limit is requested
+	defaultSimilarBuildingsLimit = 6
+	// maxSimilarCandidates is the number of ranked buildings kept in the cache,
+	// enough to fill the recommendations after the unavailable ones are filtered out
+	maxSimilarCandidates = 50
+)
+
+func (b *BuildingServiceImpl) GetSimilarBuildings(ctx context.Context, buildingID string, filter *dto.SimilarBuildingQueryParam, userID string) (*dto.BriefPublishedBuildingsResponse, error) {
+	ranking, err := b.getSimilarityRanking(ctx, buildingID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultSimilarBuildingsLimit
+	}
+
+	similar := &dto.BriefPublishedBuildingsResponse{}
+	if len(ranking) == 0 {
+		return similar, nil
+	}
+
+	ids := make([]string, 0, len(ranking))
+	distances := make(map[string]float64, len(ranking))
+	for _, result := range ranking {
+		ids = append(ids, result.ID)
+		distances[result.ID] = result.DistanceKm
+	}
+
+	// the ids keep the ranking order and the date filter drops the buildings reserved at that time
+	searchFilter := &dto.SearchBuildingQueryParam{
+		IDs:   ids,
+		Limit: limit,
+	}
+	if !filter.StartDate.ToTime().IsZero() {
+		searchFilter.StartDate = filter.StartDate
+		searchFilter.EndDate = filter.StartDate.ToTime().AddDate(0, filter.Duration, 0)
+	}
+
+	buildings, _, err := b.repo.GetAllBuildings(ctx, searchFilter, true)
+	if err != nil {
+		log.Println("error when getting similar buildings: ", err)
+		return nil, err
+	}
+
+	if len(*buildings) > 0 {
+		similar = dto.NewBriefPublishedBuildingsResponse(buildings)
+	}
+
+	for i := range *similar {
+		distance := math.Round(distances[(*similar)[i].ID]*100) / 100
+		(*similar)[i].Distance = &distance
+	}
+
+	if err := b.markFavorites(ctx, userID, similar); err != nil {
+		return nil, err
+	}
+
+	return similar, nil
+}
+
+// getSimilarityRanking returns the published buildings most similar to a building, the ranking is cached
+// and buildings unpublished in the meantime are dropped when the ranked buildings are loaded
+func (b *BuildingServiceImpl) getSimilarityRanking(ctx context.Context, buildingID string) ([]similarity.Result, error) {
+	key := fmt.Sprintf("similar-buildings:%s", buildingID)
+
+	cached, err := b.redisRepo.Get(ctx, key)
+	if err == nil {
+		ranking := []similarity.Result{}
+		if err := json.Unmarshal([]byte(cached), &ranking); err == nil {
+			return ranking, nil
+		}
+	} else if !errors.Is(err, redis2.Nil) {
+		log.Println("error when getting similar buildings from redis: ", err)
+	}
+
+	target, err := b.repo.GetSearchableBuildingByID(ctx, buildingID)
+	if err != nil {
+		if err != err2.ErrBuildingNotFound {
+			log.Println("error when getting published building: ", err)
+		}
+		return nil, err
+	}
+
+	buildings, err := b.repo.GetSearchableBuildings(ctx)
+	if err != nil {
+		log.Println("error when getting published buildings: ", err)
+		return nil, err
+	}
+
+	candidates := make([]similarity.Candidate, 0, len(*buildings))
+	for i := range *buildings {
+		candidates = append(candidates, newSimilarityCandidate(&(*buildings)[i]))
+	}
+
+	targetCandidate := newSimilarityCandidate(target)
+	ranking := similarity.Rank(&targetCandidate, candidates, similarity.DefaultWeights, maxSimilarCandidates)
+
+	ttl := b.config.GetDuration("similar.cacheTTL")
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	payload, err := json.Marshal(ranking)
+	if err == nil {
+		err = b.redisRepo.Set(ctx, key, string(payload), ttl)
+	}
+	if err != nil {
+		log.Println("error when caching similar buildings: ", err)
+	}
+
+	return ranking, nil
+}
+
+func newSimilarityCandidate(building *entity.Building) similarity.Candidate {
+	categoryIDs := make([]int, 0, len(building.Facilities))
+	for _, facility := range building.Facilities {
+		categoryIDs = append(categoryIDs, facility.CategoryID)
+	}
+
+	return similarity.Candidate{
+		ID:           building.ID,
+		Latitude:     building.Latitude,
+		Longitude:    building.Longitude,
+		MonthlyPrice: building.MonthlyPrice,
+		AnnualPrice:  building.AnnualPrice,
+		Capacity:     building.Capacity,
+		CategoryIDs:  categoryIDs,
+		Rating:       building.Rating,
+		ReviewCount:  building.ReviewCount,
+	}
+}
+
+func (b *BuildingServiceImpl) CompareBuildings(ctx context.Context, filter *dto.CompareBuildingQueryParam) (*dto.BuildingComparisonResponse, error) {
+	buildings, err := b.repo.GetBuildingDetailsByIDs(ctx, filter.IDs, true)
+	if err != nil {
+		log.Println("error when getting building details by ids: ", err)
+		return nil, err
+	}
+
+	byID := make(map[string]*entity.Building, len(*buildings))
+	for i := range *buildings {
+		byID[(*buildings)[i].ID] = &(*buildings)[i]
+	}
+
+	// the columns follow the requested order
+	compared := make([]*entity.Building, 0, len(filter.IDs))
+	for _, id := range filter.IDs {
+		building, ok := byID[id]
+		if !ok {
+			return nil, err2.ErrBuildingNotFound
+		}
+		compared = append(compared, building)
+	}
+
+	var availability []bool
+	if startDate := filter.StartDate.ToTime(); !startDate.IsZero() {
+		endDate := startDate.AddDate(0, filter.Duration, 0)
+		availability = make([]bool, len(compared))
+		for i, building := range compared {
+			availability[i] = isBuildingAvailable(building, startDate, endDate)
+		}
+	}
+
+	return dto.NewBuildingComparisonResponse(compared, availability), nil
+}
+
+// isBuildingAvailable checks the reservations loaded with the building detail the same way the search date filter does,
+// and that the building opens on the start date
+func isBuildingAvailable(building *entity.Building, startDate time.Time, endDate time.Time) bool {
+	for _, reservation := range building.Reservations {
+		if reservation.StatusID != constant.AWAITING_PAYMENT_STATUS && reservation.StatusID != constant.ACTIVE_STATUS {
+			continue
+		}
+
+		if !reservation.StartDate.After(endDate) && !reservation.EndDate.Before(startDate) {
+			return false
+		}
+	}
+
+	schedule, err := dto.NewOpeningSchedule(building)
+	if err != nil {
+		log.Println("error when reading building opening hours: ", err)
+		return false
+	}
+
+	return schedule.IsOpenOn(startDate)
 }
 
 func (b *BuildingServiceImpl) GetAllBuildings(ctx context.Context, filter *dto.SearchBuildingQueryParam) (*dto.BriefBuildingsResponse, int64, error) {
@@ -56,6 +463,11 @@ func (b *BuildingServiceImpl) GetAllBuildings(ctx context.Context, filter *dto.S
 
 	count := int64(0)
 
+	err := prepareBuildingCursor(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	filter.Offset = (filter.Page - 1) * filter.Limit
 	buildings, count, err := b.repo.GetAllBuildings(ctx, filter, false)
 	if err != nil {
@@ -63,29 +475,90 @@ func (b *BuildingServiceImpl) GetAllBuildings(ctx context.Context, filter *dto.S
 		return nil, 0, err
 	}
 
+	if filter.Enabled {
+		*buildings = cursor.Paginate(&filter.Pagination, *buildings, filter.Limit, buildingCursorKey(filter.SortBy))
+	}
+
 	buildingsResponse := dto.NewBriefBuildingsResponse(buildings)
 	return buildingsResponse, count, nil
 }
 
-func (b *BuildingServiceImpl) GetPublishedBuildingDetailByID(ctx context.Context, id string) (*dto.FullPublishedBuildingResponse, error) {
+func (b *BuildingServiceImpl) GetPublishedBuildingDetailByID(ctx context.Context, id string, userID string) (*dto.FullPublishedBuildingResponse, error) {
 	building, err := b.repo.GetBuildingDetailByID(ctx, id, true)
 	if err != nil {
 		log.Println("error when getting building detail by id: ", err)
 		return nil, err
 	}
 
+	breakdown, err := b.repo.GetBuildingRatingBreakdown(ctx, building.ID)
+	if err != nil {
+		log.Println("error when getting building rating breakdown: ", err)
+		return nil, err
+	}
+
 	buildingResponse := dto.NewFullPublishedBuildingResponse(building)
+	buildingResponse.Review.Breakdown = dto.NewRatingBreakdownResponse(breakdown)
+	if userID != "" {
+		favorited, err := b.repo.GetFavoritedBuildingIDs(ctx, userID, []string{building.ID})
+		if err != nil {
+			log.Println("error when getting favorited building ids: ", err)
+			return nil, err
+		}
+
+		buildingResponse.IsFavorite = favorited[building.ID]
+	}
+
+	b.analytics.TrackBuildingEvent(constant.BUILDING_VIEW_EVENT, building.ID)
+
 	return buildingResponse, nil
 }
 
 func (b *BuildingServiceImpl) GetBuildingDetailByID(ctx context.Context, id string) (*dto.FullBuildingResponse, error) {
-	building, err := b.repo.GetBuildingDetailByID(ctx, id, false)
-	if err != nil {
-		log.Println("error when getting building detail by id: ", err)
+	var building *entity.Building
+	var favoriteCount int64
+	var breakdown *entity.RatingBreakdown
+
+	errGroup, c := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		bd, err := b.repo.GetBuildingDetailByID(c, id, false)
+		if err != nil {
+			log.Println("error when getting building detail by id: ", err)
+			return err
+		}
+
+		building = bd
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		count, err := b.repo.CountBuildingFavoritesByID(c, id)
+		if err != nil {
+			log.Println("error when counting building favorites: ", err)
+			return err
+		}
+
+		favoriteCount = count
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		rb, err := b.repo.GetBuildingRatingBreakdown(c, id)
+		if err != nil {
+			log.Println("error when getting building rating breakdown: ", err)
+			return err
+		}
+
+		breakdown = rb
+		return nil
+	})
+
+	if err := errGroup.Wait(); err != nil {
 		return nil, err
 	}
 
 	buildingResponse := dto.NewFullBuildingResponse(building)
+	buildingResponse.FavoriteCount = favoriteCount
+	buildingResponse.Review.Breakdown = dto.NewRatingBreakdownResponse(breakdown)
 	return buildingResponse, nil
 }
 
@@ -132,9 +605,15 @@ func (b *BuildingServiceImpl) GetDistrictsByCityID(ctx context.Context, cityID i
 	return dto.NewDistrictsResponse(districts), nil
 }
 
-func (b *BuildingServiceImpl) GetBuildingStatistics(ctx context.Context) (*dto.BuildingStatResponse, error) {
+func (b *BuildingServiceImpl) GetBuildingStatistics(ctx context.Context, filter *timeseries.Query) (*dto.BuildingStatResponse, error) {
+	r, err := filter.Range(time.Local)
+	if err != nil {
+		return nil, err
+	}
+
 	statByCities := new(dto.TotalByCities)
-	statByTimeFrame := new(dto.TotalByTimeFrame)
+	statByTime := new(timeseries.Series)
+	statByFavorites := new(dto.TotalByFavorites)
 
 	errGroup, c := errgroup.WithContext(ctx)
 	errGroup.Go(func() error {
@@ -149,13 +628,24 @@ func (b *BuildingServiceImpl) GetBuildingStatistics(ctx context.Context) (*dto.B
 	})
 
 	errGroup.Go(func() error {
-		total, err := b.repo.GetBuildingCountByTime(c)
+		total, err := b.repo.GetBuildingSeries(c, r)
 		if err != nil {
 			log.Println("error when getting building count by time: ", err)
 			return err
 		}
 
-		statByTimeFrame = dto.NewTimeframeStat(total)
+		statByTime = r.Fill(total.Values())
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		total, err := b.repo.GetMostFavoritedBuildings(c, 10)
+		if err != nil {
+			log.Println("error when getting most favorited buildings: ", err)
+			return err
+		}
+
+		statByFavorites = dto.NewTotalByFavorites(total)
 		return nil
 	})
 
@@ -164,12 +654,13 @@ func (b *BuildingServiceImpl) GetBuildingStatistics(ctx context.Context) (*dto.B
 	}
 
 	return &dto.BuildingStatResponse{
-		ByCity:      statByCities,
-		ByTimeFrame: statByTimeFrame,
+		ByCity:        statByCities,
+		ByTime:        statByTime,
+		MostFavorited: statByFavorites,
 	}, nil
 }
 
-func (b *BuildingServiceImpl) UpdateBuilding(ctx context.Context, building *dto.UpdateBuildingRequest, buildingID string) error {
+func (b *BuildingServiceImpl) UpdateBuilding(ctx context.Context, building *dto.UpdateBuildingRequest, buildingID string, editorID string) (*dto.BuildingRevisionResponse, error) {
 	buildingEntity := building.ToEntity(buildingID)
 
 	if buildingEntity.DistrictID != 0 || buildingEntity.CityID != 0 {
@@ -177,21 +668,34 @@ func (b *BuildingServiceImpl) UpdateBuilding(ctx context.Context, building *dto.
 		district, err := b.repo.GetDistrictByID(ctx, buildingEntity.DistrictID)
 		if err != nil {
 			log.Println("error when getting district by id: ", err)
-			return err
+			return nil, err
 		}
 
 		if district.CityID != buildingEntity.CityID {
-			return err2.ErrDistrictNotInCity
+			return nil, err2.ErrDistrictNotInCity
 		}
 	}
 
-	err := b.repo.UpdateBuildingByID(ctx, buildingEntity)
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	// edits to a published building wait in a draft revision until they're published
+	if *current.IsPublished {
+		return b.saveDraftRevision(ctx, current, &dto.RevisionChanges{UpdateBuildingRequest: *building}, editorID, nil)
+	}
+
+	err = b.repo.UpdateBuildingByID(ctx, buildingEntity)
 	if err != nil {
 		log.Println("error when updating building: ", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	b.reindexBuilding(ctx, buildingID)
+
+	return nil, nil
 }
 
 func (b *BuildingServiceImpl) UpdateBuildingPublishState(ctx context.Context, building *dto.PublishRequest, buildingID string) error {
@@ -203,28 +707,115 @@ func (b *BuildingServiceImpl) UpdateBuildingPublishState(ctx context.Context, bu
 		return err
 	}
 
+	b.reindexBuilding(ctx, buildingID)
+
 	return nil
 }
 
-func (b *BuildingServiceImpl) AddBuildingPicture(ctx context.Context, buildingID string, index int, alt string, picture io.Reader) (*dto.AddPictureResponse, error) {
-	//	check if building exists
+func (b *BuildingServiceImpl) ScheduleBuildingPublishState(ctx context.Context, schedule *dto.SchedulePublishRequest, buildingID string, scheduledByID string) error {
+	now := time.Now()
+	if schedule.PublishAt != nil && !schedule.PublishAt.After(now) {
+		return err2.ErrInvalidPublishSchedule
+	}
+
+	if schedule.UnpublishAt != nil {
+		if !schedule.UnpublishAt.After(now) {
+			return err2.ErrInvalidPublishSchedule
+		}
+
+		if schedule.PublishAt != nil && !schedule.UnpublishAt.After(*schedule.PublishAt) {
+			return err2.ErrInvalidPublishSchedule
+		}
+	}
+
 	exists, err := b.repo.IsBuildingExist(ctx, buildingID)
 	if err != nil {
 		log.Println("error when checking building: ", err)
-		return nil, err
+		return err
 	}
 
 	if !exists {
-		return nil, err2.ErrBuildingNotFound
+		return err2.ErrBuildingNotFound
 	}
 
-	pictureCount, err := b.repo.CountBuildingPicturesByID(ctx, buildingID)
+	err = b.repo.UpdateBuildingSchedule(ctx, schedule.ToEntity(buildingID, scheduledByID))
 	if err != nil {
-		log.Println("error when counting building pictures: ", err)
-		return nil, err
+		log.Println("error when updating building schedule: ", err)
+		return err
 	}
 
-	if pictureCount >= 10 {
+	return nil
+}
+
+func (b *BuildingServiceImpl) CancelBuildingPublishSchedule(ctx context.Context, buildingID string) error {
+	exists, err := b.repo.IsBuildingExist(ctx, buildingID)
+	if err != nil {
+		log.Println("error when checking building: ", err)
+		return err
+	}
+
+	if !exists {
+		return err2.ErrBuildingNotFound
+	}
+
+	err = b.repo.UpdateBuildingSchedule(ctx, &entity.Building{ID: buildingID})
+	if err != nil {
+		log.Println("error when cancelling building schedule: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (b *BuildingServiceImpl) UpdateBuildingOpeningHours(ctx context.Context, hours *dto.OpeningHoursRequest, buildingID string) error {
+	exists, err := b.repo.IsBuildingExist(ctx, buildingID)
+	if err != nil {
+		log.Println("error when checking building: ", err)
+		return err
+	}
+
+	if !exists {
+		return err2.ErrBuildingNotFound
+	}
+
+	building := hours.ToEntity(buildingID)
+
+	// building the schedule validates the times and rejects overlapping periods
+	schedule, err := dto.NewOpeningSchedule(building)
+	if err != nil {
+		return err
+	}
+
+	// a building without hours is not restricted, so it matches both filters
+	building.IsAlwaysOpen = custom.Bool(schedule.IsEmpty() || schedule.IsAlwaysOpen())
+	building.IsOpenOnWeekends = custom.Bool(schedule.IsEmpty() || schedule.IsOpenOnWeekends())
+
+	err = b.repo.UpdateBuildingOpeningHours(ctx, building)
+	if err != nil {
+		log.Println("error when updating building opening hours: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (b *BuildingServiceImpl) AddBuildingPicture(ctx context.Context, buildingID string, index int, alt string, picture io.Reader, editorID string) (*dto.AddPictureResponse, error) {
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	// a published building gets the picture when the draft is published
+	pending := new(dto.RevisionChanges)
+	if *current.IsPublished {
+		pending, err = b.pendingChanges(ctx, buildingID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(pending.Apply(current).Pictures) >= maxBuildingPictures {
 		return nil, err2.ErrPicureLimitExceeded
 	}
 
@@ -245,26 +836,219 @@ func (b *BuildingServiceImpl) AddBuildingPicture(ctx context.Context, buildingID
 		Key:          pictureKey,
 	}
 
+	if *current.IsPublished {
+		changes := &dto.RevisionChanges{AddedPictures: dto.RevisionPictures{*dto.NewRevisionPicture(pictureEntity)}}
+		revision, err := b.saveDraftRevision(ctx, current, changes, editorID, func(merged *dto.RevisionChanges) error {
+			if len(merged.Apply(current).Pictures) > maxBuildingPictures {
+				return err2.ErrPicureLimitExceeded
+			}
+			return nil
+		})
+		if err != nil {
+			b.deletePictures(ctx, entity.Pictures{*pictureEntity})
+			return nil, err
+		}
+
+		response := dto.NewAddPictureResponse(pictureEntity)
+		response.Revision = revision
+		return response, nil
+	}
+
 	err = b.repo.AddPicture(ctx, pictureEntity)
 	if err != nil {
 		log.Println("error when adding building picture: ", err)
+		b.deletePictures(ctx, entity.Pictures{*pictureEntity})
 		return nil, err
 	}
 
 	return dto.NewAddPictureResponse(pictureEntity), nil
 }
 
-func (b *BuildingServiceImpl) AddBuildingFacility(ctx context.Context, buildingID string, facilities *dto.AddFacilitiesRequest) error {
-	facilitiesEntity := facilities.ToEntity(buildingID)
-	err := b.repo.AddFacility(ctx, facilitiesEntity)
+// maxFloorPlans is the number of floor plans a building can have, one per floor is the usual case
+const maxFloorPlans = 50
+
+func (b *BuildingServiceImpl) GetPublishedBuildingFloorPlans(ctx context.Context, buildingID string) (*dto.FloorPlansResponse, error) {
+	_, err := b.repo.GetSearchableBuildingByID(ctx, buildingID)
 	if err != nil {
-		log.Println("error when adding building facilities: ", err)
+		if err != err2.ErrBuildingNotFound {
+			log.Println("error when getting published building: ", err)
+		}
+		return nil, err
+	}
+
+	return b.getBuildingFloorPlans(ctx, buildingID)
+}
+
+func (b *BuildingServiceImpl) GetBuildingFloorPlans(ctx context.Context, buildingID string) (*dto.FloorPlansResponse, error) {
+	exists, err := b.repo.IsBuildingExist(ctx, buildingID)
+	if err != nil {
+		log.Println("error when checking building: ", err)
+		return nil, err
+	}
+
+	if !exists {
+		return nil, err2.ErrBuildingNotFound
+	}
+
+	return b.getBuildingFloorPlans(ctx, buildingID)
+}
+
+func (b *BuildingServiceImpl) getBuildingFloorPlans(ctx context.Context, buildingID string) (*dto.FloorPlansResponse, error) {
+	floorPlans, err := b.repo.GetBuildingFloorPlans(ctx, buildingID)
+	if err != nil {
+		log.Println("error when getting building floor plans: ", err)
+		return nil, err
+	}
+
+	return dto.NewFloorPlansResponse(floorPlans), nil
+}
+
+func (b *BuildingServiceImpl) AddBuildingFloorPlan(ctx context.Context, buildingID string, floor int, name string, picture io.Reader) (*dto.FloorPlanResponse, error) {
+	exists, err := b.repo.IsBuildingExist(ctx, buildingID)
+	if err != nil {
+		log.Println("error when checking building: ", err)
+		return nil, err
+	}
+
+	if !exists {
+		return nil, err2.ErrBuildingNotFound
+	}
+
+	floorPlanCount, err := b.repo.CountBuildingFloorPlansByID(ctx, buildingID)
+	if err != nil {
+		log.Println("error when counting building floor plans: ", err)
+		return nil, err
+	}
+
+	if floorPlanCount >= maxFloorPlans {
+		return nil, err2.ErrFloorPlanLimitExceeded
+	}
+
+	pictureKey := uuid.New().String()
+	uploadResult, err := b.imgKitService.UploadFile(ctx, picture, pictureKey, "floor-plans")
+	if err != nil {
+		log.Println("error when uploading file: ", err)
+		return nil, err2.ErrPictureServiceFailed
+	}
+
+	floorPlan := &entity.FloorPlan{
+		ID:           uploadResult.FileId,
+		Key:          pictureKey,
+		BuildingID:   buildingID,
+		Floor:        floor,
+		Name:         name,
+		Url:          uploadResult.Url,
+		ThumbnailUrl: uploadResult.ThumbnailUrl,
+		Width:        uploadResult.Width,
+		Height:       uploadResult.Height,
+	}
+
+	err = b.repo.AddFloorPlan(ctx, floorPlan)
+	if err != nil {
+		log.Println("error when adding building floor plan: ", err)
+		if err := b.imgKitService.DeleteFile(ctx, uploadResult.FileId); err != nil {
+			log.Println("error when deleting file: ", err)
+		}
+		return nil, err
+	}
+
+	return dto.NewFloorPlanResponse(floorPlan), nil
+}
+
+func (b *BuildingServiceImpl) UpdateFloorPlanHotspots(ctx context.Context, buildingID string, floorPlanID string, hotspots *dto.FloorPlanHotspotsRequest) (*dto.FloorPlanResponse, error) {
+	_, err := b.repo.GetFloorPlanByID(ctx, buildingID, floorPlanID)
+	if err != nil {
+		if err != err2.ErrFloorPlanNotFound {
+			log.Println("error when getting floor plan by id: ", err)
+		}
+		return nil, err
+	}
+
+	// hotspots can only link to the facilities of the same building
+	if facilityIDs := hotspots.FacilityIDs(); len(facilityIDs) > 0 {
+		count, err := b.repo.CountBuildingFacilitiesByIDs(ctx, buildingID, facilityIDs)
+		if err != nil {
+			log.Println("error when counting building facilities: ", err)
+			return nil, err
+		}
+
+		if count != int64(len(facilityIDs)) {
+			return nil, err2.ErrFacilityNotFound
+		}
+	}
+
+	err = b.repo.ReplaceFloorPlanHotspots(ctx, floorPlanID, hotspots.ToEntity(floorPlanID))
+	if err != nil {
+		log.Println("error when replacing floor plan hotspots: ", err)
+		return nil, err
+	}
+
+	floorPlan, err := b.repo.GetFloorPlanByID(ctx, buildingID, floorPlanID)
+	if err != nil {
+		log.Println("error when getting floor plan by id: ", err)
+		return nil, err
+	}
+
+	return dto.NewFloorPlanResponse(floorPlan), nil
+}
+
+func (b *BuildingServiceImpl) DeleteBuildingFloorPlan(ctx context.Context, buildingID string, floorPlanID string) error {
+	err := b.repo.DeleteFloorPlanByID(ctx, buildingID, floorPlanID)
+	if err != nil {
+		log.Println("error when deleting building floor plan: ", err)
 		return err
 	}
 
+	err = b.imgKitService.DeleteFile(ctx, floorPlanID)
+	if err != nil {
+		log.Println("error when deleting file: ", err)
+		return err2.ErrPictureServiceFailed
+	}
+
 	return nil
 }
 
+func (b *BuildingServiceImpl) AddBuildingFacility(ctx context.Context, buildingID string, facilities *dto.AddFacilitiesRequest, editorID string) (*dto.BuildingRevisionResponse, error) {
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	if *current.IsPublished {
+		// the categories are checked now since the facilities are only created when the draft is published
+		categories, err := b.repo.GetFacilityCategories(ctx)
+		if err != nil {
+			log.Println("error when getting facility categories: ", err)
+			return nil, err
+		}
+
+		categoryIDs := map[int]bool{}
+		for _, category := range *categories {
+			categoryIDs[category.ID] = true
+		}
+
+		for _, facility := range *facilities {
+			if !categoryIDs[facility.IconID] {
+				return nil, err2.ErrInvalidCategoryID
+			}
+		}
+
+		return b.saveDraftRevision(ctx, current, &dto.RevisionChanges{AddedFacilities: *facilities}, editorID, nil)
+	}
+
+	facilitiesEntity := facilities.ToEntity(buildingID)
+	err = b.repo.AddFacility(ctx, facilitiesEntity)
+	if err != nil {
+		log.Println("error when adding building facilities: ", err)
+		return nil, err
+	}
+
+	b.reindexBuilding(ctx, buildingID)
+
+	return nil, nil
+}
+
 func (b *BuildingServiceImpl) ValidateBuilding(ctx context.Context, buildingID string) (*validator.ErrorsResponse, error) {
 	//	get building
 	building, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
@@ -277,6 +1061,15 @@ func (b *BuildingServiceImpl) ValidateBuilding(ctx context.Context, buildingID s
 		return nil, nil
 	}
 
+	if errs := b.validatePublishWorthy(building); errs != nil {
+		return errs, err2.ErrNotPublishWorthy
+	}
+
+	return nil, nil
+}
+
+// validatePublishWorthy checks the required fields of a building before it goes live
+func (b *BuildingServiceImpl) validatePublishWorthy(building *entity.Building) *validator.ErrorsResponse {
 	buildingDtp := dto.NewFullBuildingResponse(building)
 	errs := b.validator.ValidateJSON(buildingDtp)
 
@@ -289,40 +1082,99 @@ func (b *BuildingServiceImpl) ValidateBuilding(ctx context.Context, buildingID s
 	}
 
 	if !indexZero {
+		if errs == nil {
+			errs = new(validator.ErrorsResponse)
+		}
 		errs.AddError("pictures", "main image is required")
 	}
 
-	if errs != nil {
-		return errs, err2.ErrNotPublishWorthy
+	return errs
+}
+
+func (b *BuildingServiceImpl) DeleteBuildingPicture(ctx context.Context, buildingID string, pictureID string, editorID string) (*dto.BuildingRevisionResponse, error) {
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		if err == err2.ErrBuildingNotFound {
+			return nil, err2.ErrPictureNotFound
+		}
+
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
 	}
 
-	return nil, nil
-}
+	if *current.IsPublished {
+		pending, err := b.pendingChanges(ctx, buildingID)
+		if err != nil {
+			return nil, err
+		}
+
+		// pictures added to the draft can be removed as well
+		found := false
+		for _, picture := range pending.Apply(current).Pictures {
+			if picture.ID == pictureID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, err2.ErrPictureNotFound
+		}
 
-func (b *BuildingServiceImpl) DeleteBuildingPicture(ctx context.Context, buildingID string, pictureID string) error {
-	err := b.repo.DeleteBuildingPicturesByID(ctx, buildingID, pictureID)
+		return b.saveDraftRevision(ctx, current, &dto.RevisionChanges{RemovedPictures: []string{pictureID}}, editorID, nil)
+	}
+
+	err = b.repo.DeleteBuildingPicturesByID(ctx, buildingID, pictureID)
 	if err != nil {
 		log.Println("error when deleting building picture: ", err)
-		return err
+		return nil, err
 	}
 
 	err = b.imgKitService.DeleteFile(ctx, pictureID)
 	if err != nil {
 		log.Println("error when deleting file: ", err)
-		return err2.ErrPictureServiceFailed
+		return nil, err2.ErrPictureServiceFailed
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (b *BuildingServiceImpl) DeleteBuildingFacility(ctx context.Context, buildingID string, facilityID int) error {
-	err := b.repo.DeleteBuildingFacilityByID(ctx, buildingID, facilityID)
+func (b *BuildingServiceImpl) DeleteBuildingFacility(ctx context.Context, buildingID string, facilityID int, editorID string) (*dto.BuildingRevisionResponse, error) {
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		if err == err2.ErrBuildingNotFound {
+			return nil, err2.ErrFacilityNotFound
+		}
+
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	if *current.IsPublished {
+		found := false
+		for _, facility := range current.Facilities {
+			if facility.ID == facilityID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, err2.ErrFacilityNotFound
+		}
+
+		return b.saveDraftRevision(ctx, current, &dto.RevisionChanges{RemovedFacilities: []int{facilityID}}, editorID, nil)
+	}
+
+	err = b.repo.DeleteBuildingFacilityByID(ctx, buildingID, facilityID)
 	if err != nil {
 		log.Println("error when deleting building facility: ", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	b.reindexBuilding(ctx, buildingID)
+
+	return nil, nil
 }
 
 func (b *BuildingServiceImpl) DeleteBuilding(ctx context.Context, buildingID string) error {
@@ -342,6 +1194,8 @@ func (b *BuildingServiceImpl) DeleteBuilding(ctx context.Context, buildingID str
 		return err
 	}
 
+	b.reindexBuilding(ctx, buildingID)
+
 	return nil
 }
 
@@ -349,6 +1203,21 @@ func (b *BuildingServiceImpl) GetBuildingReviews(ctx context.Context, buildingID
 	var reviews *entity.Reviews
 	var total int64
 
+	if filter.Enabled {
+		if err := filter.Decode(); err != nil {
+			return nil, 0, err
+		}
+
+		savedReviews, err := b.repo.GetBuildingReviewsByID(ctx, buildingID, filter)
+		if err != nil {
+			log.Println("error when getting reviews: ", err)
+			return nil, 0, err
+		}
+
+		*savedReviews = cursor.Paginate(&filter.Pagination, *savedReviews, filter.Limit, reviewCursorKey(filter.SortBy))
+		return dto.NewBriefBuildingReviewsResponse(savedReviews), 0, nil
+	}
+
 	errGroup, c := errgroup.WithContext(ctx)
 	errGroup.Go(func() error {
 		filter.Offset = (filter.Page - 1) * filter.Limit
@@ -363,7 +1232,7 @@ func (b *BuildingServiceImpl) GetBuildingReviews(ctx context.Context, buildingID
 	})
 
 	errGroup.Go(func() error {
-		count, err := b.repo.CountBuildingReviewsByID(c, buildingID)
+		count, err := b.repo.CountBuildingReviewsByID(c, buildingID, filter.Rating)
 		if err != nil {
 			log.Println("error when counting reviews: ", err)
 			return err
@@ -379,3 +1248,808 @@ func (b *BuildingServiceImpl) GetBuildingReviews(ctx context.Context, buildingID
 
 	return dto.NewBriefBuildingReviewsResponse(reviews), total, nil
 }
+
+func (b *BuildingServiceImpl) GetBuildingReviewPictures(ctx context.Context, buildingID string, filter *dto.ReviewPictureQueryParam) (*dto.ReviewPicturesResponse, int64, error) {
+	filter.Offset = (filter.Page - 1) * filter.Limit
+	pictures, count, err := b.repo.GetBuildingReviewPictures(ctx, buildingID, filter.Offset, filter.Limit)
+	if err != nil {
+		log.Println("error when getting building review pictures: ", err)
+		return nil, 0, err
+	}
+
+	return dto.NewReviewPicturesResponse(pictures), count, nil
+}
+
+func (b *BuildingServiceImpl) GetUserFavorites(ctx context.Context, userID string, filter *dto.FavoriteQueryParam) (*dto.BriefPublishedBuildingsResponse, int64, error) {
+	filter.Offset = (filter.Page - 1) * filter.Limit
+	buildings, count, err := b.repo.GetUserFavoriteBuildings(ctx, userID, filter.Offset, filter.Limit)
+	if err != nil {
+		log.Println("error when getting user favorite buildings: ", err)
+		return nil, 0, err
+	}
+
+	buildingsResponse := dto.NewBriefPublishedBuildingsResponse(buildings)
+	for i := range *buildingsResponse {
+		(*buildingsResponse)[i].IsFavorite = true
+	}
+
+	return buildingsResponse, count, nil
+}
+
+func (b *BuildingServiceImpl) AddFavorite(ctx context.Context, userID string, buildingID string) error {
+	err := b.repo.AddFavorite(ctx, &entity.Favorite{
+		UserID:     userID,
+		BuildingID: buildingID,
+	})
+	if err != nil {
+		log.Println("error when adding favorite: ", err)
+		return err
+	}
+
+	b.analytics.TrackBuildingEvent(constant.BUILDING_FAVORITE_EVENT, buildingID)
+
+	return nil
+}
+
+func (b *BuildingServiceImpl) RemoveFavorite(ctx context.Context, userID string, buildingID string) error {
+	err := b.repo.DeleteFavorite(ctx, userID, buildingID)
+	if err != nil {
+		log.Println("error when removing favorite: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// saveDraftRevision merges the changes into the open draft of the building, or starts a new draft.
+// check is run on the merged changes before they're saved
+func (b *BuildingServiceImpl) saveDraftRevision(ctx context.Context, current *entity.Building, changes *dto.RevisionChanges, editorID string, check func(merged *dto.RevisionChanges) error) (*dto.BuildingRevisionResponse, error) {
+	initial, err := initialRevision(current)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &entity.BuildingRevision{
+		BuildingID:  current.ID,
+		Changes:     string(encoded),
+		Status:      constant.REVISION_DRAFT_STATUS,
+		CreatedByID: editorID,
+	}
+
+	err = b.repo.SaveDraftBuildingRevision(ctx, initial, draft, func(pending string) (string, error) {
+		merged := new(dto.RevisionChanges)
+		if err := json.Unmarshal([]byte(pending), merged); err != nil {
+			log.Println("error when decoding building revision changes: ", err)
+			return "", err
+		}
+
+		merged.Merge(changes)
+		if check != nil {
+			if err := check(merged); err != nil {
+				return "", err
+			}
+		}
+
+		encoded, err := json.Marshal(merged)
+		if err != nil {
+			return "", err
+		}
+
+		return string(encoded), nil
+	})
+	if err != nil {
+		if !errors.Is(err, err2.ErrPicureLimitExceeded) {
+			log.Println("error when saving draft building revision: ", err)
+		}
+		return nil, err
+	}
+
+	return b.getBuildingRevisionResponse(ctx, current.ID, draft.ID)
+}
+
+// pendingChanges returns the changes of the open draft of the building, or empty changes when there is none
+func (b *BuildingServiceImpl) pendingChanges(ctx context.Context, buildingID string) (*dto.RevisionChanges, error) {
+	changes := new(dto.RevisionChanges)
+	draft, err := b.repo.GetDraftBuildingRevision(ctx, buildingID)
+	if err != nil {
+		if err == err2.ErrRevisionNotFound {
+			return changes, nil
+		}
+
+		log.Println("error when getting draft building revision: ", err)
+		return nil, err
+	}
+
+	return revisionChanges(draft)
+}
+
+// initialRevision records the live version of a building as a published revision, it's added before
+// the first draft of the building so the live version can be restored later
+func initialRevision(current *entity.Building) (*entity.BuildingRevision, error) {
+	snapshot, err := json.Marshal(dto.NewUpdateBuildingRequest(current))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BuildingRevision{
+		BuildingID:  current.ID,
+		Changes:     string(snapshot),
+		Snapshot:    string(snapshot),
+		Status:      constant.REVISION_PUBLISHED_STATUS,
+		CreatedByID: current.CreatedByID,
+		PublishedAt: sql.NullTime{Time: current.UpdatedAt, Valid: true},
+	}, nil
+}
+
+// deleteRevisionPictures deletes the files uploaded to a draft that won't be published
+func (b *BuildingServiceImpl) deleteRevisionPictures(ctx context.Context, revision *entity.BuildingRevision) {
+	changes, err := revisionChanges(revision)
+	if err != nil {
+		return
+	}
+
+	b.deletePictures(ctx, *changes.AddedPictures.ToEntity(revision.BuildingID))
+}
+
+func (b *BuildingServiceImpl) getBuildingRevisionResponse(ctx context.Context, buildingID string, revisionID string) (*dto.BuildingRevisionResponse, error) {
+	revision, err := b.repo.GetBuildingRevisionByID(ctx, buildingID, revisionID)
+	if err != nil {
+		log.Println("error when getting building revision by id: ", err)
+		return nil, err
+	}
+
+	return dto.NewBuildingRevisionResponse(revision), nil
+}
+
+// revisionChanges returns the changes a revision applies to the live building, a published revision
+// brings back its snapshot
+func revisionChanges(revision *entity.BuildingRevision) (*dto.RevisionChanges, error) {
+	encoded := revision.Changes
+	if revision.Snapshot != "" {
+		encoded = revision.Snapshot
+	}
+
+	changes := new(dto.RevisionChanges)
+	if err := json.Unmarshal([]byte(encoded), changes); err != nil {
+		log.Println("error when decoding building revision changes: ", err)
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// previewRevision returns the building as it would look with the revision applied
+func (b *BuildingServiceImpl) previewRevision(ctx context.Context, current *entity.Building, changes *dto.RevisionChanges) (*entity.Building, error) {
+	preview := changes.Apply(current)
+
+	// the names of changed relations aren't preloaded
+	if preview.DistrictID != current.DistrictID || preview.CityID != current.CityID {
+		district, err := b.repo.GetDistrictByID(ctx, preview.DistrictID)
+		if err != nil {
+			log.Println("error when getting district by id: ", err)
+			return nil, err
+		}
+
+		cities, err := b.repo.GetCities(ctx)
+		if err != nil {
+			log.Println("error when getting cities: ", err)
+			return nil, err
+		}
+
+		preview.District = *district
+		preview.City = entity.City{ID: preview.CityID}
+		for _, city := range *cities {
+			if city.ID == preview.CityID {
+				preview.City = city
+				break
+			}
+		}
+	}
+
+	categories, err := b.repo.GetFacilityCategories(ctx)
+	if err != nil {
+		log.Println("error when getting facility categories: ", err)
+		return nil, err
+	}
+
+	for i, facility := range preview.Facilities {
+		if facility.CategoryID == facility.Category.ID {
+			continue
+		}
+
+		for _, category := range *categories {
+			if category.ID == facility.CategoryID {
+				preview.Facilities[i].Category = category
+				break
+			}
+		}
+	}
+
+	return preview, nil
+}
+
+func (b *BuildingServiceImpl) GetBuildingRevisions(ctx context.Context, buildingID string, filter *dto.RevisionQueryParam) (*dto.BuildingRevisionsResponse, int64, error) {
+	filter.Offset = (filter.Page - 1) * filter.Limit
+	revisions, count, err := b.repo.GetBuildingRevisions(ctx, buildingID, filter.Offset, filter.Limit)
+	if err != nil {
+		log.Println("error when getting building revisions: ", err)
+		return nil, 0, err
+	}
+
+	return dto.NewBuildingRevisionsResponse(revisions), count, nil
+}
+
+func (b *BuildingServiceImpl) PreviewBuildingRevision(ctx context.Context, buildingID string, revisionID string) (*dto.BuildingRevisionPreviewResponse, error) {
+	revision, err := b.repo.GetBuildingRevisionByID(ctx, buildingID, revisionID)
+	if err != nil {
+		log.Println("error when getting building revision by id: ", err)
+		return nil, err
+	}
+
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	changes, err := revisionChanges(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := b.previewRevision(ctx, current, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.BuildingRevisionPreviewResponse{
+		Revision: dto.NewBuildingRevisionResponse(revision),
+		Building: dto.NewFullBuildingResponse(preview),
+		Changes:  dto.NewFieldChanges(dto.NewUpdateBuildingRequest(current), dto.NewUpdateBuildingRequest(preview)),
+	}, nil
+}
+
+func (b *BuildingServiceImpl) PublishBuildingRevision(ctx context.Context, buildingID string, revisionID string) (*validator.ErrorsResponse, error) {
+	revision, err := b.repo.GetBuildingRevisionByID(ctx, buildingID, revisionID)
+	if err != nil {
+		log.Println("error when getting building revision by id: ", err)
+		return nil, err
+	}
+
+	if revision.Status != constant.REVISION_DRAFT_STATUS {
+		return nil, err2.ErrRevisionNotDraft
+	}
+
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	// the changes are read from the locked draft, edits merged after the read above are published as well
+	var errs *validator.ErrorsResponse
+	var published *dto.RevisionChanges
+	err = b.repo.PublishBuildingRevision(ctx, buildingID, revisionID, func(pending *entity.BuildingRevision) (*dto.RevisionChanges, error) {
+		changes, err := revisionChanges(pending)
+		if err != nil {
+			return nil, err
+		}
+
+		preview, err := b.previewRevision(ctx, current, changes)
+		if err != nil {
+			return nil, err
+		}
+
+		if *current.IsPublished {
+			if errs = b.validatePublishWorthy(preview); errs != nil {
+				return nil, err2.ErrNotPublishWorthy
+			}
+		}
+
+		snapshot, err := json.Marshal(dto.NewUpdateBuildingRequest(preview))
+		if err != nil {
+			return nil, err
+		}
+
+		pending.Snapshot = string(snapshot)
+		pending.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		published = changes
+		return changes, nil
+	})
+	if err != nil {
+		if err == err2.ErrNotPublishWorthy {
+			return errs, err
+		}
+
+		log.Println("error when publishing building revision: ", err)
+		return nil, err
+	}
+
+	for _, pictureID := range published.RemovedPictures {
+		err = b.imgKitService.DeleteFile(ctx, pictureID)
+		if err != nil {
+			log.Println("error when deleting file: ", err)
+		}
+	}
+
+	b.reindexBuilding(ctx, buildingID)
+
+	return nil, nil
+}
+
+func (b *BuildingServiceImpl) DiscardBuildingRevision(ctx context.Context, buildingID string, revisionID string) error {
+	revision, err := b.repo.GetBuildingRevisionByID(ctx, buildingID, revisionID)
+	if err != nil {
+		log.Println("error when getting building revision by id: ", err)
+		return err
+	}
+
+	err = b.repo.UpdateBuildingRevisionStatus(ctx, revision.ID, constant.REVISION_DISCARDED_STATUS)
+	if err != nil {
+		log.Println("error when discarding building revision: ", err)
+		return err
+	}
+
+	b.deleteRevisionPictures(ctx, revision)
+
+	return nil
+}
+
+// RestoreBuildingRevision starts a new draft holding the snapshot of a published revision, the pending
+// draft is discarded. Facilities and pictures added after the revision are kept.
+func (b *BuildingServiceImpl) RestoreBuildingRevision(ctx context.Context, buildingID string, revisionID string, editorID string) (*dto.BuildingRevisionResponse, error) {
+	revision, err := b.repo.GetBuildingRevisionByID(ctx, buildingID, revisionID)
+	if err != nil {
+		log.Println("error when getting building revision by id: ", err)
+		return nil, err
+	}
+
+	if revision.Status != constant.REVISION_PUBLISHED_STATUS || revision.Snapshot == "" {
+		return nil, err2.ErrRevisionNotRestorable
+	}
+
+	current, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return nil, err
+	}
+
+	changes, err := revisionChanges(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	initial, err := initialRevision(current)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &entity.BuildingRevision{
+		BuildingID:  buildingID,
+		Changes:     string(encoded),
+		Status:      constant.REVISION_DRAFT_STATUS,
+		CreatedByID: editorID,
+	}
+
+	discarded, err := b.repo.ReplaceDraftBuildingRevision(ctx, initial, draft)
+	if err != nil {
+		log.Println("error when restoring building revision: ", err)
+		return nil, err
+	}
+
+	if discarded != nil {
+		b.deleteRevisionPictures(ctx, discarded)
+	}
+
+	return b.getBuildingRevisionResponse(ctx, buildingID, draft.ID)
+}
+
+// maxImportRows keeps a single import within what can be validated and uploaded in one request
+const maxImportRows = 500
+
+// maxBuildingPictures is the number of picture slots of a building, indexes 0 to 9
+const maxBuildingPictures = 10
+
+// ImportBuildings creates and updates the buildings of the spreadsheet. Validation is all or nothing,
+// the writes are done row by row since the pictures are uploaded as each row is written, so a row
+// failing to be written is reported in the errors while the other rows are still imported
+func (b *BuildingServiceImpl) ImportBuildings(ctx context.Context, file io.Reader, format string, dryRun bool, creatorID string) (*dto.ImportBuildingsResponse, error) {
+	rows, err := spreadsheet.Read(file, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) < 2 {
+		return nil, err2.ErrInvalidSpreadsheet
+	}
+
+	if len(rows)-1 > maxImportRows {
+		return nil, err2.ErrImportRowLimitExceeded
+	}
+
+	importRows, errs := dto.ParseBuildingSheet(rows)
+
+	categories, err := b.repo.GetFacilityCategories(ctx)
+	if err != nil {
+		log.Println("error when getting facility categories: ", err)
+		return nil, err
+	}
+
+	categoryIDs := make(map[int]bool, len(*categories))
+	for _, category := range *categories {
+		categoryIDs[category.ID] = true
+	}
+
+	// every row is checked before anything is written, so a single invalid row aborts the whole import
+	buildings := make([]*entity.Building, len(importRows))
+	currents := make([]*entity.Building, len(importRows))
+	districts := map[int]*entity.District{}
+	result := &dto.ImportBuildingsResponse{
+		DryRun: dryRun,
+		Total:  len(importRows),
+	}
+
+	for i := range importRows {
+		row := &importRows[i]
+
+		if row.ID == "" {
+			buildings[i] = row.ToEntity(creatorID)
+			buildings[i].ID = uuid.New().String()
+			result.Created++
+		} else {
+			current, err := b.repo.GetBuildingDetailByID(ctx, row.ID, false)
+			if err != nil {
+				if err == err2.ErrBuildingNotFound {
+					errs = append(errs, dto.ImportError{Row: row.Row, Column: "id", Reason: err.Error()})
+					continue
+				}
+
+				log.Println("error when getting building detail by id: ", err)
+				return nil, err
+			}
+
+			// a published building is imported on top of its open draft, the row is added to the draft
+			if *current.IsPublished {
+				pending, err := b.pendingChanges(ctx, current.ID)
+				if err != nil {
+					return nil, err
+				}
+				current = pending.Apply(current)
+			}
+
+			currents[i] = current
+			buildings[i] = row.ToUpdateRequest().Apply(current)
+			buildings[i].Facilities, buildings[i].Pictures = mergeImportedAssets(current, row)
+			result.Updated++
+		}
+
+		for j := range buildings[i].Pictures {
+			if buildings[i].Pictures[j].ID == "" {
+				// placeholder until the picture is uploaded, the validator only needs it to be set
+				buildings[i].Pictures[j].ID = "pending"
+			}
+		}
+
+		rowErrs, err := b.validateImportRow(ctx, row, buildings[i], categoryIDs, districts)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, rowErrs...)
+	}
+
+	if len(errs) > 0 {
+		result.Created, result.Updated = 0, 0
+		result.Errors = errs
+		return result, nil
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	// the counts are of the rows actually written, a failed write doesn't undo the other rows
+	result.Created, result.Updated = 0, 0
+	for i := range importRows {
+		row := &importRows[i]
+
+		var err error
+		if currents[i] == nil {
+			err = b.importNewBuilding(ctx, buildings[i])
+			if err == nil {
+				result.Created++
+			}
+		} else {
+			err = b.importExistingBuilding(ctx, row, currents[i], buildings[i], creatorID)
+			if err == nil {
+				result.Updated++
+			}
+		}
+
+		if err != nil {
+			result.Errors = append(result.Errors, dto.ImportError{Row: row.Row, Reason: err.Error()})
+		}
+	}
+
+	return result, nil
+}
+
+// mergeImportedAssets adds the facilities and pictures of the row that the building doesn't have yet,
+// new pictures take the free indexes
+func mergeImportedAssets(current *entity.Building, row *dto.BuildingImportRow) (entity.Facilities, entity.Pictures) {
+	facilities := append(entity.Facilities{}, current.Facilities...)
+	for _, facility := range row.Facilities {
+		exists := false
+		for _, f := range current.Facilities {
+			if f.CategoryID == facility.CategoryID && strings.EqualFold(f.Name, facility.Name) {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			facilities = append(facilities, entity.Facility{
+				BuildingID:  current.ID,
+				CategoryID:  facility.CategoryID,
+				Name:        facility.Name,
+				Description: facility.Description,
+			})
+		}
+	}
+
+	pictures := append(entity.Pictures{}, current.Pictures...)
+	usedIndexes := map[int]bool{}
+	for _, picture := range current.Pictures {
+		usedIndexes[*picture.Index] = true
+	}
+
+	nextIndex := 0
+	for _, url := range row.PictureURLs {
+		exists := false
+		for _, p := range current.Pictures {
+			if p.Url == url {
+				exists = true
+				break
+			}
+		}
+
+		if exists {
+			continue
+		}
+
+		for usedIndexes[nextIndex] {
+			nextIndex++
+		}
+		usedIndexes[nextIndex] = true
+
+		index := nextIndex
+		pictures = append(pictures, entity.Picture{
+			BuildingID: current.ID,
+			Index:      &index,
+			Url:        url,
+		})
+	}
+
+	return facilities, pictures
+}
+
+func (b *BuildingServiceImpl) validateImportRow(ctx context.Context, row *dto.BuildingImportRow, building *entity.Building, categoryIDs map[int]bool, districts map[int]*entity.District) (dto.ImportErrors, error) {
+	errs := dto.ImportErrors{}
+
+	// the city and district are updated together, same as the building update endpoint
+	switch {
+	case row.CityID != 0 && row.DistrictID == 0:
+		errs = append(errs, dto.ImportError{Row: row.Row, Column: "district_id", Reason: "district_id is required when city_id is present"})
+	case row.DistrictID != 0:
+		district, ok := districts[row.DistrictID]
+		if !ok {
+			var err error
+			district, err = b.repo.GetDistrictByID(ctx, row.DistrictID)
+			if err != nil && err != err2.ErrInvalidDistrictID {
+				log.Println("error when getting district by id: ", err)
+				return nil, err
+			}
+			districts[row.DistrictID] = district
+		}
+
+		switch {
+		case district == nil:
+			errs = append(errs, dto.ImportError{Row: row.Row, Column: "district_id", Reason: err2.ErrInvalidDistrictID.Error()})
+		case district.CityID != row.CityID:
+			errs = append(errs, dto.ImportError{Row: row.Row, Column: "city_id", Reason: err2.ErrDistrictNotInCity.Error()})
+		}
+	}
+
+	for _, facility := range row.Facilities {
+		if !categoryIDs[facility.CategoryID] {
+			errs = append(errs, dto.ImportError{Row: row.Row, Column: "facilities", Reason: err2.ErrInvalidCategoryID.Error()})
+			break
+		}
+	}
+
+	if len(building.Pictures) > maxBuildingPictures {
+		errs = append(errs, dto.ImportError{Row: row.Row, Column: "pictures", Reason: err2.ErrPicureLimitExceeded.Error()})
+		return errs, nil
+	}
+
+	if validationErrs := b.validatePublishWorthy(building); validationErrs != nil {
+		for _, validationErr := range *validationErrs {
+			column := validationErr.Field
+			if validationErr.Namespace != "" {
+				column = dto.ImportColumn(validationErr.Namespace)
+			}
+			errs = append(errs, dto.ImportError{Row: row.Row, Column: column, Reason: validationErr.Reason})
+		}
+	}
+
+	return errs, nil
+}
+
+// uploadImportedPictures lets imagekit fetch the pictures that aren't uploaded yet
+func (b *BuildingServiceImpl) uploadImportedPictures(ctx context.Context, pictures entity.Pictures) (entity.Pictures, error) {
+	uploaded := entity.Pictures{}
+	for i := range pictures {
+		picture := &pictures[i]
+		if picture.Key != "" {
+			continue
+		}
+
+		pictureKey := uuid.New().String()
+		uploadResult, err := b.imgKitService.UploadRemoteFile(ctx, picture.Url, pictureKey, "buildings")
+		if err != nil {
+			log.Println("error when uploading remote file: ", err)
+			b.deletePictures(ctx, uploaded)
+			return nil, err2.ErrPictureServiceFailed
+		}
+
+		picture.ID = uploadResult.FileId
+		picture.Url = uploadResult.Url
+		picture.ThumbnailUrl = uploadResult.ThumbnailUrl
+		picture.Key = pictureKey
+		uploaded = append(uploaded, *picture)
+	}
+
+	return uploaded, nil
+}
+
+func (b *BuildingServiceImpl) deletePictures(ctx context.Context, pictures entity.Pictures) {
+	for _, picture := range pictures {
+		if err := b.imgKitService.DeleteFile(ctx, picture.ID); err != nil {
+			log.Println("error when deleting file: ", err)
+		}
+	}
+}
+
+func (b *BuildingServiceImpl) importNewBuilding(ctx context.Context, building *entity.Building) error {
+	uploaded, err := b.uploadImportedPictures(ctx, building.Pictures)
+	if err != nil {
+		return err
+	}
+
+	// the id is generated on create
+	building.ID = ""
+	err = b.repo.CreateBuilding(ctx, building)
+	if err != nil {
+		log.Println("error when creating building: ", err)
+		b.deletePictures(ctx, uploaded)
+		return err
+	}
+
+	return nil
+}
+
+func (b *BuildingServiceImpl) importExistingBuilding(ctx context.Context, row *dto.BuildingImportRow, current *entity.Building, building *entity.Building, editorID string) error {
+	newFacilities := append(entity.Facilities{}, building.Facilities[len(current.Facilities):]...)
+	newPictures := building.Pictures[len(current.Pictures):]
+
+	// the whole row of a published building waits in the draft, like the single edits do
+	if *current.IsPublished {
+		return b.importToDraft(ctx, row, current.ID, newFacilities, newPictures, editorID)
+	}
+
+	// a row with only new facilities or pictures shouldn't leave an empty update behind
+	if changes := row.ToUpdateRequest(); !reflect.DeepEqual(*changes, dto.UpdateBuildingRequest{}) {
+		_, err := b.UpdateBuilding(ctx, changes, current.ID, editorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(newFacilities) > 0 {
+		err := b.repo.AddFacility(ctx, &newFacilities)
+		if err != nil {
+			log.Println("error when adding building facilities: ", err)
+			return err
+		}
+	}
+
+	uploaded, err := b.uploadImportedPictures(ctx, newPictures)
+	if err != nil {
+		return err
+	}
+
+	for i := range uploaded {
+		err = b.repo.AddPicture(ctx, &uploaded[i])
+		if err != nil {
+			log.Println("error when adding building picture: ", err)
+			b.deletePictures(ctx, uploaded[i:])
+			return err
+		}
+	}
+
+	b.reindexBuilding(ctx, current.ID)
+
+	return nil
+}
+
+// importToDraft saves the row of a published building as a single draft change, the uploaded pictures
+// are deleted when the draft can't be saved
+func (b *BuildingServiceImpl) importToDraft(ctx context.Context, row *dto.BuildingImportRow, buildingID string, newFacilities entity.Facilities, newPictures entity.Pictures, editorID string) error {
+	changes := &dto.RevisionChanges{UpdateBuildingRequest: *row.ToUpdateRequest()}
+	for _, facility := range newFacilities {
+		changes.AddedFacilities = append(changes.AddedFacilities, dto.AddFacilityRequest{
+			Name:        facility.Name,
+			IconID:      facility.CategoryID,
+			Description: facility.Description,
+		})
+	}
+
+	// a row without changes shouldn't leave an empty draft behind
+	if reflect.DeepEqual(*changes, dto.RevisionChanges{}) && len(newPictures) == 0 {
+		return nil
+	}
+
+	// the draft is saved against the live building, the imported view includes the open draft
+	live, err := b.repo.GetBuildingDetailByID(ctx, buildingID, false)
+	if err != nil {
+		log.Println("error when getting building detail by id: ", err)
+		return err
+	}
+
+	uploaded, err := b.uploadImportedPictures(ctx, newPictures)
+	if err != nil {
+		return err
+	}
+
+	for i := range uploaded {
+		changes.AddedPictures = append(changes.AddedPictures, *dto.NewRevisionPicture(&uploaded[i]))
+	}
+
+	_, err = b.saveDraftRevision(ctx, live, changes, editorID, func(merged *dto.RevisionChanges) error {
+		if len(merged.Apply(live).Pictures) > maxBuildingPictures {
+			return err2.ErrPicureLimitExceeded
+		}
+		return nil
+	})
+	if err != nil {
+		b.deletePictures(ctx, uploaded)
+		return err
+	}
+
+	return nil
+}
+
+func (b *BuildingServiceImpl) ExportBuildings(ctx context.Context, format string) ([]byte, error) {
+	buildings, err := b.repo.GetBuildingsForExport(ctx)
+	if err != nil {
+		log.Println("error when getting buildings for export: ", err)
+		return nil, err
+	}
+
+	content, err := spreadsheet.Bytes(format, "Buildings", dto.NewBuildingSheet(buildings))
+	if err != nil {
+		log.Println("error when writing building spreadsheet: ", err)
+		return nil, err
+	}
+
+	return content, nil
+}
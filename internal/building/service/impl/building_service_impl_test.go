@@ -0,0 +1,400 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"office-booking-backend/internal/building/dto"
+	mockRepo "office-booking-backend/internal/building/repository/mock"
+	"office-booking-backend/internal/building/service"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/custom"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/imagekit"
+	"office-booking-backend/pkg/utils/validator"
+	"strings"
+	"testing"
+
+	"github.com/imagekit-developer/imagekit-go/api/uploader"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteBuildingService struct {
+	suite.Suite
+	mockRepo        *mockRepo.BuildingRepositoryMock
+	mockImgKit      *imagekit.ImgKitServiceMock
+	mockValidator   *validator.ValidatorMock
+	buildingService service.BuildingService
+}
+
+func (s *TestSuiteBuildingService) SetupTest() {
+	s.mockRepo = new(mockRepo.BuildingRepositoryMock)
+	s.mockImgKit = new(imagekit.ImgKitServiceMock)
+	s.mockValidator = new(validator.ValidatorMock)
+	s.buildingService = NewBuildingServiceImpl(s.mockRepo, nil, s.mockImgKit, s.mockValidator, nil, nil, viper.New())
+}
+
+func (s *TestSuiteBuildingService) TearDownTest() {
+	s.mockRepo = nil
+	s.mockImgKit = nil
+	s.mockValidator = nil
+	s.buildingService = nil
+}
+
+func TestBuildingService(t *testing.T) {
+	suite.Run(t, new(TestSuiteBuildingService))
+}
+
+func index(i int) *int {
+	return &i
+}
+
+func publishedBuilding(pictures int) *entity.Building {
+	building := &entity.Building{
+		ID:          "building",
+		Name:        "Kuta Office",
+		IsPublished: custom.Bool(true),
+		Facilities:  entity.Facilities{{ID: 1, BuildingID: "building", Name: "Wifi", CategoryID: 1}},
+	}
+
+	for i := 0; i < pictures; i++ {
+		building.Pictures = append(building.Pictures, entity.Picture{ID: "picture" + string(rune('0'+i)), BuildingID: "building", Index: index(i)})
+	}
+
+	return building
+}
+
+func encodeChanges(changes *dto.RevisionChanges) string {
+	encoded, _ := json.Marshal(changes)
+	return string(encoded)
+}
+
+func decodeChanges(encoded string) *dto.RevisionChanges {
+	changes := new(dto.RevisionChanges)
+	_ = json.Unmarshal([]byte(encoded), changes)
+	return changes
+}
+
+func (s *TestSuiteBuildingService) mockDraft(pending string, err error) {
+	s.mockRepo.On("SaveDraftBuildingRevision", mock.Anything, mock.Anything, mock.Anything).Return(pending, err)
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", mock.Anything).Return(&entity.BuildingRevision{ID: "draft", Status: constant.REVISION_DRAFT_STATUS}, nil)
+}
+
+func (s *TestSuiteBuildingService) savedDraft() *entity.BuildingRevision {
+	for _, call := range s.mockRepo.Calls {
+		if call.Method == "SaveDraftBuildingRevision" {
+			return call.Arguments.Get(2).(*entity.BuildingRevision)
+		}
+	}
+	return nil
+}
+
+func (s *TestSuiteBuildingService) TestRevisionChanges_Apply() {
+	changes := &dto.RevisionChanges{
+		UpdateBuildingRequest: dto.UpdateBuildingRequest{Name: "Sanur Office"},
+		AddedFacilities:       dto.AddFacilitiesRequest{{Name: "Parking", IconID: 2}},
+		RemovedFacilities:     []int{1},
+		AddedPictures:         dto.RevisionPictures{{ID: "new", Index: 0}, {ID: "removed", Index: 5}},
+		RemovedPictures:       []string{"picture0", "removed"},
+	}
+
+	preview := changes.Apply(publishedBuilding(2))
+	s.Equal("Sanur Office", preview.Name)
+	s.Len(preview.Facilities, 1)
+	s.Equal("Parking", preview.Facilities[0].Name)
+	s.Len(preview.Pictures, 2)
+	s.Equal("new", preview.Pictures[0].ID)
+	s.Equal("picture1", preview.Pictures[1].ID)
+}
+
+func (s *TestSuiteBuildingService) TestRevisionChanges_Merge() {
+	pending := &dto.RevisionChanges{
+		UpdateBuildingRequest: dto.UpdateBuildingRequest{Name: "Sanur Office", Description: "Near the beach"},
+		AddedPictures:         dto.RevisionPictures{{ID: "new"}},
+	}
+
+	pending.Merge(&dto.RevisionChanges{
+		UpdateBuildingRequest: dto.UpdateBuildingRequest{Name: "Ubud Office"},
+		RemovedFacilities:     []int{1},
+	})
+
+	s.Equal("Ubud Office", pending.Name)
+	s.Equal("Near the beach", pending.Description)
+	s.Len(pending.AddedPictures, 1)
+	s.Equal([]int{1}, pending.RemovedFacilities)
+}
+
+func (s *TestSuiteBuildingService) TestUpdateBuilding_MergesOpenDraft() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockDraft(encodeChanges(&dto.RevisionChanges{UpdateBuildingRequest: dto.UpdateBuildingRequest{Description: "Near the beach"}}), nil)
+
+	revision, err := s.buildingService.UpdateBuilding(context.Background(), &dto.UpdateBuildingRequest{Name: "Sanur Office"}, "building", "editor")
+	s.NoError(err)
+	s.NotNil(revision)
+
+	draft := decodeChanges(s.savedDraft().Changes)
+	s.Equal("Sanur Office", draft.Name)
+	s.Equal("Near the beach", draft.Description)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateBuildingByID", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestUpdateBuilding_FirstDraftRecordsLiveVersion() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockDraft("", nil)
+
+	_, err := s.buildingService.UpdateBuilding(context.Background(), &dto.UpdateBuildingRequest{Name: "Sanur Office"}, "building", "editor")
+	s.NoError(err)
+	s.mockRepo.AssertCalled(s.T(), "SaveDraftBuildingRevision", mock.Anything, mock.MatchedBy(func(initial *entity.BuildingRevision) bool {
+		return initial.Status == constant.REVISION_PUBLISHED_STATUS && strings.Contains(initial.Snapshot, "Kuta Office")
+	}), mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestAddBuildingPicture_Draft() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("GetDraftBuildingRevision", mock.Anything, "building").Return((*entity.BuildingRevision)(nil), err2.ErrRevisionNotFound)
+	s.mockImgKit.On("UploadFile", mock.Anything, mock.Anything, mock.Anything, "buildings").Return(&uploader.UploadResult{FileId: "uploaded"}, nil)
+	s.mockDraft("", nil)
+
+	result, err := s.buildingService.AddBuildingPicture(context.Background(), "building", 1, "front", strings.NewReader("picture"), "editor")
+	s.NoError(err)
+	s.NotNil(result.Revision)
+	s.Equal("uploaded", decodeChanges(s.savedDraft().Changes).AddedPictures[0].ID)
+	s.mockRepo.AssertNotCalled(s.T(), "AddPicture", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestAddBuildingPicture_DraftLimitExceeded() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(9), nil)
+	s.mockRepo.On("GetDraftBuildingRevision", mock.Anything, "building").Return(&entity.BuildingRevision{
+		Changes: encodeChanges(&dto.RevisionChanges{AddedPictures: dto.RevisionPictures{{ID: "pending", Index: 9}}}),
+	}, nil)
+
+	_, err := s.buildingService.AddBuildingPicture(context.Background(), "building", 1, "front", strings.NewReader("picture"), "editor")
+	s.Equal(err2.ErrPicureLimitExceeded, err)
+	s.mockImgKit.AssertNotCalled(s.T(), "UploadFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestAddBuildingPicture_DraftFailedDeletesUpload() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("GetDraftBuildingRevision", mock.Anything, "building").Return((*entity.BuildingRevision)(nil), err2.ErrRevisionNotFound)
+	s.mockImgKit.On("UploadFile", mock.Anything, mock.Anything, mock.Anything, "buildings").Return(&uploader.UploadResult{FileId: "uploaded"}, nil)
+	s.mockImgKit.On("DeleteFile", mock.Anything, "uploaded").Return(nil)
+	s.mockDraft("", errors.New("deadlock"))
+
+	_, err := s.buildingService.AddBuildingPicture(context.Background(), "building", 1, "front", strings.NewReader("picture"), "editor")
+	s.Error(err)
+	s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "uploaded")
+}
+
+func (s *TestSuiteBuildingService) TestAddBuildingFacility_DraftInvalidCategory() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("GetFacilityCategories", mock.Anything).Return(&entity.Categories{{ID: 1}}, nil)
+
+	_, err := s.buildingService.AddBuildingFacility(context.Background(), "building", &dto.AddFacilitiesRequest{{Name: "Parking", IconID: 2}}, "editor")
+	s.Equal(err2.ErrInvalidCategoryID, err)
+	s.mockRepo.AssertNotCalled(s.T(), "SaveDraftBuildingRevision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestDeleteBuildingFacility_Draft() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockDraft("", nil)
+
+	revision, err := s.buildingService.DeleteBuildingFacility(context.Background(), "building", 1, "editor")
+	s.NoError(err)
+	s.NotNil(revision)
+	s.Equal([]int{1}, decodeChanges(s.savedDraft().Changes).RemovedFacilities)
+	s.mockRepo.AssertNotCalled(s.T(), "DeleteBuildingFacilityByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestDeleteBuildingFacility_DraftNotFound() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+
+	_, err := s.buildingService.DeleteBuildingFacility(context.Background(), "building", 2, "editor")
+	s.Equal(err2.ErrFacilityNotFound, err)
+}
+
+func (s *TestSuiteBuildingService) TestDeleteBuildingPicture_DraftAddedPicture() {
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("GetDraftBuildingRevision", mock.Anything, "building").Return(&entity.BuildingRevision{
+		Changes: encodeChanges(&dto.RevisionChanges{AddedPictures: dto.RevisionPictures{{ID: "pending", Index: 1}}}),
+	}, nil)
+	s.mockDraft("", nil)
+
+	_, err := s.buildingService.DeleteBuildingPicture(context.Background(), "building", "pending", "editor")
+	s.NoError(err)
+	s.Equal([]string{"pending"}, decodeChanges(s.savedDraft().Changes).RemovedPictures)
+	s.mockImgKit.AssertNotCalled(s.T(), "DeleteFile", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestPublishBuildingRevision_AppliesDraft() {
+	draft := &entity.BuildingRevision{
+		ID:         "draft",
+		BuildingID: "building",
+		Status:     constant.REVISION_DRAFT_STATUS,
+		Changes: encodeChanges(&dto.RevisionChanges{
+			AddedFacilities: dto.AddFacilitiesRequest{{Name: "Parking", IconID: 1}},
+			AddedPictures:   dto.RevisionPictures{{ID: "new", Index: 1}},
+			RemovedPictures: []string{"picture1"},
+		}),
+	}
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", "draft").Return(draft, nil)
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(2), nil)
+	s.mockRepo.On("GetFacilityCategories", mock.Anything).Return(&entity.Categories{{ID: 1}}, nil)
+	s.mockValidator.On("ValidateJSON", mock.Anything).Return((*validator.ErrorsResponse)(nil))
+	s.mockRepo.On("PublishBuildingRevision", mock.Anything, "building", "draft").Return(draft, nil)
+	s.mockRepo.On("GetSearchableBuildingByID", mock.Anything, "building").Return(publishedBuilding(2), nil)
+	s.mockImgKit.On("DeleteFile", mock.Anything, "picture1").Return(nil)
+
+	errs, err := s.buildingService.PublishBuildingRevision(context.Background(), "building", "draft")
+	s.NoError(err)
+	s.Nil(errs)
+	s.True(draft.PublishedAt.Valid)
+	s.Contains(draft.Snapshot, "Parking")
+	s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "picture1")
+}
+
+func (s *TestSuiteBuildingService) TestPublishBuildingRevision_UsesLockedDraft() {
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", "draft").Return(&entity.BuildingRevision{
+		ID:         "draft",
+		BuildingID: "building",
+		Status:     constant.REVISION_DRAFT_STATUS,
+		Changes:    encodeChanges(&dto.RevisionChanges{UpdateBuildingRequest: dto.UpdateBuildingRequest{Name: "Sanur Office"}}),
+	}, nil)
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(2), nil)
+	s.mockRepo.On("GetFacilityCategories", mock.Anything).Return(&entity.Categories{{ID: 1}}, nil)
+	s.mockValidator.On("ValidateJSON", mock.Anything).Return((*validator.ErrorsResponse)(nil))
+	// a picture removal was merged into the draft before the lock was taken
+	locked := &entity.BuildingRevision{
+		ID:         "draft",
+		BuildingID: "building",
+		Status:     constant.REVISION_DRAFT_STATUS,
+		Changes: encodeChanges(&dto.RevisionChanges{
+			UpdateBuildingRequest: dto.UpdateBuildingRequest{Name: "Sanur Office"},
+			RemovedPictures:       []string{"picture1"},
+		}),
+	}
+	s.mockRepo.On("PublishBuildingRevision", mock.Anything, "building", "draft").Return(locked, nil)
+	s.mockRepo.On("GetSearchableBuildingByID", mock.Anything, "building").Return(publishedBuilding(1), nil)
+	s.mockImgKit.On("DeleteFile", mock.Anything, "picture1").Return(nil)
+
+	_, err := s.buildingService.PublishBuildingRevision(context.Background(), "building", "draft")
+	s.NoError(err)
+	s.Contains(locked.Snapshot, "Sanur Office")
+	s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "picture1")
+}
+
+func (s *TestSuiteBuildingService) TestPublishBuildingRevision_NotDraft() {
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", "revision").Return(&entity.BuildingRevision{Status: constant.REVISION_PUBLISHED_STATUS}, nil)
+
+	_, err := s.buildingService.PublishBuildingRevision(context.Background(), "building", "revision")
+	s.Equal(err2.ErrRevisionNotDraft, err)
+}
+
+func (s *TestSuiteBuildingService) TestRestoreBuildingRevision_ReplacesDraft() {
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", "revision").Return(&entity.BuildingRevision{
+		ID:         "revision",
+		BuildingID: "building",
+		Status:     constant.REVISION_PUBLISHED_STATUS,
+		Snapshot:   encodeChanges(&dto.RevisionChanges{UpdateBuildingRequest: dto.UpdateBuildingRequest{Name: "Old Name"}}),
+	}, nil).Once()
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("ReplaceDraftBuildingRevision", mock.Anything, mock.Anything, mock.Anything).Return(&entity.BuildingRevision{
+		BuildingID: "building",
+		Changes:    encodeChanges(&dto.RevisionChanges{AddedPictures: dto.RevisionPictures{{ID: "pending"}}}),
+	}, nil)
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", mock.Anything).Return(&entity.BuildingRevision{Status: constant.REVISION_DRAFT_STATUS}, nil)
+	s.mockImgKit.On("DeleteFile", mock.Anything, "pending").Return(nil)
+
+	revision, err := s.buildingService.RestoreBuildingRevision(context.Background(), "building", "revision", "editor")
+	s.NoError(err)
+	s.NotNil(revision)
+	s.mockRepo.AssertCalled(s.T(), "ReplaceDraftBuildingRevision", mock.Anything, mock.Anything, mock.MatchedBy(func(draft *entity.BuildingRevision) bool {
+		return draft.Status == constant.REVISION_DRAFT_STATUS && decodeChanges(draft.Changes).Name == "Old Name"
+	}))
+	s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "pending")
+}
+
+func (s *TestSuiteBuildingService) TestDiscardBuildingRevision_DeletesUploads() {
+	s.mockRepo.On("GetBuildingRevisionByID", mock.Anything, "building", "draft").Return(&entity.BuildingRevision{
+		ID:         "draft",
+		BuildingID: "building",
+		Status:     constant.REVISION_DRAFT_STATUS,
+		Changes:    encodeChanges(&dto.RevisionChanges{AddedPictures: dto.RevisionPictures{{ID: "pending"}}}),
+	}, nil)
+	s.mockRepo.On("UpdateBuildingRevisionStatus", mock.Anything, "draft", constant.REVISION_DISCARDED_STATUS).Return(nil)
+	s.mockImgKit.On("DeleteFile", mock.Anything, "pending").Return(nil)
+
+	err := s.buildingService.DiscardBuildingRevision(context.Background(), "building", "draft")
+	s.NoError(err)
+	s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "pending")
+}
+
+func importSheet() *strings.Reader {
+	return strings.NewReader("id,name,facilities,pictures\nbuilding,Sanur Office,1 | Parking | Basement,https://example.com/lobby.jpg\n")
+}
+
+func (s *TestSuiteBuildingService) TestImportBuildings_PublishedToDraft() {
+	s.mockRepo.On("GetFacilityCategories", mock.Anything).Return(&entity.Categories{{ID: 1}}, nil)
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("GetDraftBuildingRevision", mock.Anything, "building").Return((*entity.BuildingRevision)(nil), err2.ErrRevisionNotFound)
+	s.mockValidator.On("ValidateJSON", mock.Anything).Return((*validator.ErrorsResponse)(nil))
+	s.mockImgKit.On("UploadRemoteFile", mock.Anything, "https://example.com/lobby.jpg", mock.Anything, "buildings").Return(&uploader.UploadResult{FileId: "uploaded"}, nil)
+	s.mockDraft("", nil)
+
+	result, err := s.buildingService.ImportBuildings(context.Background(), importSheet(), "csv", false, "editor")
+	s.NoError(err)
+	s.Empty(result.Errors)
+	s.Equal(1, result.Updated)
+
+	draft := decodeChanges(s.savedDraft().Changes)
+	s.Equal("Sanur Office", draft.Name)
+	s.Len(draft.AddedFacilities, 1)
+	s.Equal("Parking", draft.AddedFacilities[0].Name)
+	s.Len(draft.AddedPictures, 1)
+	s.Equal("uploaded", draft.AddedPictures[0].ID)
+	s.mockRepo.AssertNotCalled(s.T(), "AddFacility", mock.Anything, mock.Anything)
+	s.mockRepo.AssertNotCalled(s.T(), "AddPicture", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteBuildingService) TestImportBuildings_PublishedDraftFailedDeletesUploads() {
+	s.mockRepo.On("GetFacilityCategories", mock.Anything).Return(&entity.Categories{{ID: 1}}, nil)
+	s.mockRepo.On("GetBuildingDetailByID", mock.Anything, "building", false).Return(publishedBuilding(1), nil)
+	s.mockRepo.On("GetDraftBuildingRevision", mock.Anything, "building").Return((*entity.BuildingRevision)(nil), err2.ErrRevisionNotFound)
+	s.mockValidator.On("ValidateJSON", mock.Anything).Return((*validator.ErrorsResponse)(nil))
+	s.mockImgKit.On("UploadRemoteFile", mock.Anything, "https://example.com/lobby.jpg", mock.Anything, "buildings").Return(&uploader.UploadResult{FileId: "uploaded"}, nil)
+	s.mockImgKit.On("DeleteFile", mock.Anything, "uploaded").Return(nil)
+	s.mockDraft("", errors.New("deadlock"))
+
+	result, err := s.buildingService.ImportBuildings(context.Background(), importSheet(), "csv", false, "editor")
+	s.NoError(err)
+	s.Len(result.Errors, 1)
+	s.Equal(0, result.Updated)
+	s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "uploaded")
+}
+
+func (s *TestSuiteBuildingService) TestUpdateBuildingOpeningHours_NoHoursMatchesFilters() {
+	s.mockRepo.On("IsBuildingExist", mock.Anything, "building").Return(true, nil)
+	s.mockRepo.On("UpdateBuildingOpeningHours", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.buildingService.UpdateBuildingOpeningHours(context.Background(), &dto.OpeningHoursRequest{}, "building")
+	s.NoError(err)
+
+	building := s.mockRepo.Calls[1].Arguments.Get(1).(*entity.Building)
+	s.True(*building.IsAlwaysOpen)
+	s.True(*building.IsOpenOnWeekends)
+}
+
+func (s *TestSuiteBuildingService) TestUpdateBuildingOpeningHours_WeekdaysOnly() {
+	s.mockRepo.On("IsBuildingExist", mock.Anything, "building").Return(true, nil)
+	s.mockRepo.On("UpdateBuildingOpeningHours", mock.Anything, mock.Anything).Return(nil)
+
+	hours := &dto.OpeningHoursRequest{Weekly: []dto.OpeningPeriodRequest{{Weekday: 1, Open: "08:00", Close: "17:00"}}}
+	err := s.buildingService.UpdateBuildingOpeningHours(context.Background(), hours, "building")
+	s.NoError(err)
+
+	building := s.mockRepo.Calls[1].Arguments.Get(1).(*entity.Building)
+	s.False(*building.IsAlwaysOpen)
+	s.False(*building.IsOpenOnWeekends)
+}
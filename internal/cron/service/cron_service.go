@@ -4,5 +4,8 @@ import "context"
 
 type CronService interface {
 	ScheduleReservationTask(ctx context.Context) error
+	RunSavedSearchTask(ctx context.Context) error
+	SendReportTask(ctx context.Context) error
+	ScheduleBuildingPublishTask(ctx context.Context) error
 	Start()
 }
@@ -2,11 +2,23 @@ package impl
 
 import (
 	"context"
+	"fmt"
 	"log"
+	as "office-booking-backend/internal/analytics/service"
+	"office-booking-backend/internal/building/dto"
+	br "office-booking-backend/internal/building/repository"
+	bs "office-booking-backend/internal/building/service"
+	ns "office-booking-backend/internal/notification/service"
 	pr "office-booking-backend/internal/payment/repository"
+	rss "office-booking-backend/internal/reportsubscription/service"
 	rr "office-booking-backend/internal/reservation/repository"
+	ss "office-booking-backend/internal/savedsearch/service"
 	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/custom"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/mail"
+	"office-booking-backend/pkg/utils/validator"
+	"strings"
 	"time"
 
 	err2 "office-booking-backend/pkg/errors"
@@ -16,24 +28,42 @@ import (
 )
 
 type CronServiceImpl struct {
-	reservation rr.ReservationRepository
-	payment     pr.PaymentRepository
-	cron        *gocron.Scheduler
-	conf        *viper.Viper
+	reservation  rr.ReservationRepository
+	payment      pr.PaymentRepository
+	building     br.BuildingRepository
+	buildingSvc  bs.BuildingService
+	savedSearch  ss.SavedSearchService
+	report       rss.ReportSubscriptionService
+	notification ns.NotificationService
+	analytics    as.AnalyticsService
+	mail         mail.Client
+	cron         *gocron.Scheduler
+	conf         *viper.Viper
 }
 
-func NewCronServiceImpl(reservation rr.ReservationRepository, payment pr.PaymentRepository, cron *gocron.Scheduler, conf *viper.Viper) *CronServiceImpl {
+func NewCronServiceImpl(reservation rr.ReservationRepository, payment pr.PaymentRepository, building br.BuildingRepository, buildingSvc bs.BuildingService, savedSearch ss.SavedSearchService, report rss.ReportSubscriptionService, notification ns.NotificationService, analytics as.AnalyticsService, mail mail.Client, cron *gocron.Scheduler, conf *viper.Viper) *CronServiceImpl {
 	return &CronServiceImpl{
-		reservation: reservation,
-		payment:     payment,
-		cron:        cron,
-		conf:        conf,
+		reservation:  reservation,
+		payment:      payment,
+		building:     building,
+		buildingSvc:  buildingSvc,
+		savedSearch:  savedSearch,
+		report:       report,
+		notification: notification,
+		analytics:    analytics,
+		mail:         mail,
+		cron:         cron,
+		conf:         conf,
 	}
 }
 
 func (c *CronServiceImpl) Start() {
 	c.cron.StartAsync()
 	c.cron.Every(1).Day().At(c.conf.GetString("cron.executeAt")).Do(c.ScheduleReservationTask, context.Background())
+	c.cron.Every(c.conf.GetDuration("savedSearch.interval")).Do(c.RunSavedSearchTask, context.Background())
+	c.cron.Every(c.conf.GetDuration("cron.buildingScheduleInterval")).Do(c.ScheduleBuildingPublishTask, context.Background())
+	c.cron.Every(c.conf.GetDuration("analytics.flushInterval")).Do(c.FlushAnalyticsTask, context.Background())
+	c.cron.Every(c.conf.GetDuration("reportSubscription.interval")).Do(c.SendReportTask, context.Background())
 
 	log.Println("cron service started")
 }
@@ -62,6 +92,187 @@ func (c *CronServiceImpl) ScheduleReservationTask(ctx context.Context) error {
 	return nil
 }
 
+func (c *CronServiceImpl) RunSavedSearchTask(ctx context.Context) error {
+	err := c.savedSearch.RunSavedSearches(ctx)
+	if err != nil {
+		log.Println("failed to run saved searches: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// SendReportTask emails the scheduled reports that are due
+func (c *CronServiceImpl) SendReportTask(ctx context.Context) error {
+	err := c.report.SendDueReports(ctx)
+	if err != nil {
+		log.Println("failed to send scheduled reports: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// FlushAnalyticsTask moves the building analytics counters from redis to the daily stats
+func (c *CronServiceImpl) FlushAnalyticsTask(ctx context.Context) error {
+	err := c.analytics.FlushBuildingEvents(ctx)
+	if err != nil {
+		log.Println("failed to flush building analytics: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ScheduleBuildingPublishTask schedules the publish and unpublish due before the next run,
+// it runs on startup too so the schedules survive a restart
+func (c *CronServiceImpl) ScheduleBuildingPublishTask(ctx context.Context) error {
+	until := time.Now().Add(c.conf.GetDuration("cron.buildingScheduleInterval"))
+	buildings, err := c.building.GetScheduledBuildings(ctx, until)
+	if err != nil {
+		log.Println("failed to get scheduled buildings: ", err.Error())
+		return err
+	}
+
+	for _, building := range *buildings {
+		if building.PublishAt.Valid && !building.PublishAt.Time.After(until) {
+			err := c.scheduleBuildingTask(ctx, "publish-"+building.ID, building.ID, building.PublishAt.Time, c.publishBuilding)
+			if err != nil {
+				log.Println("failed to schedule building publish: ", err.Error())
+			}
+		}
+
+		if building.UnpublishAt.Valid && !building.UnpublishAt.Time.After(until) {
+			err := c.scheduleBuildingTask(ctx, "unpublish-"+building.ID, building.ID, building.UnpublishAt.Time, c.unpublishBuilding)
+			if err != nil {
+				log.Println("failed to schedule building unpublish: ", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *CronServiceImpl) scheduleBuildingTask(ctx context.Context, tag string, buildingID string, executeAt time.Time, task func(context.Context, string, time.Time) error) error {
+	// the previous run may have scheduled the same building already
+	_ = c.cron.RemoveByTag(tag)
+
+	if executeAt.Before(time.Now()) {
+		return task(ctx, buildingID, executeAt)
+	}
+
+	_, err := c.cron.Every(1).Day().StartAt(executeAt).LimitRunsTo(1).Tag(tag).Do(task, ctx, buildingID, executeAt)
+	return err
+}
+
+func (c *CronServiceImpl) publishBuilding(ctx context.Context, buildingID string, executeAt time.Time) error {
+	building, err := c.building.GetScheduledBuildingByID(ctx, buildingID)
+	if err != nil {
+		return err
+	}
+
+	// the schedule was changed or cancelled after this task was scheduled
+	if !building.PublishAt.Valid || !building.PublishAt.Time.Equal(executeAt) {
+		return nil
+	}
+
+	err = c.building.UpdateBuildingSchedule(ctx, &entity.Building{
+		ID:            building.ID,
+		UnpublishAt:   building.UnpublishAt,
+		ScheduledByID: building.ScheduledByID,
+	})
+	if err != nil {
+		return err
+	}
+
+	errs, err := c.buildingSvc.ValidateBuilding(ctx, buildingID)
+	if err == nil {
+		err = c.buildingSvc.UpdateBuildingPublishState(ctx, &dto.PublishRequest{IsPublished: custom.Bool(true)}, buildingID)
+	}
+
+	if err != nil {
+		c.reportBuildingScheduleFailure(ctx, building, "publish", errs, err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *CronServiceImpl) unpublishBuilding(ctx context.Context, buildingID string, executeAt time.Time) error {
+	building, err := c.building.GetScheduledBuildingByID(ctx, buildingID)
+	if err != nil {
+		return err
+	}
+
+	// the schedule was changed or cancelled after this task was scheduled
+	if !building.UnpublishAt.Valid || !building.UnpublishAt.Time.Equal(executeAt) {
+		return nil
+	}
+
+	err = c.building.UpdateBuildingSchedule(ctx, &entity.Building{
+		ID:            building.ID,
+		PublishAt:     building.PublishAt,
+		ScheduledByID: building.ScheduledByID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.buildingSvc.UpdateBuildingPublishState(ctx, &dto.PublishRequest{IsPublished: custom.Bool(false)}, buildingID)
+	if err != nil {
+		c.reportBuildingScheduleFailure(ctx, building, "unpublish", nil, err)
+		return err
+	}
+
+	return nil
+}
+
+// reportBuildingScheduleFailure tells the admin who scheduled the building why it wasn't published or unpublished
+func (c *CronServiceImpl) reportBuildingScheduleFailure(ctx context.Context, building *entity.Building, action string, errs *validator.ErrorsResponse, cause error) {
+	log.Printf("failed to %s building %s: %s\n", action, building.ID, cause.Error())
+	if building.ScheduledByID == "" {
+		return
+	}
+
+	reasons := []string{cause.Error()}
+	if errs != nil {
+		for _, e := range *errs {
+			reasons = append(reasons, e.Field+": "+e.Reason)
+		}
+	}
+
+	title := fmt.Sprintf("Scheduled %s of \"%s\" failed", action, building.Name)
+	message := strings.Join(reasons, ", ")
+
+	err := c.notification.SendNotifications(ctx, &entity.Notifications{
+		{
+			UserID:  building.ScheduledByID,
+			Type:    constant.BUILDING_SCHEDULE_FAILED_NOTIFICATION,
+			Title:   title,
+			Message: message,
+			Link:    "/admin/buildings/" + building.ID,
+		},
+	})
+	if err != nil {
+		log.Println("failed to send building schedule notification: ", err.Error())
+	}
+
+	err = c.mail.SendMail(ctx, &mail.Mail{
+		Subject:  title,
+		Template: "building-schedule-failed",
+		Variable: map[string]string{
+			"name":         building.ScheduledBy.Detail.Name,
+			"buildingName": building.Name,
+			"action":       action,
+			"reasons":      message,
+		},
+		Recipient: building.ScheduledBy.Email,
+	})
+	if err != nil {
+		log.Println("failed to send building schedule mail: ", err.Error())
+	}
+}
+
 func (c *CronServiceImpl) scheduleCancelReservation(ctx context.Context, reservationID string, executeAt time.Time) error {
 	if executeAt.Before(time.Now()) {
 		return c.cancelReservation(ctx, reservationID)
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"office-booking-backend/internal/notification/dto"
+	"office-booking-backend/internal/notification/service"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type NotificationController struct {
+	service   service.NotificationService
+	validator validator.Validator
+}
+
+func NewNotificationController(notificationService service.NotificationService, validator validator.Validator) *NotificationController {
+	return &NotificationController{
+		service:   notificationService,
+		validator: validator,
+	}
+}
+
+func (n *NotificationController) GetUserNotifications(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	filter := &dto.NotificationQueryParam{}
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := n.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	notifications, total, unread, err := n.service.GetUserNotifications(c.Context(), userID, filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "notifications fetched successfully",
+		Data:    notifications,
+		Meta: fiber.Map{
+			"total":  total,
+			"unread": unread,
+			"page":   filter.Page,
+			"limit":  filter.Limit,
+		},
+	})
+}
+
+func (n *NotificationController) MarkNotificationAsRead(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	notificationID := c.Params("notificationID")
+	err := n.service.MarkNotificationAsRead(c.Context(), userID, notificationID)
+	if err != nil {
+		if err == err2.ErrNotificationNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "notification marked as read successfully",
+	})
+}
+
+func (n *NotificationController) MarkAllNotificationsAsRead(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	err := n.service.MarkAllNotificationsAsRead(c.Context(), userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "notifications marked as read successfully",
+	})
+}
+
+func (n *NotificationController) DeleteNotification(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	notificationID := c.Params("notificationID")
+	err := n.service.DeleteNotification(c.Context(), userID, notificationID)
+	if err != nil {
+		if err == err2.ErrNotificationNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "notification deleted successfully",
+	})
+}
@@ -0,0 +1,8 @@
+package dto
+
+type NotificationQueryParam struct {
+	UnreadOnly bool `query:"unreadOnly"`
+	Page       int  `query:"page" validate:"gte=1"`
+	Limit      int  `query:"limit" validate:"gte=1"`
+	Offset     int  `query:"-" validate:"isdefault"`
+}
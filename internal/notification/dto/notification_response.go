@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+)
+
+type NotificationResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Link      string `json:"link"`
+	IsRead    bool   `json:"isRead"`
+	ReadAt    string `json:"readAt,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func NewNotificationResponse(notification *entity.Notification) *NotificationResponse {
+	readAt := ""
+	if notification.ReadAt.Valid {
+		readAt = notification.ReadAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	return &NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Link:      notification.Link,
+		IsRead:    notification.ReadAt.Valid,
+		ReadAt:    readAt,
+		CreatedAt: notification.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type NotificationsResponse []NotificationResponse
+
+func NewNotificationsResponse(notifications *entity.Notifications) *NotificationsResponse {
+	response := new(NotificationsResponse)
+	for _, notification := range *notifications {
+		*response = append(*response, *NewNotificationResponse(&notification))
+	}
+	return response
+}
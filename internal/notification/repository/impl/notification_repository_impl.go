@@ -0,0 +1,120 @@
+package impl
+
+import (
+	"context"
+	"office-booking-backend/internal/notification/repository"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepositoryImpl(db *gorm.DB) repository.NotificationRepository {
+	return &NotificationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (n *NotificationRepositoryImpl) GetUserNotifications(ctx context.Context, userID string, unreadOnly bool, offset int, limit int) (*entity.Notifications, int64, error) {
+	notifications := new(entity.Notifications)
+	var count int64
+
+	query := n.db.WithContext(ctx).
+		Model(&entity.Notification{}).
+		Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, count, nil
+}
+
+func (n *NotificationRepositoryImpl) CountUnreadNotifications(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := n.db.WithContext(ctx).
+		Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (n *NotificationRepositoryImpl) AddNotifications(ctx context.Context, notifications *entity.Notifications) error {
+	if len(*notifications) == 0 {
+		return nil
+	}
+
+	return n.db.WithContext(ctx).Create(notifications).Error
+}
+
+func (n *NotificationRepositoryImpl) MarkNotificationAsRead(ctx context.Context, userID string, notificationID string) error {
+	res := n.db.WithContext(ctx).
+		Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Where("read_at IS NULL").
+		Update("read_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		var count int64
+		err := n.db.WithContext(ctx).
+			Model(&entity.Notification{}).
+			Where("id = ? AND user_id = ?", notificationID, userID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return err2.ErrNotificationNotFound
+		}
+	}
+
+	return nil
+}
+
+func (n *NotificationRepositoryImpl) MarkAllNotificationsAsRead(ctx context.Context, userID string) error {
+	return n.db.WithContext(ctx).
+		Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+func (n *NotificationRepositoryImpl) DeleteNotification(ctx context.Context, userID string, notificationID string) error {
+	res := n.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Delete(&entity.Notification{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrNotificationNotFound
+	}
+
+	return nil
+}
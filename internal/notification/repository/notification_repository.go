@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"office-booking-backend/pkg/entity"
+)
+
+type NotificationRepository interface {
+	GetUserNotifications(ctx context.Context, userID string, unreadOnly bool, offset int, limit int) (*entity.Notifications, int64, error)
+	CountUnreadNotifications(ctx context.Context, userID string) (int64, error)
+	AddNotifications(ctx context.Context, notifications *entity.Notifications) error
+	MarkNotificationAsRead(ctx context.Context, userID string, notificationID string) error
+	MarkAllNotificationsAsRead(ctx context.Context, userID string) error
+	DeleteNotification(ctx context.Context, userID string, notificationID string) error
+}
@@ -0,0 +1,99 @@
+package impl
+
+import (
+	"context"
+	"log"
+	"office-booking-backend/internal/notification/dto"
+	"office-booking-backend/internal/notification/repository"
+	"office-booking-backend/internal/notification/service"
+	"office-booking-backend/pkg/entity"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type NotificationServiceImpl struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationServiceImpl(repo repository.NotificationRepository) service.NotificationService {
+	return &NotificationServiceImpl{
+		repo: repo,
+	}
+}
+
+func (n *NotificationServiceImpl) GetUserNotifications(ctx context.Context, userID string, filter *dto.NotificationQueryParam) (*dto.NotificationsResponse, int64, int64, error) {
+	filter.Offset = (filter.Page - 1) * filter.Limit
+
+	var notifications *entity.Notifications
+	var total, unread int64
+
+	errGroup, c := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		result, count, err := n.repo.GetUserNotifications(c, userID, filter.UnreadOnly, filter.Offset, filter.Limit)
+		if err != nil {
+			log.Println("error when getting user notifications: ", err)
+			return err
+		}
+
+		notifications = result
+		total = count
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		count, err := n.repo.CountUnreadNotifications(c, userID)
+		if err != nil {
+			log.Println("error when counting unread notifications: ", err)
+			return err
+		}
+
+		unread = count
+		return nil
+	})
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, 0, 0, err
+	}
+
+	return dto.NewNotificationsResponse(notifications), total, unread, nil
+}
+
+func (n *NotificationServiceImpl) SendNotifications(ctx context.Context, notifications *entity.Notifications) error {
+	err := n.repo.AddNotifications(ctx, notifications)
+	if err != nil {
+		log.Println("error when adding notifications: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *NotificationServiceImpl) MarkNotificationAsRead(ctx context.Context, userID string, notificationID string) error {
+	err := n.repo.MarkNotificationAsRead(ctx, userID, notificationID)
+	if err != nil {
+		log.Println("error when marking notification as read: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *NotificationServiceImpl) MarkAllNotificationsAsRead(ctx context.Context, userID string) error {
+	err := n.repo.MarkAllNotificationsAsRead(ctx, userID)
+	if err != nil {
+		log.Println("error when marking all notifications as read: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *NotificationServiceImpl) DeleteNotification(ctx context.Context, userID string, notificationID string) error {
+	err := n.repo.DeleteNotification(ctx, userID, notificationID)
+	if err != nil {
+		log.Println("error when deleting notification: ", err)
+		return err
+	}
+
+	return nil
+}
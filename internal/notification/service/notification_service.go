@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"office-booking-backend/internal/notification/dto"
+	"office-booking-backend/pkg/entity"
+)
+
+type NotificationService interface {
+	GetUserNotifications(ctx context.Context, userID string, filter *dto.NotificationQueryParam) (*dto.NotificationsResponse, int64, int64, error)
+	SendNotifications(ctx context.Context, notifications *entity.Notifications) error
+	MarkNotificationAsRead(ctx context.Context, userID string, notificationID string) error
+	MarkAllNotificationsAsRead(ctx context.Context, userID string) error
+	DeleteNotification(ctx context.Context, userID string, notificationID string) error
+}
@@ -0,0 +1,497 @@
+package controller
+
+import (
+	"office-booking-backend/internal/organization/dto"
+	"office-booking-backend/internal/organization/service"
+	dto2 "office-booking-backend/internal/reservation/dto"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type OrganizationController struct {
+	service   service.OrganizationService
+	validator validator.Validator
+}
+
+func NewOrganizationController(organizationService service.OrganizationService, validator validator.Validator) *OrganizationController {
+	return &OrganizationController{
+		service:   organizationService,
+		validator: validator,
+	}
+}
+
+// handleOrganizationError maps the errors shared by every organization endpoint
+func handleOrganizationError(err error) error {
+	switch err {
+	case err2.ErrOrganizationNotFound:
+		fallthrough
+	case err2.ErrMemberNotFound:
+		fallthrough
+	case err2.ErrReservationNotFound:
+		fallthrough
+	case err2.ErrUserNotFound:
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case err2.ErrNoPermission:
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case err2.ErrMemberAlreadyExist:
+		fallthrough
+	case err2.ErrLastOrganizationOwner:
+		fallthrough
+	case err2.ErrReservationNotAwaitingApproval:
+		fallthrough
+	case err2.ErrReservationActive:
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	default:
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+}
+
+func (o *OrganizationController) GetUserOrganizations(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizations, err := o.service.GetUserOrganizations(c.Context(), userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organizations fetched successfully",
+		Data:    organizations,
+	})
+}
+
+func (o *OrganizationController) GetOrganizationByID(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	organization, err := o.service.GetOrganizationByID(c.Context(), userID, organizationID)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organization fetched successfully",
+		Data:    organization,
+	})
+}
+
+func (o *OrganizationController) CreateOrganization(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organization := new(dto.AddOrganizationRequest)
+	if err := c.BodyParser(organization); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*organization); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	organizationID, err := o.service.CreateOrganization(c.Context(), userID, organization)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "organization created successfully",
+		Data: fiber.Map{
+			"organizationId": organizationID,
+		},
+	})
+}
+
+func (o *OrganizationController) UpdateOrganization(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	organization := new(dto.UpdateOrganizationRequest)
+	if err := c.BodyParser(organization); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*organization); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := o.service.UpdateOrganization(c.Context(), userID, organizationID, organization)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organization updated successfully",
+	})
+}
+
+func (o *OrganizationController) DeleteOrganization(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	err := o.service.DeleteOrganization(c.Context(), userID, organizationID)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organization deleted successfully",
+	})
+}
+
+func (o *OrganizationController) AddOrganizationMember(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	member := new(dto.AddMemberRequest)
+	if err := c.BodyParser(member); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*member); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := o.service.AddOrganizationMember(c.Context(), userID, organizationID, member)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "organization member added successfully",
+	})
+}
+
+func (o *OrganizationController) UpdateOrganizationMember(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+	memberID := c.Params("userID")
+
+	member := new(dto.UpdateMemberRequest)
+	if err := c.BodyParser(member); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*member); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := o.service.UpdateOrganizationMember(c.Context(), userID, organizationID, memberID, member)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organization member updated successfully",
+	})
+}
+
+func (o *OrganizationController) RemoveOrganizationMember(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+	memberID := c.Params("userID")
+
+	err := o.service.RemoveOrganizationMember(c.Context(), userID, organizationID, memberID)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organization member removed successfully",
+	})
+}
+
+func (o *OrganizationController) GetOrganizationReservations(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	filter := &dto2.ReservationQueryParam{}
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := o.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	reservations, count, err := o.service.GetOrganizationReservations(c.Context(), userID, organizationID, filter)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservations fetched successfully",
+		Data:    reservations,
+		Meta: fiber.Map{
+			"total": count,
+			"page":  filter.Page,
+			"limit": filter.Limit,
+		},
+	})
+}
+
+func (o *OrganizationController) GetOrganizationReservationByID(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+	reservationID := c.Params("reservationID")
+
+	reservation, err := o.service.GetOrganizationReservationByID(c.Context(), userID, organizationID, reservationID)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation fetched successfully",
+		Data:    reservation,
+	})
+}
+
+func (o *OrganizationController) CreateOrganizationReservation(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	reservation := new(dto2.AddReservartionRequest)
+	if err := c.BodyParser(reservation); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*reservation); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	reservationID, err := o.service.CreateOrganizationReservation(c.Context(), userID, organizationID, reservation)
+	if err != nil {
+		switch err {
+		case err2.ErrStartDateBeforeToday:
+			fallthrough
+		case err2.ErrBuildingNotFound:
+			return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidBuildingID.Error())
+		case err2.ErrBuildingNotAvailable, err2.ErrBuildingClosed:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case err2.ErrBookingAmountExceeded:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		default:
+			return handleOrganizationError(err)
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "reservation created successfully",
+		Data: fiber.Map{
+			"reservationId": reservationID,
+		},
+	})
+}
+
+func (o *OrganizationController) CancelOrganizationReservation(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+	reservationID := c.Params("reservationID")
+
+	err := o.service.CancelOrganizationReservation(c.Context(), userID, organizationID, reservationID)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation canceled successfully",
+	})
+}
+
+func (o *OrganizationController) UpdateOrganizationPolicy(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	policy := new(dto.UpdatePolicyRequest)
+	if err := c.BodyParser(policy); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*policy); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := o.service.UpdateOrganizationPolicy(c.Context(), userID, organizationID, policy)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "organization policy updated successfully",
+	})
+}
+
+func (o *OrganizationController) GetPendingApprovals(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	filter := &dto2.ReservationQueryParam{}
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := o.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	reservations, count, err := o.service.GetPendingApprovals(c.Context(), userID, organizationID, filter)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "pending approvals fetched successfully",
+		Data:    reservations,
+		Meta: fiber.Map{
+			"total": count,
+			"page":  filter.Page,
+			"limit": filter.Limit,
+		},
+	})
+}
+
+func (o *OrganizationController) GetApprovalLogs(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+
+	filter := &dto.ApprovalLogQueryParam{}
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := o.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	logs, count, err := o.service.GetApprovalLogs(c.Context(), userID, organizationID, filter)
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "approval logs fetched successfully",
+		Data:    logs,
+		Meta: fiber.Map{
+			"total": count,
+			"page":  filter.Page,
+			"limit": filter.Limit,
+		},
+	})
+}
+
+func (o *OrganizationController) ApproveOrganizationReservation(c *fiber.Ctx) error {
+	return o.decideReservation(c, true)
+}
+
+func (o *OrganizationController) RejectOrganizationReservation(c *fiber.Ctx) error {
+	return o.decideReservation(c, false)
+}
+
+func (o *OrganizationController) decideReservation(c *fiber.Ctx, approve bool) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	organizationID := c.Params("organizationID")
+	reservationID := c.Params("reservationID")
+
+	decision := new(dto.ApprovalDecisionRequest)
+	if err := c.BodyParser(decision); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := o.validator.ValidateJSON(*decision); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	var err error
+	message := "reservation approved successfully"
+	if approve {
+		err = o.service.ApproveOrganizationReservation(c.Context(), userID, organizationID, reservationID, decision)
+	} else {
+		message = "reservation rejected successfully"
+		err = o.service.RejectOrganizationReservation(c.Context(), userID, organizationID, reservationID, decision)
+	}
+	if err != nil {
+		return handleOrganizationError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: message,
+	})
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+)
+
+type AddOrganizationRequest struct {
+	Name           string `json:"name" validate:"required,min=3,max=255"`
+	BillingName    string `json:"billingName" validate:"omitempty,min=3,max=255"`
+	BillingEmail   string `json:"billingEmail" validate:"omitempty,email"`
+	BillingAddress string `json:"billingAddress" validate:"omitempty,min=3,max=255"`
+	TaxNumber      string `json:"taxNumber" validate:"omitempty,min=3,max=50"`
+}
+
+func (a *AddOrganizationRequest) ToEntity(ownerID string) *entity.Organization {
+	return &entity.Organization{
+		Name:           a.Name,
+		BillingName:    a.BillingName,
+		BillingEmail:   a.BillingEmail,
+		BillingAddress: a.BillingAddress,
+		TaxNumber:      a.TaxNumber,
+		Members: entity.OrganizationMembers{
+			{
+				UserID: ownerID,
+				Role:   constant.ORGANIZATION_OWNER_ROLE,
+			},
+		},
+	}
+}
+
+type UpdateOrganizationRequest struct {
+	Name           string `json:"name" validate:"omitempty,min=3,max=255"`
+	BillingName    string `json:"billingName" validate:"omitempty,min=3,max=255"`
+	BillingEmail   string `json:"billingEmail" validate:"omitempty,email"`
+	BillingAddress string `json:"billingAddress" validate:"omitempty,min=3,max=255"`
+	TaxNumber      string `json:"taxNumber" validate:"omitempty,min=3,max=50"`
+}
+
+func (u *UpdateOrganizationRequest) ToEntity(organizationID string) *entity.Organization {
+	return &entity.Organization{
+		ID:             organizationID,
+		Name:           u.Name,
+		BillingName:    u.BillingName,
+		BillingEmail:   u.BillingEmail,
+		BillingAddress: u.BillingAddress,
+		TaxNumber:      u.TaxNumber,
+	}
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=owner approver booker viewer"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner approver booker viewer"`
+}
+
+type UpdatePolicyRequest struct {
+	MonthlyBudget     int `json:"monthlyBudget" validate:"gte=0"`
+	MaxBookingAmount  int `json:"maxBookingAmount" validate:"gte=0"`
+	ApprovalThreshold int `json:"approvalThreshold" validate:"gte=0"`
+}
+
+func (u *UpdatePolicyRequest) ToEntity(organizationID string) *entity.Organization {
+	return &entity.Organization{
+		ID:                organizationID,
+		MonthlyBudget:     u.MonthlyBudget,
+		MaxBookingAmount:  u.MaxBookingAmount,
+		ApprovalThreshold: u.ApprovalThreshold,
+	}
+}
+
+type ApprovalDecisionRequest struct {
+	Message string `json:"message" validate:"omitempty,min=3,max=255"`
+}
+
+type ApprovalLogQueryParam struct {
+	Page   int `query:"page" validate:"gte=1"`
+	Limit  int `query:"limit" validate:"gte=1"`
+	Offset int `query:"-" validate:"isdefault"`
+}
@@ -0,0 +1,148 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+)
+
+type BriefOrganizationResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func NewBriefOrganizationResponse(organization *entity.Organization) *BriefOrganizationResponse {
+	return &BriefOrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type BriefOrganizationsResponse []BriefOrganizationResponse
+
+func NewBriefOrganizationsResponse(organizations *entity.Organizations) *BriefOrganizationsResponse {
+	response := new(BriefOrganizationsResponse)
+	for _, organization := range *organizations {
+		*response = append(*response, *NewBriefOrganizationResponse(&organization))
+	}
+	return response
+}
+
+type BillingResponse struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Address   string `json:"address"`
+	TaxNumber string `json:"taxNumber"`
+}
+
+type PolicyResponse struct {
+	MonthlyBudget     int `json:"monthlyBudget"`
+	MaxBookingAmount  int `json:"maxBookingAmount"`
+	ApprovalThreshold int `json:"approvalThreshold"`
+}
+
+type MemberResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Picture  string `json:"picture"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joinedAt"`
+}
+
+func NewMemberResponse(member *entity.OrganizationMember) *MemberResponse {
+	url := member.User.Detail.Picture.Url
+	if url == "" {
+		url = constant.DEFAULT_USER_AVATAR
+	}
+	return &MemberResponse{
+		ID:       member.UserID,
+		Name:     member.User.Detail.Name,
+		Email:    member.User.Email,
+		Picture:  url,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type MembersResponse []MemberResponse
+
+func NewMembersResponse(members *entity.OrganizationMembers) *MembersResponse {
+	response := new(MembersResponse)
+	for _, member := range *members {
+		*response = append(*response, *NewMemberResponse(&member))
+	}
+	return response
+}
+
+type FullOrganizationResponse struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Billing   BillingResponse `json:"billing"`
+	Policy    PolicyResponse  `json:"policy"`
+	Members   MembersResponse `json:"members"`
+	CreatedAt string          `json:"createdAt"`
+	UpdatedAt string          `json:"updatedAt"`
+}
+
+func NewFullOrganizationResponse(organization *entity.Organization) *FullOrganizationResponse {
+	return &FullOrganizationResponse{
+		ID:   organization.ID,
+		Name: organization.Name,
+		Billing: BillingResponse{
+			Name:      organization.BillingName,
+			Email:     organization.BillingEmail,
+			Address:   organization.BillingAddress,
+			TaxNumber: organization.TaxNumber,
+		},
+		Policy: PolicyResponse{
+			MonthlyBudget:     organization.MonthlyBudget,
+			MaxBookingAmount:  organization.MaxBookingAmount,
+			ApprovalThreshold: organization.ApprovalThreshold,
+		},
+		Members:   *NewMembersResponse(&organization.Members),
+		CreatedAt: organization.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+		UpdatedAt: organization.UpdatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ApprovalActorResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type ApprovalLogResponse struct {
+	ID            string                `json:"id"`
+	ReservationID string                `json:"reservationId"`
+	Actor         ApprovalActorResponse `json:"actor"`
+	Action        string                `json:"action"`
+	Reason        string                `json:"reason"`
+	CreatedAt     string                `json:"createdAt"`
+}
+
+func NewApprovalLogResponse(log *entity.OrganizationApprovalLog) *ApprovalLogResponse {
+	return &ApprovalLogResponse{
+		ID:            log.ID,
+		ReservationID: log.ReservationID,
+		Actor: ApprovalActorResponse{
+			ID:    log.Actor.ID,
+			Name:  log.Actor.Detail.Name,
+			Email: log.Actor.Email,
+		},
+		Action:    log.Action,
+		Reason:    log.Reason,
+		CreatedAt: log.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ApprovalLogsResponse []ApprovalLogResponse
+
+func NewApprovalLogsResponse(logs *entity.OrganizationApprovalLogs) *ApprovalLogsResponse {
+	response := new(ApprovalLogsResponse)
+	for _, log := range *logs {
+		*response = append(*response, *NewApprovalLogResponse(&log))
+	}
+	return response
+}
@@ -0,0 +1,262 @@
+package impl
+
+import (
+	"errors"
+	"office-booking-backend/internal/organization/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"strings"
+
+	"golang.org/x/net/context"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepositoryImpl(db *gorm.DB) repository.OrganizationRepository {
+	return &OrganizationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (o *OrganizationRepositoryImpl) GetUserOrganizations(ctx context.Context, userID string) (*entity.Organizations, error) {
+	organizations := new(entity.Organizations)
+	err := o.db.WithContext(ctx).
+		Joins("JOIN organization_members om ON om.organization_id = organizations.id").
+		Where("om.user_id = ?", userID).
+		Order("organizations.created_at DESC").
+		Find(organizations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (o *OrganizationRepositoryImpl) GetOrganizationByID(ctx context.Context, organizationID string) (*entity.Organization, error) {
+	organization := new(entity.Organization)
+	err := o.db.WithContext(ctx).
+		Preload("Members", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Members.User.Detail.Picture").
+		Where("id = ?", organizationID).
+		First(organization).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrOrganizationNotFound
+		}
+
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (o *OrganizationRepositoryImpl) GetOrganizationMember(ctx context.Context, organizationID string, userID string) (*entity.OrganizationMember, error) {
+	member := new(entity.OrganizationMember)
+	err := o.db.WithContext(ctx).
+		Joins("JOIN organizations o ON o.id = organization_members.organization_id AND o.deleted_at IS NULL").
+		Where("organization_members.organization_id = ?", organizationID).
+		Where("organization_members.user_id = ?", userID).
+		First(member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrMemberNotFound
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (o *OrganizationRepositoryImpl) CountOrganizationOwners(ctx context.Context, organizationID string) (int64, error) {
+	var count int64
+	err := o.db.WithContext(ctx).
+		Model(&entity.OrganizationMember{}).
+		Where("organization_id = ?", organizationID).
+		Where("role = ?", constant.ORGANIZATION_OWNER_ROLE).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (o *OrganizationRepositoryImpl) AddOrganization(ctx context.Context, organization *entity.Organization) error {
+	// members are created along with the organization in the same transaction
+	err := o.db.WithContext(ctx).Create(organization).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "CONSTRAINT `fk_organization_members_user`") {
+			return err2.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) AddOrganizationMember(ctx context.Context, member *entity.OrganizationMember) error {
+	err := o.db.WithContext(ctx).Create(member).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "Duplicate entry"):
+			return err2.ErrMemberAlreadyExist
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_organizations_members`"):
+			return err2.ErrOrganizationNotFound
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_organization_members_user`"):
+			return err2.ErrUserNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) UpdateOrganization(ctx context.Context, organization *entity.Organization) error {
+	res := o.db.WithContext(ctx).
+		Model(&entity.Organization{}).
+		Where("id = ?", organization.ID).
+		Updates(organization)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) UpdateOrganizationMember(ctx context.Context, member *entity.OrganizationMember) error {
+	res := o.db.WithContext(ctx).
+		Model(&entity.OrganizationMember{}).
+		Where("organization_id = ?", member.OrganizationID).
+		Where("user_id = ?", member.UserID).
+		Update("role", member.Role)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) DeleteOrganizationByID(ctx context.Context, organizationID string) error {
+	res := o.db.WithContext(ctx).
+		Where("id = ?", organizationID).
+		Delete(&entity.Organization{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) DeleteOrganizationMember(ctx context.Context, organizationID string, userID string) error {
+	res := o.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Where("user_id = ?", userID).
+		Delete(&entity.OrganizationMember{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) GetOrganizationApprovers(ctx context.Context, organizationID string) (*entity.OrganizationMembers, error) {
+	members := new(entity.OrganizationMembers)
+	err := o.db.WithContext(ctx).
+		Preload("User.Detail").
+		Where("organization_id = ?", organizationID).
+		Where("role IN (?)", []string{constant.ORGANIZATION_OWNER_ROLE, constant.ORGANIZATION_APPROVER_ROLE}).
+		Find(members).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (o *OrganizationRepositoryImpl) CountApprovalLogs(ctx context.Context, organizationID string) (int64, error) {
+	var count int64
+	err := o.db.WithContext(ctx).
+		Model(&entity.OrganizationApprovalLog{}).
+		Where("organization_id = ?", organizationID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (o *OrganizationRepositoryImpl) GetApprovalLogs(ctx context.Context, organizationID string, offset int, limit int) (*entity.OrganizationApprovalLogs, error) {
+	logs := new(entity.OrganizationApprovalLogs)
+	err := o.db.WithContext(ctx).
+		Preload("Actor.Detail").
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(logs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (o *OrganizationRepositoryImpl) AddApprovalLog(ctx context.Context, log *entity.OrganizationApprovalLog) error {
+	err := o.db.WithContext(ctx).Create(log).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_organization_approval_logs_reservation`"):
+			return err2.ErrReservationNotFound
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_organization_approval_logs_organization`"):
+			return err2.ErrOrganizationNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *OrganizationRepositoryImpl) UpdateOrganizationPolicy(ctx context.Context, organization *entity.Organization) error {
+	// select the columns explicitly so a policy can be disabled by setting it to 0
+	res := o.db.WithContext(ctx).
+		Model(&entity.Organization{}).
+		Where("id = ?", organization.ID).
+		Select("monthly_budget", "max_booking_amount", "approval_threshold").
+		Updates(organization)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrOrganizationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package mock
+
+import (
+	"office-booking-backend/pkg/entity"
+
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+type OrganizationRepositoryMock struct {
+	mock.Mock
+}
+
+func (o *OrganizationRepositoryMock) GetUserOrganizations(ctx context.Context, userID string) (*entity.Organizations, error) {
+	args := o.Called(ctx, userID)
+	return args.Get(0).(*entity.Organizations), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) GetOrganizationByID(ctx context.Context, organizationID string) (*entity.Organization, error) {
+	args := o.Called(ctx, organizationID)
+	return args.Get(0).(*entity.Organization), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) GetOrganizationMember(ctx context.Context, organizationID string, userID string) (*entity.OrganizationMember, error) {
+	args := o.Called(ctx, organizationID, userID)
+	return args.Get(0).(*entity.OrganizationMember), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) CountOrganizationOwners(ctx context.Context, organizationID string) (int64, error) {
+	args := o.Called(ctx, organizationID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) GetOrganizationApprovers(ctx context.Context, organizationID string) (*entity.OrganizationMembers, error) {
+	args := o.Called(ctx, organizationID)
+	return args.Get(0).(*entity.OrganizationMembers), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) CountApprovalLogs(ctx context.Context, organizationID string) (int64, error) {
+	args := o.Called(ctx, organizationID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) GetApprovalLogs(ctx context.Context, organizationID string, offset int, limit int) (*entity.OrganizationApprovalLogs, error) {
+	args := o.Called(ctx, organizationID, offset, limit)
+	return args.Get(0).(*entity.OrganizationApprovalLogs), args.Error(1)
+}
+
+func (o *OrganizationRepositoryMock) AddOrganization(ctx context.Context, organization *entity.Organization) error {
+	args := o.Called(ctx, organization)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) AddOrganizationMember(ctx context.Context, member *entity.OrganizationMember) error {
+	args := o.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) AddApprovalLog(ctx context.Context, log *entity.OrganizationApprovalLog) error {
+	args := o.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) UpdateOrganization(ctx context.Context, organization *entity.Organization) error {
+	args := o.Called(ctx, organization)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) UpdateOrganizationMember(ctx context.Context, member *entity.OrganizationMember) error {
+	args := o.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) UpdateOrganizationPolicy(ctx context.Context, organization *entity.Organization) error {
+	args := o.Called(ctx, organization)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) DeleteOrganizationByID(ctx context.Context, organizationID string) error {
+	args := o.Called(ctx, organizationID)
+	return args.Error(0)
+}
+
+func (o *OrganizationRepositoryMock) DeleteOrganizationMember(ctx context.Context, organizationID string, userID string) error {
+	args := o.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
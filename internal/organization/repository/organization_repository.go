@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"office-booking-backend/pkg/entity"
+
+	"golang.org/x/net/context"
+)
+
+type OrganizationRepository interface {
+	GetUserOrganizations(ctx context.Context, userID string) (*entity.Organizations, error)
+	GetOrganizationByID(ctx context.Context, organizationID string) (*entity.Organization, error)
+	GetOrganizationMember(ctx context.Context, organizationID string, userID string) (*entity.OrganizationMember, error)
+	CountOrganizationOwners(ctx context.Context, organizationID string) (int64, error)
+	GetOrganizationApprovers(ctx context.Context, organizationID string) (*entity.OrganizationMembers, error)
+	CountApprovalLogs(ctx context.Context, organizationID string) (int64, error)
+	GetApprovalLogs(ctx context.Context, organizationID string, offset int, limit int) (*entity.OrganizationApprovalLogs, error)
+	AddOrganization(ctx context.Context, organization *entity.Organization) error
+	AddOrganizationMember(ctx context.Context, member *entity.OrganizationMember) error
+	AddApprovalLog(ctx context.Context, log *entity.OrganizationApprovalLog) error
+	UpdateOrganization(ctx context.Context, organization *entity.Organization) error
+	UpdateOrganizationMember(ctx context.Context, member *entity.OrganizationMember) error
+	UpdateOrganizationPolicy(ctx context.Context, organization *entity.Organization) error
+	DeleteOrganizationByID(ctx context.Context, organizationID string) error
+	DeleteOrganizationMember(ctx context.Context, organizationID string, userID string) error
+}
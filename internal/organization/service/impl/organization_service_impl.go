@@ -0,0 +1,463 @@
+package impl
+
+import (
+	"log"
+	"office-booking-backend/internal/organization/dto"
+	"office-booking-backend/internal/organization/repository"
+	"office-booking-backend/internal/organization/service"
+	dto2 "office-booking-backend/internal/reservation/dto"
+	service2 "office-booking-backend/internal/reservation/service"
+	repository2 "office-booking-backend/internal/user/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/mail"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+type OrganizationServiceImpl struct {
+	repo               repository.OrganizationRepository
+	userRepo           repository2.UserRepository
+	reservationService service2.ReservationService
+	mail               mail.Client
+}
+
+func NewOrganizationServiceImpl(organizationRepository repository.OrganizationRepository, userRepository repository2.UserRepository, reservationService service2.ReservationService, mailClient mail.Client) service.OrganizationService {
+	return &OrganizationServiceImpl{
+		repo:               organizationRepository,
+		userRepo:           userRepository,
+		reservationService: reservationService,
+		mail:               mailClient,
+	}
+}
+
+// authorize makes sure the user is a member of the organization with one of the given roles.
+// non-members get ErrOrganizationNotFound so the existence of the organization isn't leaked
+func (o *OrganizationServiceImpl) authorize(ctx context.Context, organizationID string, userID string, roles ...string) (*entity.OrganizationMember, error) {
+	member, err := o.repo.GetOrganizationMember(ctx, organizationID, userID)
+	if err != nil {
+		if err == err2.ErrMemberNotFound {
+			return nil, err2.ErrOrganizationNotFound
+		}
+
+		log.Println("error when getting organization member: ", err)
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return member, nil
+	}
+
+	for _, role := range roles {
+		if member.Role == role {
+			return member, nil
+		}
+	}
+
+	return nil, err2.ErrNoPermission
+}
+
+func (o *OrganizationServiceImpl) GetUserOrganizations(ctx context.Context, userID string) (*dto.BriefOrganizationsResponse, error) {
+	organizations, err := o.repo.GetUserOrganizations(ctx, userID)
+	if err != nil {
+		log.Println("error when getting user organizations: ", err)
+		return nil, err
+	}
+
+	return dto.NewBriefOrganizationsResponse(organizations), nil
+}
+
+func (o *OrganizationServiceImpl) GetOrganizationByID(ctx context.Context, userID string, organizationID string) (*dto.FullOrganizationResponse, error) {
+	_, err := o.authorize(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := o.repo.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		log.Println("error when getting organization by id: ", err)
+		return nil, err
+	}
+
+	return dto.NewFullOrganizationResponse(organization), nil
+}
+
+func (o *OrganizationServiceImpl) CreateOrganization(ctx context.Context, userID string, organization *dto.AddOrganizationRequest) (string, error) {
+	organizationEntity := organization.ToEntity(userID)
+	err := o.repo.AddOrganization(ctx, organizationEntity)
+	if err != nil {
+		log.Println("error when creating organization: ", err)
+		return "", err
+	}
+
+	return organizationEntity.ID, nil
+}
+
+func (o *OrganizationServiceImpl) UpdateOrganization(ctx context.Context, userID string, organizationID string, organization *dto.UpdateOrganizationRequest) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	err = o.repo.UpdateOrganization(ctx, organization.ToEntity(organizationID))
+	if err != nil {
+		log.Println("error when updating organization: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) DeleteOrganization(ctx context.Context, userID string, organizationID string) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	err = o.repo.DeleteOrganizationByID(ctx, organizationID)
+	if err != nil {
+		log.Println("error when deleting organization: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) AddOrganizationMember(ctx context.Context, userID string, organizationID string, member *dto.AddMemberRequest) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	user, err := o.userRepo.GetFullUserByEmail(ctx, member.Email)
+	if err != nil {
+		log.Println("error when getting user by email: ", err)
+		return err
+	}
+
+	err = o.repo.AddOrganizationMember(ctx, &entity.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           member.Role,
+	})
+	if err != nil {
+		log.Println("error when adding organization member: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) UpdateOrganizationMember(ctx context.Context, userID string, organizationID string, memberID string, member *dto.UpdateMemberRequest) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	current, err := o.repo.GetOrganizationMember(ctx, organizationID, memberID)
+	if err != nil {
+		log.Println("error when getting organization member: ", err)
+		return err
+	}
+
+	if current.Role == constant.ORGANIZATION_OWNER_ROLE && member.Role != constant.ORGANIZATION_OWNER_ROLE {
+		err = o.ensureAnotherOwner(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.repo.UpdateOrganizationMember(ctx, &entity.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         memberID,
+		Role:           member.Role,
+	})
+	if err != nil {
+		log.Println("error when updating organization member: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) RemoveOrganizationMember(ctx context.Context, userID string, organizationID string, memberID string) error {
+	// members are allowed to leave the organization by themselves
+	if userID == memberID {
+		_, err := o.authorize(ctx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE)
+		if err != nil {
+			return err
+		}
+	}
+
+	current, err := o.repo.GetOrganizationMember(ctx, organizationID, memberID)
+	if err != nil {
+		log.Println("error when getting organization member: ", err)
+		return err
+	}
+
+	if current.Role == constant.ORGANIZATION_OWNER_ROLE {
+		err = o.ensureAnotherOwner(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.repo.DeleteOrganizationMember(ctx, organizationID, memberID)
+	if err != nil {
+		log.Println("error when removing organization member: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) ensureAnotherOwner(ctx context.Context, organizationID string) error {
+	count, err := o.repo.CountOrganizationOwners(ctx, organizationID)
+	if err != nil {
+		log.Println("error when counting organization owners: ", err)
+		return err
+	}
+
+	if count <= 1 {
+		return err2.ErrLastOrganizationOwner
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) GetOrganizationReservations(ctx context.Context, userID string, organizationID string, filter *dto2.ReservationQueryParam) (*dto2.BriefAdminReservationsResponse, int64, error) {
+	_, err := o.authorize(ctx, organizationID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter.OrganizationID = organizationID
+	return o.reservationService.GetReservations(ctx, filter)
+}
+
+func (o *OrganizationServiceImpl) GetOrganizationReservationByID(ctx context.Context, userID string, organizationID string, reservationID string) (*dto2.FullAdminReservationResponse, error) {
+	_, err := o.authorize(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.reservationService.GetOrganizationReservationByID(ctx, organizationID, reservationID)
+}
+
+func (o *OrganizationServiceImpl) CreateOrganizationReservation(ctx context.Context, userID string, organizationID string, reservation *dto2.AddReservartionRequest) (string, error) {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE, constant.ORGANIZATION_BOOKER_ROLE)
+	if err != nil {
+		return "", err
+	}
+
+	organization, err := o.repo.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		log.Println("error when getting organization by id: ", err)
+		return "", err
+	}
+
+	var reasons []string
+	var amount int
+	reservationID, err := o.reservationService.CreateOrganizationReservation(ctx, userID, organizationID, reservation, func(reservation *entity.Reservation, spending int64) error {
+		amount = reservation.Amount
+		reasons, err = checkPolicies(organization, amount, spending)
+		if err != nil {
+			return err
+		}
+
+		// reservations violating a policy must be approved internally before the admin can review them
+		if len(reasons) > 0 {
+			reservation.StatusID = constant.PENDING_APPROVAL_STATUS
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(reasons) > 0 {
+		o.addApprovalLog(ctx, &entity.OrganizationApprovalLog{
+			OrganizationID: organizationID,
+			ReservationID:  reservationID,
+			ActorID:        userID,
+			Action:         constant.APPROVAL_REQUESTED_ACTION,
+			Reason:         strings.Join(reasons, "; "),
+		})
+		o.notifyApprovers(ctx, organization, reservationID, amount, reasons)
+	}
+
+	return reservationID, nil
+}
+
+// checkPolicies rejects a reservation above the maximum booking amount and returns the reasons why it needs
+// an internal approval, if any. spending is what the organization already booked this month
+func checkPolicies(organization *entity.Organization, amount int, spending int64) ([]string, error) {
+	if organization.MaxBookingAmount > 0 && amount > organization.MaxBookingAmount {
+		return nil, err2.ErrBookingAmountExceeded
+	}
+
+	var reasons []string
+	if organization.ApprovalThreshold > 0 && amount > organization.ApprovalThreshold {
+		reasons = append(reasons, "amount exceeds the approval threshold")
+	}
+
+	if organization.MonthlyBudget > 0 && spending+int64(amount) > int64(organization.MonthlyBudget) {
+		reasons = append(reasons, "monthly budget exceeded")
+	}
+
+	return reasons, nil
+}
+
+func (o *OrganizationServiceImpl) addApprovalLog(ctx context.Context, approvalLog *entity.OrganizationApprovalLog) {
+	err := o.repo.AddApprovalLog(ctx, approvalLog)
+	if err != nil {
+		log.Println("error when adding organization approval log: ", err)
+	}
+}
+
+func (o *OrganizationServiceImpl) notifyApprovers(ctx context.Context, organization *entity.Organization, reservationID string, amount int, reasons []string) {
+	approvers, err := o.repo.GetOrganizationApprovers(ctx, organization.ID)
+	if err != nil {
+		log.Println("error when getting organization approvers: ", err)
+		return
+	}
+
+	for _, approver := range *approvers {
+		err = o.mail.SendMail(ctx, &mail.Mail{
+			Subject:  "Reservation Awaiting Approval",
+			Template: "organization-approval-request",
+			Variable: map[string]string{
+				"name":             approver.User.Detail.Name,
+				"organizationName": organization.Name,
+				"reservationId":    reservationID,
+				"amount":           strconv.Itoa(amount),
+				"reasons":          strings.Join(reasons, ", "),
+			},
+			Recipient: approver.User.Email,
+		})
+		if err != nil {
+			log.Println("error when sending approval request mail: ", err)
+		}
+	}
+}
+
+func (o *OrganizationServiceImpl) ApproveOrganizationReservation(ctx context.Context, userID string, organizationID string, reservationID string, decision *dto.ApprovalDecisionRequest) error {
+	return o.decideReservation(ctx, userID, organizationID, reservationID, constant.PENDING_STATUS, constant.APPROVAL_APPROVED_ACTION, decision.Message)
+}
+
+func (o *OrganizationServiceImpl) RejectOrganizationReservation(ctx context.Context, userID string, organizationID string, reservationID string, decision *dto.ApprovalDecisionRequest) error {
+	return o.decideReservation(ctx, userID, organizationID, reservationID, constant.REJECTED_STATUS, constant.APPROVAL_REJECTED_ACTION, decision.Message)
+}
+
+func (o *OrganizationServiceImpl) decideReservation(ctx context.Context, userID string, organizationID string, reservationID string, statusID int, action string, message string) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE, constant.ORGANIZATION_APPROVER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	err = o.reservationService.UpdateOrganizationReservationApproval(ctx, organizationID, reservationID, statusID, message)
+	if err != nil {
+		return err
+	}
+
+	o.addApprovalLog(ctx, &entity.OrganizationApprovalLog{
+		OrganizationID: organizationID,
+		ReservationID:  reservationID,
+		ActorID:        userID,
+		Action:         action,
+		Reason:         message,
+	})
+
+	reservation, err := o.reservationService.GetOrganizationReservationByID(ctx, organizationID, reservationID)
+	if err != nil {
+		log.Println("error when getting organization reservation: ", err)
+		return nil
+	}
+
+	err = o.mail.SendMail(ctx, &mail.Mail{
+		Subject:  "Reservation Approval Decision",
+		Template: "organization-approval-decision",
+		Variable: map[string]string{
+			"name":          reservation.Tenant.Name,
+			"reservationId": reservationID,
+			"decision":      action,
+			"message":       message,
+		},
+		Recipient: reservation.Tenant.Email,
+	})
+	if err != nil {
+		log.Println("error when sending approval decision mail: ", err)
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) GetPendingApprovals(ctx context.Context, userID string, organizationID string, filter *dto2.ReservationQueryParam) (*dto2.BriefAdminReservationsResponse, int64, error) {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE, constant.ORGANIZATION_APPROVER_ROLE)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter.OrganizationID = organizationID
+	filter.StatusID = constant.PENDING_APPROVAL_STATUS
+	return o.reservationService.GetReservations(ctx, filter)
+}
+
+func (o *OrganizationServiceImpl) GetApprovalLogs(ctx context.Context, userID string, organizationID string, filter *dto.ApprovalLogQueryParam) (*dto.ApprovalLogsResponse, int64, error) {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE, constant.ORGANIZATION_APPROVER_ROLE)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := o.repo.CountApprovalLogs(ctx, organizationID)
+	if err != nil {
+		log.Println("error when counting approval logs: ", err)
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	filter.Offset = (filter.Page - 1) * filter.Limit
+	logs, err := o.repo.GetApprovalLogs(ctx, organizationID, filter.Offset, filter.Limit)
+	if err != nil {
+		log.Println("error when getting approval logs: ", err)
+		return nil, 0, err
+	}
+
+	return dto.NewApprovalLogsResponse(logs), count, nil
+}
+
+func (o *OrganizationServiceImpl) UpdateOrganizationPolicy(ctx context.Context, userID string, organizationID string, policy *dto.UpdatePolicyRequest) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	err = o.repo.UpdateOrganizationPolicy(ctx, policy.ToEntity(organizationID))
+	if err != nil {
+		log.Println("error when updating organization policy: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) CancelOrganizationReservation(ctx context.Context, userID string, organizationID string, reservationID string) error {
+	_, err := o.authorize(ctx, organizationID, userID, constant.ORGANIZATION_OWNER_ROLE, constant.ORGANIZATION_BOOKER_ROLE)
+	if err != nil {
+		return err
+	}
+
+	return o.reservationService.CancelOrganizationReservation(ctx, organizationID, reservationID)
+}
@@ -0,0 +1,159 @@
+package impl
+
+import (
+	"context"
+	"office-booking-backend/internal/organization/dto"
+	mockRepo "office-booking-backend/internal/organization/repository/mock"
+	"office-booking-backend/internal/organization/service"
+	dto2 "office-booking-backend/internal/reservation/dto"
+	mockReservationSrv "office-booking-backend/internal/reservation/service/mock"
+	mockUserRepo "office-booking-backend/internal/user/repository/mock"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	mockMail "office-booking-backend/pkg/utils/mail"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteOrganizationService struct {
+	suite.Suite
+	mockRepo            *mockRepo.OrganizationRepositoryMock
+	mockUserRepo        *mockUserRepo.UserRepositoryMock
+	mockReservationSrv  *mockReservationSrv.ReservationServiceMock
+	mockMail            *mockMail.ClientMock
+	organizationService service.OrganizationService
+}
+
+func (s *TestSuiteOrganizationService) SetupTest() {
+	s.mockRepo = new(mockRepo.OrganizationRepositoryMock)
+	s.mockUserRepo = new(mockUserRepo.UserRepositoryMock)
+	s.mockReservationSrv = new(mockReservationSrv.ReservationServiceMock)
+	s.mockMail = new(mockMail.ClientMock)
+	s.organizationService = NewOrganizationServiceImpl(s.mockRepo, s.mockUserRepo, s.mockReservationSrv, s.mockMail)
+}
+
+func (s *TestSuiteOrganizationService) TearDownTest() {
+	s.mockRepo = nil
+	s.mockUserRepo = nil
+	s.mockReservationSrv = nil
+	s.mockMail = nil
+	s.organizationService = nil
+}
+
+func TestOrganizationService(t *testing.T) {
+	suite.Run(t, new(TestSuiteOrganizationService))
+}
+
+func (s *TestSuiteOrganizationService) TestCheckPolicies() {
+	organization := &entity.Organization{
+		MonthlyBudget:     1000,
+		MaxBookingAmount:  600,
+		ApprovalThreshold: 400,
+	}
+
+	for _, tc := range []struct {
+		Name     string
+		Amount   int
+		Spending int64
+		Reasons  int
+		Err      error
+	}{
+		{Name: "within policies", Amount: 300, Spending: 500},
+		{Name: "above threshold", Amount: 500, Spending: 0, Reasons: 1},
+		{Name: "over budget", Amount: 300, Spending: 800, Reasons: 1},
+		{Name: "above threshold and over budget", Amount: 500, Spending: 800, Reasons: 2},
+		{Name: "exactly the budget", Amount: 300, Spending: 700},
+		{Name: "above max booking amount", Amount: 700, Spending: 0, Err: err2.ErrBookingAmountExceeded},
+	} {
+		s.Run(tc.Name, func() {
+			reasons, err := checkPolicies(organization, tc.Amount, tc.Spending)
+			s.Equal(tc.Err, err)
+			s.Len(reasons, tc.Reasons)
+		})
+	}
+
+	reasons, err := checkPolicies(&entity.Organization{}, 1000000, 1000000)
+	s.NoError(err)
+	s.Empty(reasons)
+}
+
+func (s *TestSuiteOrganizationService) mockBooking(role string, organization *entity.Organization, reservation *entity.Reservation, spending int64) {
+	s.mockRepo.On("GetOrganizationMember", mock.Anything, "organization", "user").Return(&entity.OrganizationMember{Role: role}, nil)
+	s.mockRepo.On("GetOrganizationByID", mock.Anything, "organization").Return(organization, nil)
+	s.mockReservationSrv.On("CreateOrganizationReservation", mock.Anything, "user", "organization", mock.Anything).Return(reservation, spending, "reservation", nil)
+}
+
+func (s *TestSuiteOrganizationService) TestCreateOrganizationReservation_WithinPolicies() {
+	reservation := &entity.Reservation{Amount: 300}
+	s.mockBooking(constant.ORGANIZATION_BOOKER_ROLE, &entity.Organization{ID: "organization", MonthlyBudget: 1000}, reservation, 0)
+
+	id, err := s.organizationService.CreateOrganizationReservation(context.Background(), "user", "organization", &dto2.AddReservartionRequest{})
+	s.NoError(err)
+	s.Equal("reservation", id)
+	s.Zero(reservation.StatusID)
+	s.mockRepo.AssertNotCalled(s.T(), "AddApprovalLog", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteOrganizationService) TestCreateOrganizationReservation_NeedsApproval() {
+	reservation := &entity.Reservation{Amount: 300}
+	s.mockBooking(constant.ORGANIZATION_OWNER_ROLE, &entity.Organization{ID: "organization", MonthlyBudget: 1000}, reservation, 800)
+	s.mockRepo.On("AddApprovalLog", mock.Anything, mock.Anything).Return(nil)
+	s.mockRepo.On("GetOrganizationApprovers", mock.Anything, "organization").Return(&entity.OrganizationMembers{{}, {}}, nil)
+	s.mockMail.On("SendMail", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := s.organizationService.CreateOrganizationReservation(context.Background(), "user", "organization", &dto2.AddReservartionRequest{})
+	s.NoError(err)
+	s.Equal(constant.PENDING_APPROVAL_STATUS, reservation.StatusID)
+	s.mockRepo.AssertCalled(s.T(), "AddApprovalLog", mock.Anything, mock.MatchedBy(func(log *entity.OrganizationApprovalLog) bool {
+		return log.Action == constant.APPROVAL_REQUESTED_ACTION && log.Reason == "monthly budget exceeded"
+	}))
+	s.mockMail.AssertNumberOfCalls(s.T(), "SendMail", 2)
+}
+
+func (s *TestSuiteOrganizationService) TestCreateOrganizationReservation_AmountExceeded() {
+	s.mockBooking(constant.ORGANIZATION_BOOKER_ROLE, &entity.Organization{ID: "organization", MaxBookingAmount: 100}, &entity.Reservation{Amount: 300}, 0)
+
+	_, err := s.organizationService.CreateOrganizationReservation(context.Background(), "user", "organization", &dto2.AddReservartionRequest{})
+	s.Equal(err2.ErrBookingAmountExceeded, err)
+	s.mockRepo.AssertNotCalled(s.T(), "AddApprovalLog", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteOrganizationService) TestCreateOrganizationReservation_NoPermission() {
+	s.mockRepo.On("GetOrganizationMember", mock.Anything, "organization", "user").Return(&entity.OrganizationMember{Role: constant.ORGANIZATION_VIEWER_ROLE}, nil)
+
+	_, err := s.organizationService.CreateOrganizationReservation(context.Background(), "user", "organization", &dto2.AddReservartionRequest{})
+	s.Equal(err2.ErrNoPermission, err)
+	s.mockReservationSrv.AssertNotCalled(s.T(), "CreateOrganizationReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteOrganizationService) TestCreateOrganizationReservation_NotMember() {
+	s.mockRepo.On("GetOrganizationMember", mock.Anything, "organization", "user").Return((*entity.OrganizationMember)(nil), err2.ErrMemberNotFound)
+
+	_, err := s.organizationService.CreateOrganizationReservation(context.Background(), "user", "organization", &dto2.AddReservartionRequest{})
+	s.Equal(err2.ErrOrganizationNotFound, err)
+}
+
+func (s *TestSuiteOrganizationService) TestApproveOrganizationReservation_Success() {
+	s.mockRepo.On("GetOrganizationMember", mock.Anything, "organization", "user").Return(&entity.OrganizationMember{Role: constant.ORGANIZATION_APPROVER_ROLE}, nil)
+	s.mockReservationSrv.On("UpdateOrganizationReservationApproval", mock.Anything, "organization", "reservation", constant.PENDING_STATUS, "ok").Return(nil)
+	s.mockRepo.On("AddApprovalLog", mock.Anything, mock.Anything).Return(nil)
+	s.mockReservationSrv.On("GetOrganizationReservationByID", mock.Anything, "organization", "reservation").Return(&dto2.FullAdminReservationResponse{}, nil)
+	s.mockMail.On("SendMail", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.organizationService.ApproveOrganizationReservation(context.Background(), "user", "organization", "reservation", &dto.ApprovalDecisionRequest{Message: "ok"})
+	s.NoError(err)
+	s.mockRepo.AssertCalled(s.T(), "AddApprovalLog", mock.Anything, mock.MatchedBy(func(log *entity.OrganizationApprovalLog) bool {
+		return log.Action == constant.APPROVAL_APPROVED_ACTION
+	}))
+}
+
+func (s *TestSuiteOrganizationService) TestRejectOrganizationReservation_BookerCantDecide() {
+	s.mockRepo.On("GetOrganizationMember", mock.Anything, "organization", "user").Return(&entity.OrganizationMember{Role: constant.ORGANIZATION_BOOKER_ROLE}, nil)
+
+	err := s.organizationService.RejectOrganizationReservation(context.Background(), "user", "organization", "reservation", &dto.ApprovalDecisionRequest{})
+	s.Equal(err2.ErrNoPermission, err)
+	s.mockReservationSrv.AssertNotCalled(s.T(), "UpdateOrganizationReservationApproval", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"office-booking-backend/internal/organization/dto"
+	dto2 "office-booking-backend/internal/reservation/dto"
+
+	"golang.org/x/net/context"
+)
+
+type OrganizationService interface {
+	GetUserOrganizations(ctx context.Context, userID string) (*dto.BriefOrganizationsResponse, error)
+	GetOrganizationByID(ctx context.Context, userID string, organizationID string) (*dto.FullOrganizationResponse, error)
+	CreateOrganization(ctx context.Context, userID string, organization *dto.AddOrganizationRequest) (string, error)
+	UpdateOrganization(ctx context.Context, userID string, organizationID string, organization *dto.UpdateOrganizationRequest) error
+	DeleteOrganization(ctx context.Context, userID string, organizationID string) error
+	AddOrganizationMember(ctx context.Context, userID string, organizationID string, member *dto.AddMemberRequest) error
+	UpdateOrganizationMember(ctx context.Context, userID string, organizationID string, memberID string, member *dto.UpdateMemberRequest) error
+	RemoveOrganizationMember(ctx context.Context, userID string, organizationID string, memberID string) error
+	GetOrganizationReservations(ctx context.Context, userID string, organizationID string, filter *dto2.ReservationQueryParam) (*dto2.BriefAdminReservationsResponse, int64, error)
+	GetOrganizationReservationByID(ctx context.Context, userID string, organizationID string, reservationID string) (*dto2.FullAdminReservationResponse, error)
+	CreateOrganizationReservation(ctx context.Context, userID string, organizationID string, reservation *dto2.AddReservartionRequest) (string, error)
+	CancelOrganizationReservation(ctx context.Context, userID string, organizationID string, reservationID string) error
+	UpdateOrganizationPolicy(ctx context.Context, userID string, organizationID string, policy *dto.UpdatePolicyRequest) error
+	GetPendingApprovals(ctx context.Context, userID string, organizationID string, filter *dto2.ReservationQueryParam) (*dto2.BriefAdminReservationsResponse, int64, error)
+	GetApprovalLogs(ctx context.Context, userID string, organizationID string, filter *dto.ApprovalLogQueryParam) (*dto.ApprovalLogsResponse, int64, error)
+	ApproveOrganizationReservation(ctx context.Context, userID string, organizationID string, reservationID string, decision *dto.ApprovalDecisionRequest) error
+	RejectOrganizationReservation(ctx context.Context, userID string, organizationID string, reservationID string, decision *dto.ApprovalDecisionRequest) error
+}
@@ -66,6 +66,7 @@ type PaymentDetailResponse struct {
 	EndDate   string                     `json:"endDate"`
 	Method    BriefPaymentMethodResponse `json:"method"`
 	Proof     string                     `json:"proof"`
+	Billing   BillingResponse            `json:"billing"`
 	CreatedAt string                     `json:"createdAt"`
 	UpdatedAt string                     `json:"updatedAt"`
 }
@@ -84,6 +85,7 @@ func NewPaymentDetailResponse(payment *entity.Transaction) *PaymentDetailRespons
 			AccountName:   payment.Payment.AccountName,
 		},
 		Proof:     payment.Proof.URL,
+		Billing:   *NewBillingResponse(&payment.Reservation),
 		CreatedAt: payment.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
 		UpdatedAt: payment.UpdatedAt.Format(constant.DATE_RESPONSE_FORMAT),
 	}
@@ -96,3 +98,36 @@ type BriefPaymentMethodResponse struct {
 	AccountNumber string `json:"accountNumber"`
 	AccountName   string `json:"accountName"`
 }
+
+type BillingResponse struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Address   string `json:"address"`
+	TaxNumber string `json:"taxNumber"`
+}
+
+// NewBillingResponse uses the organization billing details when the reservation is booked for an organization,
+// otherwise it falls back to the reservation company name and the tenant email
+func NewBillingResponse(reservation *entity.Reservation) *BillingResponse {
+	billing := &BillingResponse{
+		Name:  reservation.CompanyName,
+		Email: reservation.User.Email,
+	}
+
+	if reservation.OrganizationID == "" {
+		return billing
+	}
+
+	organization := reservation.Organization
+	billing.Name = organization.Name
+	if organization.BillingName != "" {
+		billing.Name = organization.BillingName
+	}
+	if organization.BillingEmail != "" {
+		billing.Email = organization.BillingEmail
+	}
+	billing.Address = organization.BillingAddress
+	billing.TaxNumber = organization.TaxNumber
+
+	return billing
+}
@@ -1,6 +1,7 @@
 package impl
 
 import (
+	"database/sql"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
 	"strings"
@@ -98,9 +99,11 @@ func (p *PaymentRepositoryImpl) GetReservationPaymentByID(ctx context.Context, r
 		return nil, err
 	}
 
-	query := squirrel.Select("t.id, t.reservation_id, t.payment_id, t.proof_id, t.created_at, t.updated_at, p.id, p.account_name, p.account_number, p.account_name, b.icon, b.name, r.amount, r.start_date, r.end_date, pp.id, pp.url").
+	query := squirrel.Select("t.id, t.reservation_id, t.payment_id, t.proof_id, t.created_at, t.updated_at, p.id, p.account_name, p.account_number, p.account_name, b.icon, b.name, r.amount, r.start_date, r.end_date, r.company_name, u.email, pp.id, pp.url, o.id, o.name, o.billing_name, o.billing_email, o.billing_address, o.tax_number").
 		From("transactions AS t").
 		Join("reservations r ON r.id = t.reservation_id").
+		LeftJoin("users u ON u.id = r.user_id").
+		LeftJoin("organizations o ON o.id = r.organization_id AND o.deleted_at IS NULL").
 		Join("payments p ON p.id = t.payment_id").
 		Join("banks b ON b.id = p.bank_id").
 		Join("payment_proofs pp ON pp.id = t.proof_id").
@@ -122,10 +125,25 @@ func (p *PaymentRepositoryImpl) GetReservationPaymentByID(ctx context.Context, r
 
 	if rows.Next() {
 		var tx entity.Transaction
-		err = rows.Scan(&tx.ID, &tx.ReservationID, &tx.PaymentID, &tx.ProofID, &tx.CreatedAt, &tx.UpdatedAt, &tx.Payment.ID, &tx.Payment.AccountName, &tx.Payment.AccountNumber, &tx.Payment.AccountName, &tx.Payment.Bank.Icon, &tx.Payment.Bank.Name, &tx.Reservation.Amount, &tx.Reservation.StartDate, &tx.Reservation.EndDate, &tx.Proof.ID, &tx.Proof.URL)
+		// the user is null when the account was deleted, the payment must still be found by an admin
+		var userEmail, organizationID, organizationName, billingName, billingEmail, billingAddress, taxNumber sql.NullString
+		err = rows.Scan(&tx.ID, &tx.ReservationID, &tx.PaymentID, &tx.ProofID, &tx.CreatedAt, &tx.UpdatedAt, &tx.Payment.ID, &tx.Payment.AccountName, &tx.Payment.AccountNumber, &tx.Payment.AccountName, &tx.Payment.Bank.Icon, &tx.Payment.Bank.Name, &tx.Reservation.Amount, &tx.Reservation.StartDate, &tx.Reservation.EndDate,
+			&tx.Reservation.CompanyName, &userEmail, &tx.Proof.ID, &tx.Proof.URL,
+			&organizationID, &organizationName, &billingName, &billingEmail, &billingAddress, &taxNumber)
 		if err != nil {
 			return nil, err
 		}
+
+		tx.Reservation.User.Email = userEmail.String
+		tx.Reservation.OrganizationID = organizationID.String
+		tx.Reservation.Organization = entity.Organization{
+			ID:             organizationID.String,
+			Name:           organizationName.String,
+			BillingName:    billingName.String,
+			BillingEmail:   billingEmail.String,
+			BillingAddress: billingAddress.String,
+			TaxNumber:      taxNumber.String,
+		}
 		return &tx, nil
 	}
 
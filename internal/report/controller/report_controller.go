@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"office-booking-backend/internal/report/dto"
+	"office-booking-backend/internal/report/service"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/spreadsheet"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sheet is a report that can be exported as a spreadsheet
+type sheet interface {
+	Sheet() [][]string
+}
+
+type ReportController struct {
+	service   service.ReportService
+	validator validator.Validator
+}
+
+func NewReportController(reportService service.ReportService, validator validator.Validator) *ReportController {
+	return &ReportController{
+		service:   reportService,
+		validator: validator,
+	}
+}
+
+// sendReport responds with the report as json, or as a spreadsheet attachment when a file format is requested
+func sendReport(c *fiber.Ctx, name string, format string, message string, report sheet) error {
+	if format == spreadsheet.CSV || format == spreadsheet.XLSX {
+		content, err := spreadsheet.Bytes(format, name, report.Sheet())
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		c.Attachment(name + "." + format)
+		c.Set(fiber.HeaderContentType, spreadsheet.ContentType(format))
+		return c.Status(fiber.StatusOK).Send(content)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: message,
+		Data:    report,
+	})
+}
+
+func reportError(err error) error {
+	if err == err2.ErrInvalidDateRange {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
+
+func (r *ReportController) GetOccupancyReport(c *fiber.Ctx) error {
+	filter := new(dto.ReportQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	report, err := r.service.GetOccupancyReport(c.Context(), filter)
+	if err != nil {
+		return reportError(err)
+	}
+
+	return sendReport(c, "occupancy", filter.Format, "occupancy report fetched successfully", report)
+}
+
+func (r *ReportController) GetRevenueReport(c *fiber.Ctx) error {
+	filter := new(dto.ReportQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	report, err := r.service.GetRevenueReport(c.Context(), filter)
+	if err != nil {
+		return reportError(err)
+	}
+
+	return sendReport(c, "revenue", filter.Format, "revenue report fetched successfully", report)
+}
+
+func (r *ReportController) GetLeaseReport(c *fiber.Ctx) error {
+	filter := new(dto.ReportQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	report, err := r.service.GetLeaseReport(c.Context(), filter)
+	if err != nil {
+		return reportError(err)
+	}
+
+	return sendReport(c, "leases", filter.Format, "lease report fetched successfully", report)
+}
+
+func (r *ReportController) GetRevenueForecast(c *fiber.Ctx) error {
+	filter := new(dto.ForecastQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	report, err := r.service.GetRevenueForecast(c.Context(), filter)
+	if err != nil {
+		return reportError(err)
+	}
+
+	return sendReport(c, "forecast", filter.Format, "revenue forecast fetched successfully", report)
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/custom"
+	err2 "office-booking-backend/pkg/errors"
+	"time"
+)
+
+const (
+	// defaultReportMonths is the period of the reports when no date range is given
+	defaultReportMonths = 12
+	// maxReportMonths keeps the monthly reports at a reasonable size
+	maxReportMonths = 36
+	// defaultForecastMonths is the number of months forecasted when not specified
+	defaultForecastMonths = 12
+)
+
+const (
+	GROUP_BY_BUILDING = "building"
+	GROUP_BY_CITY     = "city"
+)
+
+type ReportQueryParam struct {
+	StartDate custom.Date `query:"startDate"`
+	EndDate   custom.Date `query:"endDate"`
+	GroupBy   string      `query:"groupBy" validate:"omitempty,oneof=building city"`
+	Format    string      `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// SetDefault groups the report by building when not specified
+func (r *ReportQueryParam) SetDefault() {
+	if r.GroupBy == "" {
+		r.GroupBy = GROUP_BY_BUILDING
+	}
+}
+
+// DateRange returns the inclusive date range of the report, from the start of the month a year ago until today by default
+func (r *ReportQueryParam) DateRange() (time.Time, time.Time, error) {
+	endDate := r.EndDate.ToTime()
+	if endDate.IsZero() {
+		now := time.Now()
+		endDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	startDate := r.StartDate.ToTime()
+	if startDate.IsZero() {
+		startDate = time.Date(endDate.Year(), endDate.Month()-defaultReportMonths+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if endDate.Before(startDate) || startDate.AddDate(0, maxReportMonths, 0).Before(endDate) {
+		return time.Time{}, time.Time{}, err2.ErrInvalidDateRange
+	}
+
+	return startDate, endDate, nil
+}
+
+type ForecastQueryParam struct {
+	Months int `query:"months" validate:"omitempty,gte=1,lte=36"`
+	// IncludePending adds the pending reservations weighted by the historical acceptance rate as probable revenue
+	IncludePending bool   `query:"includePending"`
+	Format         string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// SetDefault forecasts a year when not specified
+func (f *ForecastQueryParam) SetDefault() {
+	if f.Months == 0 {
+		f.Months = defaultForecastMonths
+	}
+}
+
+// DateRange returns the inclusive date range of the forecast, from today until the end of the last month
+func (f *ForecastQueryParam) DateRange() (time.Time, time.Time) {
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(now.Year(), now.Month()+time.Month(f.Months), 0, 0, 0, 0, 0, time.UTC)
+	return startDate, endDate
+}
@@ -0,0 +1,184 @@
+package dto
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	monthFormat = "2006-01"
+)
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+type OccupancyRowResponse struct {
+	Month         string  `json:"month"`
+	GroupID       string  `json:"groupId"`
+	GroupName     string  `json:"groupName"`
+	BookedDays    int     `json:"bookedDays"`
+	AvailableDays int     `json:"availableDays"`
+	Occupancy     float64 `json:"occupancy"`
+}
+
+// NewOccupancyRowResponse returns the occupancy percentage of the booked days over the available days
+func NewOccupancyRowResponse(month time.Time, groupID string, groupName string, bookedDays int, availableDays int) OccupancyRowResponse {
+	occupancy := 0.0
+	if availableDays > 0 {
+		occupancy = round(float64(bookedDays) / float64(availableDays) * 100)
+	}
+
+	return OccupancyRowResponse{
+		Month:         month.Format(monthFormat),
+		GroupID:       groupID,
+		GroupName:     groupName,
+		BookedDays:    bookedDays,
+		AvailableDays: availableDays,
+		Occupancy:     occupancy,
+	}
+}
+
+type OccupancyReportResponse struct {
+	StartDate string                 `json:"startDate"`
+	EndDate   string                 `json:"endDate"`
+	GroupBy   string                 `json:"groupBy"`
+	Rows      []OccupancyRowResponse `json:"rows"`
+}
+
+func (o *OccupancyReportResponse) Sheet() [][]string {
+	sheet := [][]string{{"month", "groupId", "groupName", "bookedDays", "availableDays", "occupancy"}}
+	for _, row := range o.Rows {
+		sheet = append(sheet, []string{
+			row.Month,
+			row.GroupID,
+			row.GroupName,
+			strconv.Itoa(row.BookedDays),
+			strconv.Itoa(row.AvailableDays),
+			formatFloat(row.Occupancy),
+		})
+	}
+	return sheet
+}
+
+type RevenueRowResponse struct {
+	Month        string `json:"month"`
+	GroupID      string `json:"groupId"`
+	GroupName    string `json:"groupName"`
+	Reservations int64  `json:"reservations"`
+	Revenue      int64  `json:"revenue"`
+}
+
+type RevenueReportResponse struct {
+	StartDate string               `json:"startDate"`
+	EndDate   string               `json:"endDate"`
+	GroupBy   string               `json:"groupBy"`
+	Total     int64                `json:"total"`
+	Rows      []RevenueRowResponse `json:"rows"`
+}
+
+func (r *RevenueReportResponse) Sheet() [][]string {
+	sheet := [][]string{{"month", "groupId", "groupName", "reservations", "revenue"}}
+	for _, row := range r.Rows {
+		sheet = append(sheet, []string{
+			row.Month,
+			row.GroupID,
+			row.GroupName,
+			strconv.FormatInt(row.Reservations, 10),
+			strconv.FormatInt(row.Revenue, 10),
+		})
+	}
+	return sheet
+}
+
+type LeaseRowResponse struct {
+	GroupID   string `json:"groupId"`
+	GroupName string `json:"groupName"`
+	// Leases is the number of paid reservations starting in the period
+	Leases             int     `json:"leases"`
+	AverageLeaseMonths float64 `json:"averageLeaseMonths"`
+	// Ended is the number of paid reservations ending in the period, Renewed of them were followed by another lease
+	Ended       int     `json:"ended"`
+	Renewed     int     `json:"renewed"`
+	RenewalRate float64 `json:"renewalRate"`
+}
+
+// NewLeaseRowResponse returns the average lease length and the renewal percentage of a group
+func NewLeaseRowResponse(groupID string, groupName string, leases int, leaseMonths int, ended int, renewed int) LeaseRowResponse {
+	row := LeaseRowResponse{
+		GroupID:   groupID,
+		GroupName: groupName,
+		Leases:    leases,
+		Ended:     ended,
+		Renewed:   renewed,
+	}
+
+	if leases > 0 {
+		row.AverageLeaseMonths = round(float64(leaseMonths) / float64(leases))
+	}
+
+	if ended > 0 {
+		row.RenewalRate = round(float64(renewed) / float64(ended) * 100)
+	}
+
+	return row
+}
+
+type LeaseReportResponse struct {
+	StartDate string             `json:"startDate"`
+	EndDate   string             `json:"endDate"`
+	GroupBy   string             `json:"groupBy"`
+	Rows      []LeaseRowResponse `json:"rows"`
+}
+
+func (l *LeaseReportResponse) Sheet() [][]string {
+	sheet := [][]string{{"groupId", "groupName", "leases", "averageLeaseMonths", "ended", "renewed", "renewalRate"}}
+	for _, row := range l.Rows {
+		sheet = append(sheet, []string{
+			row.GroupID,
+			row.GroupName,
+			strconv.Itoa(row.Leases),
+			formatFloat(row.AverageLeaseMonths),
+			strconv.Itoa(row.Ended),
+			strconv.Itoa(row.Renewed),
+			formatFloat(row.RenewalRate),
+		})
+	}
+	return sheet
+}
+
+type ForecastRowResponse struct {
+	Month     string `json:"month"`
+	Confirmed int64  `json:"confirmed"`
+	Probable  int64  `json:"probable"`
+	Total     int64  `json:"total"`
+}
+
+type ForecastReportResponse struct {
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	// AcceptanceRate is the percentage of the decided pending reservations that were accepted, used to weight the probable revenue
+	AcceptanceRate float64               `json:"acceptanceRate"`
+	Confirmed      int64                 `json:"confirmed"`
+	Probable       int64                 `json:"probable"`
+	Total          int64                 `json:"total"`
+	Rows           []ForecastRowResponse `json:"rows"`
+}
+
+func (f *ForecastReportResponse) Sheet() [][]string {
+	sheet := [][]string{{"month", "confirmed", "probable", "total"}}
+	for _, row := range f.Rows {
+		sheet = append(sheet, []string{
+			row.Month,
+			strconv.FormatInt(row.Confirmed, 10),
+			strconv.FormatInt(row.Probable, 10),
+			strconv.FormatInt(row.Total, 10),
+		})
+	}
+	return sheet
+}
@@ -0,0 +1,115 @@
+package impl
+
+import (
+	"context"
+	"office-booking-backend/internal/report/dto"
+	"office-booking-backend/internal/report/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// paidStatus are the reservations that count as leased
+var paidStatus = []int{constant.ACTIVE_STATUS, constant.COMPLETED_STATUS}
+
+type ReportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportRepositoryImpl(db *gorm.DB) repository.ReportRepository {
+	return &ReportRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetReportBuildings returns the published buildings, the unpublished ones and the reserved ids of new buildings
+// can't be leased
+func (r *ReportRepositoryImpl) GetReportBuildings(ctx context.Context) (*entity.Buildings, error) {
+	buildings := new(entity.Buildings)
+	err := r.db.WithContext(ctx).
+		Select("`buildings`.`id`, `buildings`.`name`, `buildings`.`city_id`, `buildings`.`published_at`").
+		Joins("City").
+		Where("`buildings`.`is_published` = ?", true).
+		Order("`buildings`.`name` ASC").
+		Find(buildings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return buildings, nil
+}
+
+// GetPaidReservations returns the paid reservations that overlap the date range
+func (r *ReportRepositoryImpl) GetPaidReservations(ctx context.Context, startDate time.Time, endDate time.Time) (*entity.Reservations, error) {
+	reservations := new(entity.Reservations)
+	err := r.db.WithContext(ctx).
+		Select("id, building_id, user_id, organization_id, start_date, end_date, amount").
+		Where("status_id IN ?", paidStatus).
+		Where("start_date <= ? AND end_date >= ?", endDate.Format("2006-01-02"), startDate.Format("2006-01-02")).
+		Order("start_date ASC").
+		Find(reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// GetRevenueStats sums the paid reservations by the month they were booked in, like the revenue stat does
+func (r *ReportRepositoryImpl) GetRevenueStats(ctx context.Context, startDate time.Time, endDate time.Time, groupBy string) (*entity.RevenueStats, error) {
+	group := "buildings.id AS group_id, buildings.name AS group_name"
+	if groupBy == dto.GROUP_BY_CITY {
+		group = "COALESCE(cities.id, '') AS group_id, COALESCE(cities.name, '') AS group_name"
+	}
+
+	stats := new(entity.RevenueStats)
+	err := r.db.WithContext(ctx).
+		Model(&entity.Reservation{}).
+		Select("DATE_FORMAT(reservations.created_at, '%Y-%m') AS period, "+group+", COUNT(reservations.id) AS count, SUM(reservations.amount) AS total").
+		Joins("JOIN buildings ON buildings.id = reservations.building_id").
+		Joins("LEFT JOIN cities ON cities.id = buildings.city_id").
+		Where("reservations.status_id IN ?", paidStatus).
+		Where("DATE(reservations.created_at) BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Group("period, group_id, group_name").
+		Order("period ASC, total DESC").
+		Scan(stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetForecastReservations returns the reservations with the statuses that haven't ended before the start date
+func (r *ReportRepositoryImpl) GetForecastReservations(ctx context.Context, startDate time.Time, statuses []int) (*entity.Reservations, error) {
+	reservations := new(entity.Reservations)
+	err := r.db.WithContext(ctx).
+		Select("id, building_id, start_date, end_date, amount, status_id").
+		Where("status_id IN ?", statuses).
+		Where("end_date > ?", startDate.Format("2006-01-02")).
+		Order("start_date ASC").
+		Find(reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// GetStatusCounts counts the reservations made since the given time by status
+func (r *ReportRepositoryImpl) GetStatusCounts(ctx context.Context, since time.Time) (*entity.StatusesStat, error) {
+	stats := new(entity.StatusesStat)
+	err := r.db.WithContext(ctx).
+		Model(&entity.Reservation{}).
+		Select("status_id, COUNT(*) AS total").
+		Where("created_at >= ?", since).
+		Group("status_id").
+		Scan(stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
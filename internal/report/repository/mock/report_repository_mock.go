@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+	"office-booking-backend/pkg/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ReportRepositoryMock) GetReportBuildings(ctx context.Context) (*entity.Buildings, error) {
+	args := r.Called(ctx)
+	return args.Get(0).(*entity.Buildings), args.Error(1)
+}
+
+func (r *ReportRepositoryMock) GetPaidReservations(ctx context.Context, startDate time.Time, endDate time.Time) (*entity.Reservations, error) {
+	args := r.Called(ctx, startDate, endDate)
+	return args.Get(0).(*entity.Reservations), args.Error(1)
+}
+
+func (r *ReportRepositoryMock) GetRevenueStats(ctx context.Context, startDate time.Time, endDate time.Time, groupBy string) (*entity.RevenueStats, error) {
+	args := r.Called(ctx, startDate, endDate, groupBy)
+	return args.Get(0).(*entity.RevenueStats), args.Error(1)
+}
+
+func (r *ReportRepositoryMock) GetForecastReservations(ctx context.Context, startDate time.Time, statuses []int) (*entity.Reservations, error) {
+	args := r.Called(ctx, startDate, statuses)
+	return args.Get(0).(*entity.Reservations), args.Error(1)
+}
+
+func (r *ReportRepositoryMock) GetStatusCounts(ctx context.Context, since time.Time) (*entity.StatusesStat, error) {
+	args := r.Called(ctx, since)
+	return args.Get(0).(*entity.StatusesStat), args.Error(1)
+}
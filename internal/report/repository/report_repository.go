@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"office-booking-backend/pkg/entity"
+	"time"
+)
+
+type ReportRepository interface {
+	GetReportBuildings(ctx context.Context) (*entity.Buildings, error)
+	GetPaidReservations(ctx context.Context, startDate time.Time, endDate time.Time) (*entity.Reservations, error)
+	GetRevenueStats(ctx context.Context, startDate time.Time, endDate time.Time, groupBy string) (*entity.RevenueStats, error)
+	GetForecastReservations(ctx context.Context, startDate time.Time, statuses []int) (*entity.Reservations, error)
+	GetStatusCounts(ctx context.Context, since time.Time) (*entity.StatusesStat, error)
+}
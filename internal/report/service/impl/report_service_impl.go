@@ -0,0 +1,449 @@
+package impl
+
+import (
+	"context"
+	"log"
+	"math"
+	"office-booking-backend/internal/report/dto"
+	"office-booking-backend/internal/report/repository"
+	"office-booking-backend/internal/report/service"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// defaultRenewalWindow is how long after the end of a lease the next one still counts as a renewal
+	defaultRenewalWindow = 30 * 24 * time.Hour
+	// defaultAcceptanceLookback is the history used for the acceptance rate of the pending reservations
+	defaultAcceptanceLookback = 365 * 24 * time.Hour
+)
+
+var (
+	// confirmedStatus are the accepted reservations of the forecast, awaiting payment ones are counted in full
+	confirmedStatus = []int{constant.ACTIVE_STATUS, constant.AWAITING_PAYMENT_STATUS}
+	// pendingStatus are the reservations waiting for the approval of an admin or an organization
+	pendingStatus = []int{constant.PENDING_STATUS, constant.PENDING_APPROVAL_STATUS}
+)
+
+type ReportServiceImpl struct {
+	repo   repository.ReportRepository
+	config *viper.Viper
+}
+
+func NewReportServiceImpl(repo repository.ReportRepository, config *viper.Viper) service.ReportService {
+	return &ReportServiceImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// reportGroup is the building or city a report row belongs to
+type reportGroup struct {
+	id   string
+	name string
+}
+
+func groupOf(building *entity.Building, groupBy string) reportGroup {
+	if groupBy == dto.GROUP_BY_CITY {
+		if building.CityID == 0 {
+			return reportGroup{}
+		}
+		return reportGroup{id: strconv.Itoa(building.CityID), name: building.City.Name}
+	}
+	return reportGroup{id: building.ID, name: building.Name}
+}
+
+// groupsOf returns the groups in the order of the buildings
+func groupsOf(buildings *entity.Buildings, groupBy string) []reportGroup {
+	seen := make(map[reportGroup]bool)
+	groups := make([]reportGroup, 0)
+	for i := range *buildings {
+		group := groupOf(&(*buildings)[i], groupBy)
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// toDate drops the time of day, so the days can be counted without timezone surprises
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// overlapDays counts the days of a lease, which ends exclusively, between two dates inclusive
+func overlapDays(start time.Time, end time.Time, from time.Time, to time.Time) int {
+	start, end = toDate(start), toDate(end)
+	if start.Before(from) {
+		start = from
+	}
+
+	if to = to.AddDate(0, 0, 1); end.After(to) {
+		end = to
+	}
+
+	if !end.After(start) {
+		return 0
+	}
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// leaseMonths returns the length of a lease in whole months
+func leaseMonths(reservation *entity.Reservation) int {
+	start, end := toDate(reservation.StartDate), toDate(reservation.EndDate)
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	return months
+}
+
+// isRenewed reports whether the same tenant leased the building again within the window after the lease ended
+func isRenewed(lease *entity.Reservation, reservations []*entity.Reservation, window time.Duration) bool {
+	for _, next := range reservations {
+		if next.ID == lease.ID || !next.StartDate.After(lease.StartDate) || next.StartDate.After(lease.EndDate.Add(window)) {
+			continue
+		}
+
+		sameUser := lease.UserID != "" && next.UserID == lease.UserID
+		sameOrganization := lease.OrganizationID != "" && next.OrganizationID == lease.OrganizationID
+		if sameUser || sameOrganization {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReportServiceImpl) loadReportData(ctx context.Context, startDate time.Time, endDate time.Time) (*entity.Buildings, *entity.Reservations, error) {
+	var buildings *entity.Buildings
+	var reservations *entity.Reservations
+
+	errGroup, c := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		b, err := r.repo.GetReportBuildings(c)
+		if err != nil {
+			log.Println("error when getting report buildings: ", err)
+			return err
+		}
+		buildings = b
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		res, err := r.repo.GetPaidReservations(c, startDate, endDate)
+		if err != nil {
+			log.Println("error when getting paid reservations: ", err)
+			return err
+		}
+		reservations = res
+		return nil
+	})
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return buildings, reservations, nil
+}
+
+func reservationsByBuilding(reservations *entity.Reservations) map[string][]*entity.Reservation {
+	byBuilding := make(map[string][]*entity.Reservation)
+	for i := range *reservations {
+		reservation := &(*reservations)[i]
+		byBuilding[reservation.BuildingID] = append(byBuilding[reservation.BuildingID], reservation)
+	}
+	return byBuilding
+}
+
+// GetOccupancyReport returns the booked days over the available days of every month, a building is available
+// from the day it was first published
+func (r *ReportServiceImpl) GetOccupancyReport(ctx context.Context, filter *dto.ReportQueryParam) (*dto.OccupancyReportResponse, error) {
+	filter.SetDefault()
+	startDate, endDate, err := filter.DateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	buildings, reservations, err := r.loadReportData(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byBuilding := reservationsByBuilding(reservations)
+	groups := groupsOf(buildings, filter.GroupBy)
+
+	rows := make([]dto.OccupancyRowResponse, 0)
+	for month := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(endDate); month = month.AddDate(0, 1, 0) {
+		from, to := month, month.AddDate(0, 1, -1)
+		if from.Before(startDate) {
+			from = startDate
+		}
+		if to.After(endDate) {
+			to = endDate
+		}
+
+		booked := make(map[reportGroup]int)
+		available := make(map[reportGroup]int)
+		for i := range *buildings {
+			building := &(*buildings)[i]
+			buildingFrom := from
+			if publishedAt := toDate(building.PublishedAt.Time); publishedAt.After(buildingFrom) {
+				buildingFrom = publishedAt
+			}
+
+			if buildingFrom.After(to) {
+				continue
+			}
+
+			availableDays := int(to.Sub(buildingFrom).Hours()/24) + 1
+			bookedDays := 0
+			for _, reservation := range byBuilding[building.ID] {
+				bookedDays += overlapDays(reservation.StartDate, reservation.EndDate, buildingFrom, to)
+			}
+
+			if bookedDays > availableDays {
+				bookedDays = availableDays
+			}
+
+			group := groupOf(building, filter.GroupBy)
+			booked[group] += bookedDays
+			available[group] += availableDays
+		}
+
+		for _, group := range groups {
+			if _, ok := available[group]; ok {
+				rows = append(rows, dto.NewOccupancyRowResponse(month, group.id, group.name, booked[group], available[group]))
+			}
+		}
+	}
+
+	return &dto.OccupancyReportResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		GroupBy:   filter.GroupBy,
+		Rows:      rows,
+	}, nil
+}
+
+func (r *ReportServiceImpl) GetRevenueReport(ctx context.Context, filter *dto.ReportQueryParam) (*dto.RevenueReportResponse, error) {
+	filter.SetDefault()
+	startDate, endDate, err := filter.DateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := r.repo.GetRevenueStats(ctx, startDate, endDate, filter.GroupBy)
+	if err != nil {
+		log.Println("error when getting revenue stats: ", err)
+		return nil, err
+	}
+
+	report := &dto.RevenueReportResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		GroupBy:   filter.GroupBy,
+		Rows:      make([]dto.RevenueRowResponse, 0, len(*stats)),
+	}
+
+	for _, stat := range *stats {
+		report.Total += stat.Total
+		report.Rows = append(report.Rows, dto.RevenueRowResponse{
+			Month:        stat.Period,
+			GroupID:      stat.GroupID,
+			GroupName:    stat.GroupName,
+			Reservations: stat.Count,
+			Revenue:      stat.Total,
+		})
+	}
+
+	return report, nil
+}
+
+// GetLeaseReport returns the average length of the leases starting in the period and the share of the leases
+// ending in the period that the same user or organization renewed
+func (r *ReportServiceImpl) GetLeaseReport(ctx context.Context, filter *dto.ReportQueryParam) (*dto.LeaseReportResponse, error) {
+	filter.SetDefault()
+	startDate, endDate, err := filter.DateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	window := r.config.GetDuration("report.renewalWindow")
+	if window <= 0 {
+		window = defaultRenewalWindow
+	}
+
+	// the renewals of the leases ending at the end of the period start after it
+	buildings, reservations, err := r.loadReportData(ctx, startDate, endDate.Add(window))
+	if err != nil {
+		return nil, err
+	}
+
+	// a lease ending later today or in the future can't be renewed yet
+	endedUntil := endDate
+	if today := toDate(time.Now()); today.Before(endedUntil) {
+		endedUntil = today
+	}
+
+	byBuilding := reservationsByBuilding(reservations)
+	leases := make(map[reportGroup]int)
+	months := make(map[reportGroup]int)
+	ended := make(map[reportGroup]int)
+	renewed := make(map[reportGroup]int)
+	for i := range *buildings {
+		building := &(*buildings)[i]
+		group := groupOf(building, filter.GroupBy)
+		for _, reservation := range byBuilding[building.ID] {
+			if start := toDate(reservation.StartDate); !start.Before(startDate) && !start.After(endDate) {
+				leases[group]++
+				months[group] += leaseMonths(reservation)
+			}
+
+			if end := toDate(reservation.EndDate); !end.Before(startDate) && !end.After(endedUntil) {
+				ended[group]++
+				if isRenewed(reservation, byBuilding[building.ID], window) {
+					renewed[group]++
+				}
+			}
+		}
+	}
+
+	rows := make([]dto.LeaseRowResponse, 0)
+	for _, group := range groupsOf(buildings, filter.GroupBy) {
+		rows = append(rows, dto.NewLeaseRowResponse(group.id, group.name, leases[group], months[group], ended[group], renewed[group]))
+	}
+
+	return &dto.LeaseReportResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		GroupBy:   filter.GroupBy,
+		Rows:      rows,
+	}, nil
+}
+
+// recognizedAmount spreads the amount of a lease evenly over its days and returns the part of the days
+// between two dates inclusive. Reservations are paid upfront so there is no installment schedule to follow
+func recognizedAmount(reservation *entity.Reservation, from time.Time, to time.Time) float64 {
+	leaseDays := int(toDate(reservation.EndDate).Sub(toDate(reservation.StartDate)).Hours() / 24)
+	if leaseDays <= 0 {
+		return 0
+	}
+
+	days := overlapDays(reservation.StartDate, reservation.EndDate, from, to)
+	return float64(reservation.Amount) * float64(days) / float64(leaseDays)
+}
+
+// acceptanceRate returns the share of the reservations decided by an admin that were accepted
+func (r *ReportServiceImpl) acceptanceRate(ctx context.Context) (float64, error) {
+	lookback := r.config.GetDuration("report.acceptanceLookback")
+	if lookback <= 0 {
+		lookback = defaultAcceptanceLookback
+	}
+
+	stats, err := r.repo.GetStatusCounts(ctx, time.Now().Add(-lookback))
+	if err != nil {
+		return 0, err
+	}
+
+	var accepted, decided int64
+	for _, stat := range *stats {
+		switch stat.StatusID {
+		case constant.AWAITING_PAYMENT_STATUS, constant.ACTIVE_STATUS, constant.COMPLETED_STATUS:
+			accepted += stat.Total
+			decided += stat.Total
+		case constant.REJECTED_STATUS:
+			decided += stat.Total
+		}
+	}
+
+	if decided == 0 {
+		return 0, nil
+	}
+	return float64(accepted) / float64(decided), nil
+}
+
+// GetRevenueForecast projects the revenue recognized every month from the accepted reservations, and from the pending
+// ones weighted by the acceptance rate when requested
+func (r *ReportServiceImpl) GetRevenueForecast(ctx context.Context, filter *dto.ForecastQueryParam) (*dto.ForecastReportResponse, error) {
+	filter.SetDefault()
+	startDate, endDate := filter.DateRange()
+
+	statuses := confirmedStatus
+	if filter.IncludePending {
+		statuses = append(append([]int{}, confirmedStatus...), pendingStatus...)
+	}
+
+	var reservations *entity.Reservations
+	rate := 0.0
+
+	errGroup, c := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		res, err := r.repo.GetForecastReservations(c, startDate, statuses)
+		if err != nil {
+			log.Println("error when getting forecast reservations: ", err)
+			return err
+		}
+		reservations = res
+		return nil
+	})
+
+	if filter.IncludePending {
+		errGroup.Go(func() error {
+			var err error
+			rate, err = r.acceptanceRate(c)
+			if err != nil {
+				log.Println("error when getting reservation acceptance rate: ", err)
+			}
+			return err
+		})
+	}
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := &dto.ForecastReportResponse{
+		StartDate:      startDate.Format("2006-01-02"),
+		EndDate:        endDate.Format("2006-01-02"),
+		AcceptanceRate: math.Round(rate*10000) / 100,
+		Rows:           make([]dto.ForecastRowResponse, 0, filter.Months),
+	}
+
+	for month := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(endDate); month = month.AddDate(0, 1, 0) {
+		from, to := month, month.AddDate(0, 1, -1)
+		if from.Before(startDate) {
+			from = startDate
+		}
+
+		var confirmed, probable float64
+		for i := range *reservations {
+			reservation := &(*reservations)[i]
+			amount := recognizedAmount(reservation, from, to)
+			if reservation.StatusID == constant.PENDING_STATUS || reservation.StatusID == constant.PENDING_APPROVAL_STATUS {
+				probable += amount * rate
+			} else {
+				confirmed += amount
+			}
+		}
+
+		row := dto.ForecastRowResponse{
+			Month:     month.Format("2006-01"),
+			Confirmed: int64(math.Round(confirmed)),
+			Probable:  int64(math.Round(probable)),
+		}
+		row.Total = row.Confirmed + row.Probable
+
+		report.Confirmed += row.Confirmed
+		report.Probable += row.Probable
+		report.Total += row.Total
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,176 @@
+package impl
+
+import (
+	"context"
+	"office-booking-backend/internal/report/dto"
+	mockRepo "office-booking-backend/internal/report/repository/mock"
+	"office-booking-backend/internal/report/service"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteReportService struct {
+	suite.Suite
+	mockRepo      *mockRepo.ReportRepositoryMock
+	reportService service.ReportService
+}
+
+func (s *TestSuiteReportService) SetupTest() {
+	s.mockRepo = new(mockRepo.ReportRepositoryMock)
+	s.reportService = NewReportServiceImpl(s.mockRepo, viper.New())
+}
+
+func (s *TestSuiteReportService) TearDownTest() {
+	s.mockRepo = nil
+	s.reportService = nil
+}
+
+func TestReportService(t *testing.T) {
+	suite.Run(t, new(TestSuiteReportService))
+}
+
+func date(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func (s *TestSuiteReportService) TestOverlapDays() {
+	for _, tc := range []struct {
+		Name     string
+		Start    string
+		End      string
+		Expected int
+	}{
+		{Name: "Inside The Range", Start: "2023-01-10", End: "2023-01-20", Expected: 10},
+		{Name: "Starts Before The Range", Start: "2022-12-25", End: "2023-01-05", Expected: 4},
+		{Name: "Ends After The Range", Start: "2023-01-25", End: "2023-02-10", Expected: 7},
+		{Name: "Covers The Range", Start: "2022-12-01", End: "2023-03-01", Expected: 31},
+		{Name: "Ends On The First Day", Start: "2022-12-20", End: "2023-01-01", Expected: 0},
+		{Name: "After The Range", Start: "2023-02-01", End: "2023-02-10", Expected: 0},
+	} {
+		s.Run(tc.Name, func() {
+			days := overlapDays(date(tc.Start), date(tc.End), date("2023-01-01"), date("2023-01-31"))
+			s.Equal(tc.Expected, days)
+		})
+	}
+}
+
+func (s *TestSuiteReportService) TestLeaseMonths() {
+	for _, tc := range []struct {
+		Name     string
+		Start    string
+		End      string
+		Expected int
+	}{
+		{Name: "Whole Months", Start: "2023-01-15", End: "2023-04-15", Expected: 3},
+		{Name: "A Year", Start: "2023-01-15", End: "2024-01-15", Expected: 12},
+		{Name: "Short Of A Month", Start: "2023-01-15", End: "2023-03-14", Expected: 1},
+		{Name: "Shorter Month", Start: "2023-01-31", End: "2023-02-28", Expected: 0},
+	} {
+		s.Run(tc.Name, func() {
+			months := leaseMonths(&entity.Reservation{StartDate: date(tc.Start), EndDate: date(tc.End)})
+			s.Equal(tc.Expected, months)
+		})
+	}
+}
+
+func (s *TestSuiteReportService) TestIsRenewed() {
+	window := 30 * 24 * time.Hour
+	lease := &entity.Reservation{ID: "lease", UserID: "user", OrganizationID: "organization", StartDate: date("2023-01-01"), EndDate: date("2023-02-01")}
+
+	for _, tc := range []struct {
+		Name     string
+		Next     *entity.Reservation
+		Expected bool
+	}{
+		{Name: "Same User Within The Window", Next: &entity.Reservation{ID: "next", UserID: "user", StartDate: date("2023-02-10")}, Expected: true},
+		{Name: "Same Organization", Next: &entity.Reservation{ID: "next", UserID: "member", OrganizationID: "organization", StartDate: date("2023-02-01")}, Expected: true},
+		{Name: "After The Window", Next: &entity.Reservation{ID: "next", UserID: "user", StartDate: date("2023-03-10")}, Expected: false},
+		{Name: "Before The Lease", Next: &entity.Reservation{ID: "next", UserID: "user", StartDate: date("2022-12-01")}, Expected: false},
+		{Name: "Another Tenant", Next: &entity.Reservation{ID: "next", UserID: "other", StartDate: date("2023-02-10")}, Expected: false},
+		{Name: "The Lease Itself", Next: lease, Expected: false},
+	} {
+		s.Run(tc.Name, func() {
+			s.Equal(tc.Expected, isRenewed(lease, []*entity.Reservation{tc.Next}, window))
+		})
+	}
+}
+
+// forecastMonths returns today and the first days of the next two months, the forecast starts today
+func forecastMonths() (time.Time, time.Time, time.Time) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return today, nextMonth, nextMonth.AddDate(0, 1, 0)
+}
+
+func lease(statusID int, start time.Time, end time.Time, amount int) entity.Reservation {
+	return entity.Reservation{StatusID: statusID, StartDate: start, EndDate: end, Amount: amount}
+}
+
+func (s *TestSuiteReportService) TestGetRevenueForecast_ProratesByMonth() {
+	today, nextMonth, monthAfter := forecastMonths()
+	daysLeft := int(nextMonth.Sub(today).Hours() / 24)
+	s.mockRepo.On("GetForecastReservations", mock.Anything, today, confirmedStatus).Return(&entity.Reservations{
+		// 100 a day from today until the 10th day of next month
+		lease(constant.ACTIVE_STATUS, today, nextMonth.AddDate(0, 0, 10), (daysLeft+10)*100),
+		// 100 a day, half of it after the forecast
+		lease(constant.ACTIVE_STATUS, monthAfter.AddDate(0, 0, -5), monthAfter.AddDate(0, 0, 5), 1000),
+	}, nil)
+
+	report, err := s.reportService.GetRevenueForecast(context.Background(), &dto.ForecastQueryParam{Months: 2})
+	s.NoError(err)
+	s.Len(report.Rows, 2)
+	s.Equal(int64(daysLeft*100), report.Rows[0].Confirmed)
+	s.Equal(int64(1500), report.Rows[1].Confirmed)
+	s.Equal(int64(daysLeft*100+1500), report.Confirmed)
+	s.Equal(int64(0), report.Probable)
+	s.mockRepo.AssertNotCalled(s.T(), "GetStatusCounts", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReportService) TestGetRevenueForecast_WeightsPending() {
+	today, nextMonth, _ := forecastMonths()
+	statuses := []int{constant.ACTIVE_STATUS, constant.AWAITING_PAYMENT_STATUS, constant.PENDING_STATUS, constant.PENDING_APPROVAL_STATUS}
+	s.mockRepo.On("GetForecastReservations", mock.Anything, today, statuses).Return(&entity.Reservations{
+		lease(constant.ACTIVE_STATUS, nextMonth, nextMonth.AddDate(0, 0, 10), 1000),
+		lease(constant.AWAITING_PAYMENT_STATUS, nextMonth, nextMonth.AddDate(0, 0, 10), 1000),
+		lease(constant.PENDING_STATUS, nextMonth, nextMonth.AddDate(0, 0, 20), 2000),
+		lease(constant.PENDING_APPROVAL_STATUS, nextMonth.AddDate(0, 0, 10), nextMonth.AddDate(0, 0, 20), 400),
+	}, nil)
+	s.mockRepo.On("GetStatusCounts", mock.Anything, mock.Anything).Return(&entity.StatusesStat{
+		{StatusID: constant.COMPLETED_STATUS, Total: 2},
+		{StatusID: constant.ACTIVE_STATUS, Total: 1},
+		{StatusID: constant.REJECTED_STATUS, Total: 1},
+		{StatusID: constant.CANCELED_STATUS, Total: 4},
+	}, nil)
+
+	report, err := s.reportService.GetRevenueForecast(context.Background(), &dto.ForecastQueryParam{Months: 2, IncludePending: true})
+	s.NoError(err)
+	s.Equal(75.0, report.AcceptanceRate)
+	s.Equal(int64(0), report.Rows[0].Total)
+	s.Equal(int64(2000), report.Rows[1].Confirmed)
+	s.Equal(int64(1800), report.Rows[1].Probable)
+	s.Equal(int64(3800), report.Total)
+}
+
+func (s *TestSuiteReportService) TestGetRevenueForecast_NoDecidedHistory() {
+	today, nextMonth, _ := forecastMonths()
+	s.mockRepo.On("GetForecastReservations", mock.Anything, today, mock.Anything).Return(&entity.Reservations{
+		lease(constant.ACTIVE_STATUS, nextMonth, nextMonth.AddDate(0, 0, 10), 1000),
+		lease(constant.PENDING_STATUS, nextMonth, nextMonth.AddDate(0, 0, 10), 1000),
+	}, nil)
+	s.mockRepo.On("GetStatusCounts", mock.Anything, mock.Anything).Return(&entity.StatusesStat{}, nil)
+
+	report, err := s.reportService.GetRevenueForecast(context.Background(), &dto.ForecastQueryParam{Months: 2, IncludePending: true})
+	s.NoError(err)
+	s.Equal(0.0, report.AcceptanceRate)
+	s.Equal(int64(1000), report.Confirmed)
+	s.Equal(int64(0), report.Probable)
+	s.Equal(int64(1000), report.Total)
+}
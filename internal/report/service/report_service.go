@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"office-booking-backend/internal/report/dto"
+)
+
+type ReportService interface {
+	GetOccupancyReport(ctx context.Context, filter *dto.ReportQueryParam) (*dto.OccupancyReportResponse, error)
+	GetRevenueReport(ctx context.Context, filter *dto.ReportQueryParam) (*dto.RevenueReportResponse, error)
+	GetLeaseReport(ctx context.Context, filter *dto.ReportQueryParam) (*dto.LeaseReportResponse, error)
+	GetRevenueForecast(ctx context.Context, filter *dto.ForecastQueryParam) (*dto.ForecastReportResponse, error)
+}
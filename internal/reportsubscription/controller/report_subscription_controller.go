@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"office-booking-backend/internal/reportsubscription/dto"
+	"office-booking-backend/internal/reportsubscription/service"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/confirm"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type ReportSubscriptionController struct {
+	service   service.ReportSubscriptionService
+	validator validator.Validator
+}
+
+func NewReportSubscriptionController(reportSubscriptionService service.ReportSubscriptionService, validator validator.Validator) *ReportSubscriptionController {
+	return &ReportSubscriptionController{
+		service:   reportSubscriptionService,
+		validator: validator,
+	}
+}
+
+func (r *ReportSubscriptionController) GetUserReportSubscriptions(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	subscriptions, err := r.service.GetUserReportSubscriptions(c.Context(), userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "report subscriptions fetched successfully",
+		Data:    subscriptions,
+	})
+}
+
+func (r *ReportSubscriptionController) GetReportSubscriptionByID(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	subscriptionID := c.Params("subscriptionID")
+	subscription, err := r.service.GetReportSubscriptionByID(c.Context(), userID, subscriptionID)
+	if err != nil {
+		if err == err2.ErrReportSubscriptionNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "report subscription fetched successfully",
+		Data:    subscription,
+	})
+}
+
+func (r *ReportSubscriptionController) CreateReportSubscription(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	subscription := new(dto.AddReportSubscriptionRequest)
+	if err := c.BodyParser(subscription); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*subscription); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	subscriptionID, err := r.service.CreateReportSubscription(c.Context(), userID, subscription)
+	if err != nil {
+		if err == err2.ErrUserNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "report subscription created successfully",
+		Data: fiber.Map{
+			"subscriptionId": subscriptionID,
+		},
+	})
+}
+
+func (r *ReportSubscriptionController) UpdateReportSubscription(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	subscriptionID := c.Params("subscriptionID")
+
+	subscription := new(dto.UpdateReportSubscriptionRequest)
+	if err := c.BodyParser(subscription); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*subscription); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := r.service.UpdateReportSubscription(c.Context(), userID, subscriptionID, subscription)
+	if err != nil {
+		if err == err2.ErrReportSubscriptionNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "report subscription updated successfully",
+	})
+}
+
+func (r *ReportSubscriptionController) DeleteReportSubscription(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	subscriptionID := c.Params("subscriptionID")
+	err := r.service.DeleteReportSubscription(c.Context(), userID, subscriptionID)
+	if err != nil {
+		if err == err2.ErrReportSubscriptionNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "report subscription deleted successfully",
+	})
+}
+
+func (r *ReportSubscriptionController) ConfirmUnsubscribe(c *fiber.Ctx) error {
+	return confirm.Render(c, "Unsubscribe from report emails", "You will stop receiving the scheduled OfficeZone reports.")
+}
+
+func (r *ReportSubscriptionController) Unsubscribe(c *fiber.Ctx) error {
+	unsubscribeToken := c.Params("token")
+	err := r.service.Unsubscribe(c.Context(), unsubscribeToken)
+	if err != nil {
+		if err == err2.ErrReportSubscriptionNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "unsubscribed from report emails successfully",
+	})
+}
@@ -0,0 +1,19 @@
+package dto
+
+type AddReportSubscriptionRequest struct {
+	Reports   []string `json:"reports" validate:"required,min=1,unique,dive,oneof=new_users reservations revenue pending_payments expiring_leases"`
+	Frequency string   `json:"frequency" validate:"required,oneof=daily weekly monthly"`
+	// Weekday is used by weekly reports where 0 is sunday, DayOfMonth by monthly reports and defaults to the 1st
+	Weekday    int `json:"weekday" validate:"gte=0,lte=6"`
+	DayOfMonth int `json:"dayOfMonth" validate:"omitempty,gte=1,lte=28"`
+	Hour       int `json:"hour" validate:"gte=0,lte=23"`
+}
+
+type UpdateReportSubscriptionRequest struct {
+	Reports    []string `json:"reports" validate:"omitempty,min=1,unique,dive,oneof=new_users reservations revenue pending_payments expiring_leases"`
+	Frequency  string   `json:"frequency" validate:"omitempty,oneof=daily weekly monthly"`
+	Weekday    *int     `json:"weekday" validate:"omitempty,gte=0,lte=6"`
+	DayOfMonth *int     `json:"dayOfMonth" validate:"omitempty,gte=1,lte=28"`
+	Hour       *int     `json:"hour" validate:"omitempty,gte=0,lte=23"`
+	IsActive   *bool    `json:"isActive" validate:"omitempty"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	"strings"
+)
+
+type ReportSubscriptionResponse struct {
+	ID         string   `json:"id"`
+	Reports    []string `json:"reports"`
+	Frequency  string   `json:"frequency"`
+	Weekday    int      `json:"weekday"`
+	DayOfMonth int      `json:"dayOfMonth"`
+	Hour       int      `json:"hour"`
+	IsActive   bool     `json:"isActive"`
+	LastSentAt string   `json:"lastSentAt,omitempty"`
+	NextSendAt string   `json:"nextSendAt"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+func NewReportSubscriptionResponse(subscription *entity.ReportSubscription) *ReportSubscriptionResponse {
+	lastSentAt := ""
+	if subscription.LastSentAt.Valid {
+		lastSentAt = subscription.LastSentAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	return &ReportSubscriptionResponse{
+		ID:         subscription.ID,
+		Reports:    strings.Split(subscription.Reports, ","),
+		Frequency:  subscription.Frequency,
+		Weekday:    subscription.Weekday,
+		DayOfMonth: subscription.DayOfMonth,
+		Hour:       subscription.Hour,
+		IsActive:   subscription.IsActive != nil && *subscription.IsActive,
+		LastSentAt: lastSentAt,
+		NextSendAt: subscription.NextSendAt.Format(constant.DATE_RESPONSE_FORMAT),
+		CreatedAt:  subscription.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ReportSubscriptionsResponse []ReportSubscriptionResponse
+
+func NewReportSubscriptionsResponse(subscriptions *entity.ReportSubscriptions) *ReportSubscriptionsResponse {
+	response := new(ReportSubscriptionsResponse)
+	for _, subscription := range *subscriptions {
+		*response = append(*response, *NewReportSubscriptionResponse(&subscription))
+	}
+	return response
+}
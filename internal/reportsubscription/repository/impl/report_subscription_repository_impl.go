@@ -0,0 +1,167 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"office-booking-backend/internal/reportsubscription/repository"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReportSubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportSubscriptionRepositoryImpl(db *gorm.DB) repository.ReportSubscriptionRepository {
+	return &ReportSubscriptionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ReportSubscriptionRepositoryImpl) GetUserReportSubscriptions(ctx context.Context, userID string) (*entity.ReportSubscriptions, error) {
+	subscriptions := new(entity.ReportSubscriptions)
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *ReportSubscriptionRepositoryImpl) GetReportSubscriptionByID(ctx context.Context, userID string, subscriptionID string) (*entity.ReportSubscription, error) {
+	subscription := new(entity.ReportSubscription)
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", subscriptionID, userID).
+		First(subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrReportSubscriptionNotFound
+		}
+
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *ReportSubscriptionRepositoryImpl) GetDueReportSubscriptions(ctx context.Context, now time.Time) (*entity.ReportSubscriptions, error) {
+	subscriptions := new(entity.ReportSubscriptions)
+	err := r.db.WithContext(ctx).
+		Preload("User.Detail").
+		Where("is_active = ? AND next_send_at <= ?", true, now).
+		Find(subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *ReportSubscriptionRepositoryImpl) AddReportSubscription(ctx context.Context, subscription *entity.ReportSubscription) error {
+	err := r.db.WithContext(ctx).Create(subscription).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "CONSTRAINT `fk_report_subscriptions_user`") {
+			return err2.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionRepositoryImpl) UpdateReportSubscription(ctx context.Context, subscription *entity.ReportSubscription) error {
+	// the schedule fields are selected because zero is a valid weekday and hour
+	res := r.db.WithContext(ctx).
+		Model(&entity.ReportSubscription{}).
+		Where("id = ? AND user_id = ?", subscription.ID, subscription.UserID).
+		Select("reports", "frequency", "weekday", "day_of_month", "hour", "is_active", "next_send_at").
+		Updates(subscription)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrReportSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionRepositoryImpl) DeleteReportSubscription(ctx context.Context, userID string, subscriptionID string) error {
+	res := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", subscriptionID, userID).
+		Delete(&entity.ReportSubscription{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrReportSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionRepositoryImpl) DeactivateReportSubscriptionByToken(ctx context.Context, token string) error {
+	res := r.db.WithContext(ctx).
+		Model(&entity.ReportSubscription{}).
+		Where("unsubscribe_token = ?", token).
+		Update("is_active", false)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		var count int64
+		err := r.db.WithContext(ctx).
+			Model(&entity.ReportSubscription{}).
+			Where("unsubscribe_token = ?", token).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return err2.ErrReportSubscriptionNotFound
+		}
+	}
+
+	return nil
+}
+
+// ClaimReportSubscription moves the schedule of a due subscription, it returns false when another run
+// has already claimed it
+func (r *ReportSubscriptionRepositoryImpl) ClaimReportSubscription(ctx context.Context, subscriptionID string, dueAt time.Time, sentAt time.Time, nextSendAt time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&entity.ReportSubscription{}).
+		Where("id = ? AND next_send_at = ?", subscriptionID, dueAt).
+		Updates(map[string]interface{}{
+			"last_sent_at": sentAt,
+			"next_send_at": nextSendAt,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// ReleaseReportSubscription restores the schedule of a claimed subscription that failed to send
+func (r *ReportSubscriptionRepositoryImpl) ReleaseReportSubscription(ctx context.Context, subscriptionID string, lastSentAt sql.NullTime, dueAt time.Time, nextSendAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.ReportSubscription{}).
+		Where("id = ? AND next_send_at = ?", subscriptionID, nextSendAt).
+		Updates(map[string]interface{}{
+			"last_sent_at": lastSentAt,
+			"next_send_at": dueAt,
+		}).Error
+}
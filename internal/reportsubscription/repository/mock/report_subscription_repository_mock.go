@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"context"
+	"database/sql"
+	"office-booking-backend/pkg/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportSubscriptionRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ReportSubscriptionRepositoryMock) GetUserReportSubscriptions(ctx context.Context, userID string) (*entity.ReportSubscriptions, error) {
+	args := r.Called(ctx, userID)
+	return args.Get(0).(*entity.ReportSubscriptions), args.Error(1)
+}
+
+func (r *ReportSubscriptionRepositoryMock) GetReportSubscriptionByID(ctx context.Context, userID string, subscriptionID string) (*entity.ReportSubscription, error) {
+	args := r.Called(ctx, userID, subscriptionID)
+	return args.Get(0).(*entity.ReportSubscription), args.Error(1)
+}
+
+func (r *ReportSubscriptionRepositoryMock) GetDueReportSubscriptions(ctx context.Context, now time.Time) (*entity.ReportSubscriptions, error) {
+	args := r.Called(ctx, now)
+	return args.Get(0).(*entity.ReportSubscriptions), args.Error(1)
+}
+
+func (r *ReportSubscriptionRepositoryMock) AddReportSubscription(ctx context.Context, subscription *entity.ReportSubscription) error {
+	args := r.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (r *ReportSubscriptionRepositoryMock) UpdateReportSubscription(ctx context.Context, subscription *entity.ReportSubscription) error {
+	args := r.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (r *ReportSubscriptionRepositoryMock) DeleteReportSubscription(ctx context.Context, userID string, subscriptionID string) error {
+	args := r.Called(ctx, userID, subscriptionID)
+	return args.Error(0)
+}
+
+func (r *ReportSubscriptionRepositoryMock) DeactivateReportSubscriptionByToken(ctx context.Context, token string) error {
+	args := r.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (r *ReportSubscriptionRepositoryMock) ClaimReportSubscription(ctx context.Context, subscriptionID string, dueAt time.Time, sentAt time.Time, nextSendAt time.Time) (bool, error) {
+	args := r.Called(ctx, subscriptionID, dueAt, sentAt, nextSendAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *ReportSubscriptionRepositoryMock) ReleaseReportSubscription(ctx context.Context, subscriptionID string, lastSentAt sql.NullTime, dueAt time.Time, nextSendAt time.Time) error {
+	args := r.Called(ctx, subscriptionID, lastSentAt, dueAt, nextSendAt)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"office-booking-backend/pkg/entity"
+	"time"
+)
+
+type ReportSubscriptionRepository interface {
+	GetUserReportSubscriptions(ctx context.Context, userID string) (*entity.ReportSubscriptions, error)
+	GetReportSubscriptionByID(ctx context.Context, userID string, subscriptionID string) (*entity.ReportSubscription, error)
+	GetDueReportSubscriptions(ctx context.Context, now time.Time) (*entity.ReportSubscriptions, error)
+	AddReportSubscription(ctx context.Context, subscription *entity.ReportSubscription) error
+	UpdateReportSubscription(ctx context.Context, subscription *entity.ReportSubscription) error
+	DeleteReportSubscription(ctx context.Context, userID string, subscriptionID string) error
+	DeactivateReportSubscriptionByToken(ctx context.Context, token string) error
+	ClaimReportSubscription(ctx context.Context, subscriptionID string, dueAt time.Time, sentAt time.Time, nextSendAt time.Time) (bool, error)
+	ReleaseReportSubscription(ctx context.Context, subscriptionID string, lastSentAt sql.NullTime, dueAt time.Time, nextSendAt time.Time) error
+}
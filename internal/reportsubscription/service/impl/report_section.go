@@ -0,0 +1,195 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	rd "office-booking-backend/internal/reservation/dto"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/custom"
+	"office-booking-backend/pkg/utils/timeseries"
+	"strconv"
+	"time"
+)
+
+// reportSection is a report of the email, its summary is in the body and the rows are attached as csv
+// with the first row as the header
+type reportSection struct {
+	Title   string
+	Summary string
+	Rows    [][]string
+}
+
+// reportPeriod returns the days before the send date covered by the report: a day, a week or a month
+func reportPeriod(frequency string, sendAt time.Time) *timeseries.Query {
+	sendDate := timeseries.Truncate(sendAt, timeseries.Day, time.Local)
+
+	startDate := sendDate.AddDate(0, 0, -1)
+	switch frequency {
+	case constant.WEEKLY_FREQUENCY:
+		startDate = sendDate.AddDate(0, 0, -7)
+	case constant.MONTHLY_FREQUENCY:
+		startDate = sendDate.AddDate(0, -1, 0)
+	}
+
+	return &timeseries.Query{
+		StartDate: custom.Date(startDate),
+		EndDate:   custom.Date(sendDate.AddDate(0, 0, -1)),
+		Interval:  timeseries.Day,
+	}
+}
+
+func (r *ReportSubscriptionServiceImpl) buildSection(ctx context.Context, report string, tr *timeseries.Range) (*reportSection, error) {
+	switch report {
+	case constant.NEW_USERS_REPORT:
+		return r.newUsersSection(ctx, tr)
+	case constant.RESERVATIONS_REPORT:
+		return r.reservationsSection(ctx, tr)
+	case constant.REVENUE_REPORT:
+		return r.revenueSection(ctx, tr)
+	case constant.PENDING_PAYMENTS_REPORT:
+		return r.pendingPaymentsSection(ctx)
+	case constant.EXPIRING_LEASES_REPORT:
+		return r.expiringLeasesSection(ctx)
+	default:
+		return nil, fmt.Errorf("unknown report %s", report)
+	}
+}
+
+func (r *ReportSubscriptionServiceImpl) newUsersSection(ctx context.Context, tr *timeseries.Range) (*reportSection, error) {
+	stats, err := r.userRepo.GetRegisteredMemberSeries(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	series := tr.Fill(stats.Values())
+	return &reportSection{
+		Title:   "New users",
+		Summary: fmt.Sprintf("%d new users registered", series.Total),
+		Rows:    seriesRows(series, "newUsers"),
+	}, nil
+}
+
+func (r *ReportSubscriptionServiceImpl) reservationsSection(ctx context.Context, tr *timeseries.Range) (*reportSection, error) {
+	stats, err := r.reservationRepo.GetReservationSeries(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus, err := r.reservationRepo.GetReservationCountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{{"status", "reservations"}}
+	for _, stat := range *byStatus {
+		rows = append(rows, []string{stat.StatusName, strconv.FormatInt(stat.Total, 10)})
+	}
+
+	series := tr.Fill(stats.Values())
+	return &reportSection{
+		Title:   "Reservations by status",
+		Summary: fmt.Sprintf("%d new reservations, the totals below are for all reservations", series.Total),
+		Rows:    rows,
+	}, nil
+}
+
+func (r *ReportSubscriptionServiceImpl) revenueSection(ctx context.Context, tr *timeseries.Range) (*reportSection, error) {
+	stats, err := r.reservationRepo.GetRevenueSeries(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	series := tr.Fill(stats.Values())
+	return &reportSection{
+		Title:   "Revenue",
+		Summary: fmt.Sprintf("Rp %d revenue from paid reservations", series.Total),
+		Rows:    seriesRows(series, "revenue"),
+	}, nil
+}
+
+func (r *ReportSubscriptionServiceImpl) pendingPaymentsSection(ctx context.Context) (*reportSection, error) {
+	filter := &rd.ReservationQueryParam{
+		StatusID:  constant.AWAITING_PAYMENT_STATUS,
+		SortBy:    "start_date",
+		SortOrder: "asc",
+		Page:      1,
+		Limit:     r.maxRows(),
+	}
+
+	total, err := r.reservationRepo.CountReservation(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := r.reservationRepo.GetReservations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{{"reservationId", "buildingName", "companyName", "email", "startDate", "amount", "expiredAt"}}
+	for _, reservation := range *reservations {
+		rows = append(rows, []string{
+			reservation.ID,
+			reservation.Building.Name,
+			reservation.CompanyName,
+			reservation.User.Email,
+			reservation.StartDate.Format(constant.DATE_RESPONSE_FORMAT),
+			strconv.Itoa(reservation.Amount),
+			reservation.ExpiredAt.Format(constant.DATE_RESPONSE_FORMAT),
+		})
+	}
+
+	return &reportSection{
+		Title:   "Pending payments",
+		Summary: fmt.Sprintf("%d reservations are awaiting payment", total),
+		Rows:    rows,
+	}, nil
+}
+
+func (r *ReportSubscriptionServiceImpl) expiringLeasesSection(ctx context.Context) (*reportSection, error) {
+	until := time.Now().Add(r.expiringWindow())
+	filter := &rd.ReservationQueryParam{
+		StatusID:  constant.ACTIVE_STATUS,
+		EndDate:   custom.Date(until),
+		SortBy:    "end_date",
+		SortOrder: "asc",
+		Page:      1,
+		Limit:     r.maxRows(),
+	}
+
+	total, err := r.reservationRepo.CountReservation(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := r.reservationRepo.GetReservations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{{"reservationId", "buildingName", "companyName", "email", "startDate", "endDate"}}
+	for _, reservation := range *reservations {
+		rows = append(rows, []string{
+			reservation.ID,
+			reservation.Building.Name,
+			reservation.CompanyName,
+			reservation.User.Email,
+			reservation.StartDate.Format(constant.DATE_RESPONSE_FORMAT),
+			reservation.EndDate.Format(constant.DATE_RESPONSE_FORMAT),
+		})
+	}
+
+	return &reportSection{
+		Title:   "Expiring leases",
+		Summary: fmt.Sprintf("%d active leases end before %s", total, until.Format(timeseries.BucketFormat)),
+		Rows:    rows,
+	}, nil
+}
+
+func seriesRows(series *timeseries.Series, column string) [][]string {
+	rows := [][]string{{"date", column}}
+	for _, point := range series.Points {
+		rows = append(rows, []string{point.Start, strconv.FormatInt(point.Value, 10)})
+	}
+	return rows
+}
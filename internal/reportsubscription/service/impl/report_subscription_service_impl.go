@@ -0,0 +1,275 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"office-booking-backend/internal/reportsubscription/dto"
+	"office-booking-backend/internal/reportsubscription/repository"
+	"office-booking-backend/internal/reportsubscription/service"
+	rr "office-booking-backend/internal/reservation/repository"
+	ur "office-booking-backend/internal/user/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/mail"
+	"office-booking-backend/pkg/utils/spreadsheet"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultReportMaxRows        = 500
+	defaultReportExpiringWindow = 30 * 24 * time.Hour
+)
+
+type ReportSubscriptionServiceImpl struct {
+	repo            repository.ReportSubscriptionRepository
+	userRepo        ur.UserRepository
+	reservationRepo rr.ReservationRepository
+	mail            mail.Client
+	conf            *viper.Viper
+}
+
+func NewReportSubscriptionServiceImpl(repo repository.ReportSubscriptionRepository, userRepo ur.UserRepository, reservationRepo rr.ReservationRepository, mail mail.Client, conf *viper.Viper) service.ReportSubscriptionService {
+	return &ReportSubscriptionServiceImpl{
+		repo:            repo,
+		userRepo:        userRepo,
+		reservationRepo: reservationRepo,
+		mail:            mail,
+		conf:            conf,
+	}
+}
+
+func (r *ReportSubscriptionServiceImpl) GetUserReportSubscriptions(ctx context.Context, userID string) (*dto.ReportSubscriptionsResponse, error) {
+	subscriptions, err := r.repo.GetUserReportSubscriptions(ctx, userID)
+	if err != nil {
+		log.Println("error when getting user report subscriptions: ", err)
+		return nil, err
+	}
+
+	return dto.NewReportSubscriptionsResponse(subscriptions), nil
+}
+
+func (r *ReportSubscriptionServiceImpl) GetReportSubscriptionByID(ctx context.Context, userID string, subscriptionID string) (*dto.ReportSubscriptionResponse, error) {
+	subscription, err := r.repo.GetReportSubscriptionByID(ctx, userID, subscriptionID)
+	if err != nil {
+		log.Println("error when getting report subscription by id: ", err)
+		return nil, err
+	}
+
+	return dto.NewReportSubscriptionResponse(subscription), nil
+}
+
+func (r *ReportSubscriptionServiceImpl) CreateReportSubscription(ctx context.Context, userID string, subscription *dto.AddReportSubscriptionRequest) (string, error) {
+	subscriptionEntity := &entity.ReportSubscription{
+		UserID:     userID,
+		Reports:    strings.Join(subscription.Reports, ","),
+		Frequency:  subscription.Frequency,
+		Weekday:    subscription.Weekday,
+		DayOfMonth: subscription.DayOfMonth,
+		Hour:       subscription.Hour,
+	}
+
+	if subscriptionEntity.DayOfMonth == 0 {
+		subscriptionEntity.DayOfMonth = 1
+	}
+	subscriptionEntity.NextSendAt = nextSendAt(subscriptionEntity, time.Now())
+
+	err := r.repo.AddReportSubscription(ctx, subscriptionEntity)
+	if err != nil {
+		log.Println("error when adding report subscription: ", err)
+		return "", err
+	}
+
+	return subscriptionEntity.ID, nil
+}
+
+func (r *ReportSubscriptionServiceImpl) UpdateReportSubscription(ctx context.Context, userID string, subscriptionID string, subscription *dto.UpdateReportSubscriptionRequest) error {
+	subscriptionEntity, err := r.repo.GetReportSubscriptionByID(ctx, userID, subscriptionID)
+	if err != nil {
+		log.Println("error when getting report subscription by id: ", err)
+		return err
+	}
+
+	if len(subscription.Reports) > 0 {
+		subscriptionEntity.Reports = strings.Join(subscription.Reports, ",")
+	}
+
+	if subscription.Frequency != "" {
+		subscriptionEntity.Frequency = subscription.Frequency
+	}
+
+	if subscription.Weekday != nil {
+		subscriptionEntity.Weekday = *subscription.Weekday
+	}
+
+	if subscription.DayOfMonth != nil {
+		subscriptionEntity.DayOfMonth = *subscription.DayOfMonth
+	}
+
+	if subscription.Hour != nil {
+		subscriptionEntity.Hour = *subscription.Hour
+	}
+
+	if subscription.IsActive != nil {
+		subscriptionEntity.IsActive = subscription.IsActive
+	}
+
+	// a reactivated subscription starts from the next schedule instead of sending the missed ones
+	subscriptionEntity.NextSendAt = nextSendAt(subscriptionEntity, time.Now())
+
+	err = r.repo.UpdateReportSubscription(ctx, subscriptionEntity)
+	if err != nil {
+		log.Println("error when updating report subscription: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionServiceImpl) DeleteReportSubscription(ctx context.Context, userID string, subscriptionID string) error {
+	err := r.repo.DeleteReportSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		log.Println("error when deleting report subscription: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionServiceImpl) Unsubscribe(ctx context.Context, token string) error {
+	err := r.repo.DeactivateReportSubscriptionByToken(ctx, token)
+	if err != nil {
+		log.Println("error when unsubscribing report subscription: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// SendDueReports emails the subscriptions whose schedule has passed. A subscription is claimed by
+// moving its schedule before sending so overlapping runs don't send it twice, a subscription that
+// fails is released and retried on the next run
+func (r *ReportSubscriptionServiceImpl) SendDueReports(ctx context.Context) error {
+	now := time.Now()
+	subscriptions, err := r.repo.GetDueReportSubscriptions(ctx, now)
+	if err != nil {
+		log.Println("error when getting due report subscriptions: ", err)
+		return err
+	}
+
+	for _, subscription := range *subscriptions {
+		// missed schedules are skipped, the next report covers its own period only
+		next := nextSendAt(&subscription, now)
+		claimed, err := r.repo.ClaimReportSubscription(ctx, subscription.ID, subscription.NextSendAt, now, next)
+		if err != nil {
+			log.Println("error when claiming report subscription "+subscription.ID+": ", err)
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		err = r.send(ctx, &subscription)
+		if err != nil {
+			log.Println("error when sending report subscription "+subscription.ID+": ", err)
+
+			err = r.repo.ReleaseReportSubscription(ctx, subscription.ID, subscription.LastSentAt, subscription.NextSendAt, next)
+			if err != nil {
+				log.Println("error when releasing report subscription "+subscription.ID+": ", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionServiceImpl) send(ctx context.Context, subscription *entity.ReportSubscription) error {
+	period := reportPeriod(subscription.Frequency, subscription.NextSendAt)
+	tr, err := period.Range(time.Local)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]string, 0)
+	attachments := make([]mail.Attachment, 0)
+	for _, report := range strings.Split(subscription.Reports, ",") {
+		section, err := r.buildSection(ctx, report, tr)
+		if err != nil {
+			return err
+		}
+
+		content, err := spreadsheet.Bytes(spreadsheet.CSV, section.Title, section.Rows)
+		if err != nil {
+			return err
+		}
+
+		// the template is html and renders the summary unescaped, one line per report
+		summaries = append(summaries, html.EscapeString(section.Title+": "+section.Summary))
+		attachments = append(attachments, mail.Attachment{
+			Filename: fmt.Sprintf("%s_%s_%s.%s", report, period.StartDate.String(), period.EndDate.String(), spreadsheet.CSV),
+			Content:  content,
+		})
+	}
+
+	title := fmt.Sprintf("Your %s OfficeZone report", subscription.Frequency)
+	return r.mail.SendMail(ctx, &mail.Mail{
+		Subject:  fmt.Sprintf("%s (%s - %s)", title, period.StartDate.String(), period.EndDate.String()),
+		Template: "report-subscription",
+		Variable: map[string]string{
+			"name":        subscription.User.Detail.Name,
+			"title":       title,
+			"startDate":   period.StartDate.String(),
+			"endDate":     period.EndDate.String(),
+			"summary":     strings.Join(summaries, "<br>"),
+			"unsubscribe": r.conf.GetString("reportSubscription.unsubscribeUrl") + subscription.UnsubscribeToken,
+		},
+		Attachments: attachments,
+		Recipient:   subscription.User.Email,
+	})
+}
+
+func (r *ReportSubscriptionServiceImpl) maxRows() int {
+	maxRows := r.conf.GetInt("reportSubscription.maxRows")
+	if maxRows <= 0 {
+		return defaultReportMaxRows
+	}
+	return maxRows
+}
+
+func (r *ReportSubscriptionServiceImpl) expiringWindow() time.Duration {
+	window := r.conf.GetDuration("reportSubscription.expiringWindow")
+	if window <= 0 {
+		return defaultReportExpiringWindow
+	}
+	return window
+}
+
+// nextSendAt returns the first scheduled time after the given time
+func nextSendAt(subscription *entity.ReportSubscription, after time.Time) time.Time {
+	after = after.In(time.Local)
+	next := time.Date(after.Year(), after.Month(), after.Day(), subscription.Hour, 0, 0, 0, time.Local)
+
+	switch subscription.Frequency {
+	case constant.WEEKLY_FREQUENCY:
+		next = next.AddDate(0, 0, (subscription.Weekday-int(next.Weekday())+7)%7)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+	case constant.MONTHLY_FREQUENCY:
+		next = time.Date(after.Year(), after.Month(), subscription.DayOfMonth, subscription.Hour, 0, 0, 0, time.Local)
+		if !next.After(after) {
+			next = next.AddDate(0, 1, 0)
+		}
+	default:
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+
+	return next
+}
@@ -0,0 +1,190 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	mockRepo "office-booking-backend/internal/reportsubscription/repository/mock"
+	"office-booking-backend/internal/reportsubscription/service"
+	mockReservationRepo "office-booking-backend/internal/reservation/repository/mock"
+	mockUserRepo "office-booking-backend/internal/user/repository/mock"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	mockMail "office-booking-backend/pkg/utils/mail"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteReportSubscriptionService struct {
+	suite.Suite
+	mockRepo                  *mockRepo.ReportSubscriptionRepositoryMock
+	mockUserRepo              *mockUserRepo.UserRepositoryMock
+	mockReservationRepo       *mockReservationRepo.ReservationRepositoryMock
+	mockMail                  *mockMail.ClientMock
+	reportSubscriptionService service.ReportSubscriptionService
+}
+
+func (s *TestSuiteReportSubscriptionService) SetupTest() {
+	s.mockRepo = new(mockRepo.ReportSubscriptionRepositoryMock)
+	s.mockUserRepo = new(mockUserRepo.UserRepositoryMock)
+	s.mockReservationRepo = new(mockReservationRepo.ReservationRepositoryMock)
+	s.mockMail = new(mockMail.ClientMock)
+	s.reportSubscriptionService = NewReportSubscriptionServiceImpl(s.mockRepo, s.mockUserRepo, s.mockReservationRepo, s.mockMail, viper.New())
+}
+
+func (s *TestSuiteReportSubscriptionService) TearDownTest() {
+	s.mockRepo = nil
+	s.mockUserRepo = nil
+	s.mockReservationRepo = nil
+	s.mockMail = nil
+	s.reportSubscriptionService = nil
+}
+
+func TestReportSubscriptionService(t *testing.T) {
+	suite.Run(t, new(TestSuiteReportSubscriptionService))
+}
+
+func (s *TestSuiteReportSubscriptionService) TestNextSendAt() {
+	// 2023-05-10 is a wednesday
+	after := time.Date(2023, 5, 10, 9, 30, 0, 0, time.Local)
+
+	for _, tc := range []struct {
+		Name         string
+		Subscription *entity.ReportSubscription
+		Expected     time.Time
+	}{
+		{
+			Name:         "daily later today",
+			Subscription: &entity.ReportSubscription{Frequency: constant.DAILY_FREQUENCY, Hour: 10},
+			Expected:     time.Date(2023, 5, 10, 10, 0, 0, 0, time.Local),
+		},
+		{
+			Name:         "daily hour passed",
+			Subscription: &entity.ReportSubscription{Frequency: constant.DAILY_FREQUENCY, Hour: 9},
+			Expected:     time.Date(2023, 5, 11, 9, 0, 0, 0, time.Local),
+		},
+		{
+			Name:         "weekly later this week",
+			Subscription: &entity.ReportSubscription{Frequency: constant.WEEKLY_FREQUENCY, Weekday: 5, Hour: 8},
+			Expected:     time.Date(2023, 5, 12, 8, 0, 0, 0, time.Local),
+		},
+		{
+			Name:         "weekly same day hour passed",
+			Subscription: &entity.ReportSubscription{Frequency: constant.WEEKLY_FREQUENCY, Weekday: 3, Hour: 8},
+			Expected:     time.Date(2023, 5, 17, 8, 0, 0, 0, time.Local),
+		},
+		{
+			Name:         "weekly day passed",
+			Subscription: &entity.ReportSubscription{Frequency: constant.WEEKLY_FREQUENCY, Weekday: 1, Hour: 8},
+			Expected:     time.Date(2023, 5, 15, 8, 0, 0, 0, time.Local),
+		},
+		{
+			Name:         "monthly later this month",
+			Subscription: &entity.ReportSubscription{Frequency: constant.MONTHLY_FREQUENCY, DayOfMonth: 20, Hour: 8},
+			Expected:     time.Date(2023, 5, 20, 8, 0, 0, 0, time.Local),
+		},
+		{
+			Name:         "monthly day passed",
+			Subscription: &entity.ReportSubscription{Frequency: constant.MONTHLY_FREQUENCY, DayOfMonth: 1, Hour: 8},
+			Expected:     time.Date(2023, 6, 1, 8, 0, 0, 0, time.Local),
+		},
+	} {
+		s.Run(tc.Name, func() {
+			s.Equal(tc.Expected, nextSendAt(tc.Subscription, after))
+		})
+	}
+}
+
+func (s *TestSuiteReportSubscriptionService) TestReportPeriod() {
+	sendAt := time.Date(2023, 5, 10, 8, 0, 0, 0, time.Local)
+
+	for _, tc := range []struct {
+		Frequency string
+		StartDate string
+		EndDate   string
+	}{
+		{Frequency: constant.DAILY_FREQUENCY, StartDate: "2023-05-09", EndDate: "2023-05-09"},
+		{Frequency: constant.WEEKLY_FREQUENCY, StartDate: "2023-05-03", EndDate: "2023-05-09"},
+		{Frequency: constant.MONTHLY_FREQUENCY, StartDate: "2023-04-10", EndDate: "2023-05-09"},
+	} {
+		s.Run(tc.Frequency, func() {
+			period := reportPeriod(tc.Frequency, sendAt)
+			s.Equal(tc.StartDate, period.StartDate.String())
+			s.Equal(tc.EndDate, period.EndDate.String())
+		})
+	}
+}
+
+func dueSubscription() *entity.ReportSubscriptions {
+	return &entity.ReportSubscriptions{
+		{
+			ID:         "subscription",
+			Reports:    constant.PENDING_PAYMENTS_REPORT,
+			Frequency:  constant.DAILY_FREQUENCY,
+			Hour:       8,
+			NextSendAt: time.Date(2023, 5, 10, 8, 0, 0, 0, time.Local),
+			User:       entity.User{Email: "admin@mail.com"},
+		},
+	}
+}
+
+func (s *TestSuiteReportSubscriptionService) mockPendingPayments() {
+	s.mockReservationRepo.On("CountReservation", mock.Anything, mock.Anything).Return(int64(0), nil)
+	s.mockReservationRepo.On("GetReservations", mock.Anything, mock.Anything).Return(&entity.Reservations{}, nil)
+}
+
+func (s *TestSuiteReportSubscriptionService) TestSendDueReports_Claimed() {
+	s.mockRepo.On("GetDueReportSubscriptions", mock.Anything, mock.Anything).Return(dueSubscription(), nil)
+	s.mockRepo.On("ClaimReportSubscription", mock.Anything, "subscription", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	s.mockPendingPayments()
+	s.mockMail.On("SendMail", mock.Anything, mock.MatchedBy(func(m *mockMail.Mail) bool {
+		return m.Template == "report-subscription" && len(m.Attachments) == 1
+	})).Return(nil)
+
+	err := s.reportSubscriptionService.SendDueReports(context.Background())
+	s.NoError(err)
+	s.mockMail.AssertNumberOfCalls(s.T(), "SendMail", 1)
+	s.mockRepo.AssertNotCalled(s.T(), "ReleaseReportSubscription", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReportSubscriptionService) TestSendDueReports_SummaryLines() {
+	subscriptions := dueSubscription()
+	(*subscriptions)[0].Reports = constant.PENDING_PAYMENTS_REPORT + "," + constant.NEW_USERS_REPORT
+	s.mockRepo.On("GetDueReportSubscriptions", mock.Anything, mock.Anything).Return(subscriptions, nil)
+	s.mockRepo.On("ClaimReportSubscription", mock.Anything, "subscription", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	s.mockPendingPayments()
+	s.mockUserRepo.On("GetRegisteredMemberSeries", mock.Anything, mock.Anything).Return(&entity.TimeBucketStats{}, nil)
+	s.mockMail.On("SendMail", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.reportSubscriptionService.SendDueReports(context.Background())
+	s.NoError(err)
+
+	sent := s.mockMail.Calls[0].Arguments.Get(1).(*mockMail.Mail)
+	s.Equal("Pending payments: 0 reservations are awaiting payment<br>New users: 0 new users registered", sent.Variable["summary"])
+}
+
+func (s *TestSuiteReportSubscriptionService) TestSendDueReports_AlreadyClaimed() {
+	s.mockRepo.On("GetDueReportSubscriptions", mock.Anything, mock.Anything).Return(dueSubscription(), nil)
+	s.mockRepo.On("ClaimReportSubscription", mock.Anything, "subscription", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	err := s.reportSubscriptionService.SendDueReports(context.Background())
+	s.NoError(err)
+	s.mockMail.AssertNotCalled(s.T(), "SendMail", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReportSubscriptionService) TestSendDueReports_SendFailed() {
+	subscriptions := dueSubscription()
+	dueAt := (*subscriptions)[0].NextSendAt
+	s.mockRepo.On("GetDueReportSubscriptions", mock.Anything, mock.Anything).Return(subscriptions, nil)
+	s.mockRepo.On("ClaimReportSubscription", mock.Anything, "subscription", dueAt, mock.Anything, mock.Anything).Return(true, nil)
+	s.mockRepo.On("ReleaseReportSubscription", mock.Anything, "subscription", mock.Anything, dueAt, mock.Anything).Return(nil)
+	s.mockPendingPayments()
+	s.mockMail.On("SendMail", mock.Anything, mock.Anything).Return(errors.New("mailgun down"))
+
+	err := s.reportSubscriptionService.SendDueReports(context.Background())
+	s.NoError(err)
+	s.mockRepo.AssertCalled(s.T(), "ReleaseReportSubscription", mock.Anything, "subscription", mock.Anything, dueAt, mock.Anything)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"office-booking-backend/internal/reportsubscription/dto"
+)
+
+type ReportSubscriptionService interface {
+	GetUserReportSubscriptions(ctx context.Context, userID string) (*dto.ReportSubscriptionsResponse, error)
+	GetReportSubscriptionByID(ctx context.Context, userID string, subscriptionID string) (*dto.ReportSubscriptionResponse, error)
+	CreateReportSubscription(ctx context.Context, userID string, subscription *dto.AddReportSubscriptionRequest) (string, error)
+	UpdateReportSubscription(ctx context.Context, userID string, subscriptionID string, subscription *dto.UpdateReportSubscriptionRequest) error
+	DeleteReportSubscription(ctx context.Context, userID string, subscriptionID string) error
+	Unsubscribe(ctx context.Context, token string) error
+	SendDueReports(ctx context.Context) error
+}
@@ -3,8 +3,10 @@ package controller
 import (
 	"office-booking-backend/internal/reservation/dto"
 	"office-booking-backend/internal/reservation/service"
+	"office-booking-backend/pkg/constant"
 	err2 "office-booking-backend/pkg/errors"
 	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/timeseries"
 	"office-booking-backend/pkg/utils/validator"
 	"reflect"
 	"strconv"
@@ -64,6 +66,10 @@ func (r *ReservationController) GetReservations(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
 	}
 
+	if filter.Detect(c) {
+		filter.Page = 1
+	}
+
 	if errs := r.validator.ValidateQuery(filter); errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidQueryParams.Error(),
@@ -71,19 +77,32 @@ func (r *ReservationController) GetReservations(c *fiber.Ctx) error {
 		})
 	}
 
+	// reservations waiting for the internal approval of their organization aren't handled by the admins yet
+	if filter.StatusID == 0 {
+		filter.ExcludedStatusIDs = []int{constant.PENDING_APPROVAL_STATUS}
+	}
+
 	reservations, count, err := r.service.GetReservations(c.Context(), filter)
 	if err != nil {
+		if err == err2.ErrInvalidCursor {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	meta := fiber.Map{
+		"total": count,
+		"page":  filter.Page,
+		"limit": filter.Limit,
+	}
+	if filter.Enabled {
+		meta = filter.Meta(filter.Limit)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Message: "reservations fetched successfully",
 		Data:    reservations,
-		Meta: fiber.Map{
-			"total": count,
-			"page":  filter.Page,
-			"limit": filter.Limit,
-		},
+		Meta:    meta,
 	})
 }
 
@@ -128,8 +147,24 @@ func (r *ReservationController) GetUserReservationDetailByID(c *fiber.Ctx) error
 }
 
 func (r *ReservationController) GetReservationTotal(c *fiber.Ctx) error {
-	stat, err := r.service.GetReservationStat(c.Context())
+	filter := new(timeseries.Query)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	stat, err := r.service.GetReservationStat(c.Context(), filter)
 	if err != nil {
+		if err == err2.ErrInvalidDateRange || err == err2.ErrTooManyDataPoints {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -140,8 +175,24 @@ func (r *ReservationController) GetReservationTotal(c *fiber.Ctx) error {
 }
 
 func (r *ReservationController) GetTotalRevenueByTime(c *fiber.Ctx) error {
-	stat, err := r.service.GetTotalRevenueByTime(c.Context())
+	filter := new(timeseries.Query)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	stat, err := r.service.GetTotalRevenueByTime(c.Context(), filter)
 	if err != nil {
+		if err == err2.ErrInvalidDateRange || err == err2.ErrTooManyDataPoints {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -175,7 +226,7 @@ func (r *ReservationController) CreateReservation(c *fiber.Ctx) error {
 			fallthrough
 		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidBuildingID.Error())
-		case err2.ErrBuildingNotAvailable:
+		case err2.ErrBuildingNotAvailable, err2.ErrBuildingClosed:
 			return fiber.NewError(fiber.StatusConflict, err.Error())
 		case err2.ErrInvalidUserID:
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
@@ -210,7 +261,7 @@ func (r *ReservationController) CreateAdminReservation(c *fiber.Ctx) error {
 		switch err {
 		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidBuildingID.Error())
-		case err2.ErrBuildingNotAvailable:
+		case err2.ErrBuildingNotAvailable, err2.ErrBuildingClosed:
 			return fiber.NewError(fiber.StatusConflict, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -275,7 +326,7 @@ func (r *ReservationController) UpdateReservation(c *fiber.Ctx) error {
 		switch err {
 		case err2.ErrReservationNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
-		case err2.ErrBuildingNotAvailable:
+		case err2.ErrBuildingNotAvailable, err2.ErrBuildingClosed:
 			return fiber.NewError(fiber.StatusConflict, err.Error())
 		case err2.ErrBuildingNotFound:
 			return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidBuildingID.Error())
@@ -313,6 +364,8 @@ func (r *ReservationController) UpdateReservationStatus(c *fiber.Ctx) error {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		case err2.ErrInvalidStatus:
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err2.ErrReservationAwaitingApproval:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
 		default:
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
@@ -436,3 +489,167 @@ func (r *ReservationController) UpdateReservationReview(c *fiber.Ctx) error {
 		Message: "review updated successfully",
 	})
 }
+
+func (r *ReservationController) GetUserReservationTransfers(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	transfers, err := r.service.GetUserReservationTransfers(c.Context(), userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation transfers fetched successfully",
+		Data:    transfers,
+	})
+}
+
+func (r *ReservationController) GetReservationTransfers(c *fiber.Ctx) error {
+	reservationID := c.Params("reservationID")
+
+	transfers, err := r.service.GetReservationTransfers(c.Context(), reservationID)
+	if err != nil {
+		switch err {
+		case err2.ErrReservationNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation transfers fetched successfully",
+		Data:    transfers,
+	})
+}
+
+func (r *ReservationController) CreateReservationTransfer(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	reservationID := c.Params("reservationID")
+
+	transferRequest := new(dto.AddReservationTransferRequest)
+	if err := c.BodyParser(transferRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*transferRequest); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	transferID, err := r.service.CreateReservationTransfer(c.Context(), userID, reservationID, transferRequest)
+	if err != nil {
+		switch err {
+		case err2.ErrReservationNotFound:
+			fallthrough
+		case err2.ErrUserNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrTransferToSelf:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case err2.ErrReservationNotTransferable:
+			fallthrough
+		case err2.ErrTransferAlreadyPending:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "reservation transfer requested successfully",
+		Data: fiber.Map{
+			"transferId": transferID,
+		},
+	})
+}
+
+func (r *ReservationController) CancelReservationTransfer(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	reservationID := c.Params("reservationID")
+
+	err := r.service.CancelReservationTransfer(c.Context(), userID, reservationID)
+	if err != nil {
+		switch err {
+		case err2.ErrTransferNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrTransferNotPending:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation transfer canceled successfully",
+	})
+}
+
+func (r *ReservationController) AcceptReservationTransfer(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	transferID := c.Params("transferID")
+
+	err := r.service.AcceptReservationTransfer(c.Context(), userID, transferID)
+	if err != nil {
+		switch err {
+		case err2.ErrTransferNotFound:
+			fallthrough
+		case err2.ErrReservationNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrTransferNotPending:
+			fallthrough
+		case err2.ErrReservationNotTransferable:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation transfer accepted successfully",
+	})
+}
+
+func (r *ReservationController) DeclineReservationTransfer(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	transferID := c.Params("transferID")
+
+	err := r.service.DeclineReservationTransfer(c.Context(), userID, transferID)
+	if err != nil {
+		switch err {
+		case err2.ErrTransferNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrTransferNotPending:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reservation transfer declined successfully",
+	})
+}
@@ -1,12 +1,15 @@
 package dto
 
-import "office-booking-backend/pkg/custom"
+import (
+	"office-booking-backend/pkg/custom"
+	"office-booking-backend/pkg/utils/cursor"
+)
 
 type ReservationQueryParam struct {
 	UserName     string      `query:"userName" validate:"omitempty,min=3,max=50"`
 	UserID       string      `query:"userId" validate:"omitempty,uuid4"`
 	BuildingID   string      `query:"buildingId" validate:"omitempty,uuid4"`
-	StatusID     int         `query:"statusId" validate:"omitempty,gte=1,lte=6"`
+	StatusID     int         `query:"statusId" validate:"omitempty,gte=1,lte=7"`
 	StartDate    custom.Date `query:"startDate"`
 	EndDate      custom.Date `query:"endDate"`
 	CreatedStart custom.Date `query:"createdStart" validate:"required_with=CreatedEnd"`
@@ -16,4 +19,11 @@ type ReservationQueryParam struct {
 	Page         int         `query:"page" validate:"gte=1"`
 	Limit        int         `query:"limit" validate:"gte=1"`
 	Offset       int         `query:"-" validate:"isdefault"`
+
+	// OrganizationID is set by the organization endpoints, it can't be set from the query
+	OrganizationID string `query:"-"`
+	// ExcludedStatusIDs is set by the admin endpoint, it can't be set from the query
+	ExcludedStatusIDs []int `query:"-"`
+
+	cursor.Pagination
 }
@@ -72,31 +72,67 @@ func (u *UpdateReservationStatusRequest) ToEntity(reservationID string) *entity.
 	}
 }
 
+// SubRatingsRequest are the optional ratings of each aspect of the stay
+type SubRatingsRequest struct {
+	LocationRating     *int `json:"locationRating" validate:"omitempty,gte=1,lte=5"`
+	CleanlinessRating  *int `json:"cleanlinessRating" validate:"omitempty,gte=1,lte=5"`
+	FacilitiesRating   *int `json:"facilitiesRating" validate:"omitempty,gte=1,lte=5"`
+	ValueRating        *int `json:"valueRating" validate:"omitempty,gte=1,lte=5"`
+	ConnectivityRating *int `json:"connectivityRating" validate:"omitempty,gte=1,lte=5"`
+}
+
 type AddReviewRequest struct {
 	Rating  int    `json:"rating" validate:"required,gte=1,lte=5"`
 	Message string `json:"message" validate:"omitempty,min=3,max=255"`
+	SubRatingsRequest
 }
 
 func (a *AddReviewRequest) ToEntity(reservation *entity.Reservation) *entity.Review {
 	return &entity.Review{
-		ReservationID: reservation.ID,
-		UserID:        reservation.UserID,
-		BuildingID:    reservation.BuildingID,
-		Rating:        a.Rating,
-		Message:       a.Message,
+		ReservationID:      reservation.ID,
+		UserID:             reservation.UserID,
+		BuildingID:         reservation.BuildingID,
+		Rating:             a.Rating,
+		LocationRating:     a.LocationRating,
+		CleanlinessRating:  a.CleanlinessRating,
+		FacilitiesRating:   a.FacilitiesRating,
+		ValueRating:        a.ValueRating,
+		ConnectivityRating: a.ConnectivityRating,
+		Message:            a.Message,
 	}
 }
 
+// UpdateReviewRequest keeps the sub-ratings that are left out of the request
 type UpdateReviewRequest struct {
 	Rating  int    `json:"rating" validate:"required,gte=1,lte=5"`
 	Message string `json:"message" validate:"omitempty,min=3,max=255"`
+	SubRatingsRequest
 }
 
 func (u *UpdateReviewRequest) ToEntity(review *entity.Review) *entity.Review {
 	return &entity.Review{
-		ID:         review.ID,
-		BuildingID: review.BuildingID,
-		Rating:     u.Rating,
-		Message:    u.Message,
+		ID:                 review.ID,
+		BuildingID:         review.BuildingID,
+		Rating:             u.Rating,
+		LocationRating:     u.LocationRating,
+		CleanlinessRating:  u.CleanlinessRating,
+		FacilitiesRating:   u.FacilitiesRating,
+		ValueRating:        u.ValueRating,
+		ConnectivityRating: u.ConnectivityRating,
+		Message:            u.Message,
+	}
+}
+
+type AddReservationTransferRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	CompanyName string `json:"companyName" validate:"omitempty,min=3,max=255"`
+}
+
+func (a *AddReservationTransferRequest) ToEntity(reservation *entity.Reservation, toUserID string) *entity.ReservationTransfer {
+	return &entity.ReservationTransfer{
+		ReservationID: reservation.ID,
+		FromUserID:    reservation.UserID,
+		ToUserID:      toUserID,
+		CompanyName:   a.CompanyName,
 	}
 }
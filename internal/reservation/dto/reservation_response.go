@@ -3,6 +3,7 @@ package dto
 import (
 	"office-booking-backend/pkg/constant"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
 	"time"
 )
 
@@ -225,8 +226,8 @@ func NewFullReservationResponse(reservation *entity.Reservation) *FullReservatio
 }
 
 type ReservationStatResponse struct {
-	ByStatus    *ReservationTotals `json:"byStatus"`
-	ByTimeframe *TimeframeStat     `json:"byTimeframe"`
+	ByStatus *ReservationTotals `json:"byStatus"`
+	ByTime   *timeseries.Series `json:"byTime"`
 }
 
 type ReservationTotal struct {
@@ -253,36 +254,89 @@ func NewReservationStatsResponse(stats *entity.StatusesStat) *ReservationTotals
 	return response
 }
 
-type TimeframeStat struct {
-	Today     int64 `json:"today"`
-	ThisWeek  int64 `json:"thisWeek"`
-	ThisMonth int64 `json:"thisMonth"`
-	ThisYear  int64 `json:"thisYear"`
-	AllTime   int64 `json:"allTime"`
+type BriefReviewResponse struct {
+	ID                 string    `json:"id"`
+	Rating             int       `json:"rating"`
+	LocationRating     *int      `json:"locationRating"`
+	CleanlinessRating  *int      `json:"cleanlinessRating"`
+	FacilitiesRating   *int      `json:"facilitiesRating"`
+	ValueRating        *int      `json:"valueRating"`
+	ConnectivityRating *int      `json:"connectivityRating"`
+	Message            string    `json:"message"`
+	Status             string    `json:"status"`
+	CreatedAt          time.Time `json:"createdAt"`
 }
 
-func NewTimeframeStat(stats *entity.TimeframeStat) *TimeframeStat {
-	return &TimeframeStat{
-		Today:     stats.Day.Int64,
-		ThisWeek:  stats.Week.Int64,
-		ThisMonth: stats.Month.Int64,
-		ThisYear:  stats.Year.Int64,
-		AllTime:   stats.All.Int64,
+func NewBriefReviewResponse(review *entity.Review) *BriefReviewResponse {
+	return &BriefReviewResponse{
+		ID:                 review.ID,
+		Rating:             review.Rating,
+		LocationRating:     review.LocationRating,
+		CleanlinessRating:  review.CleanlinessRating,
+		FacilitiesRating:   review.FacilitiesRating,
+		ValueRating:        review.ValueRating,
+		ConnectivityRating: review.ConnectivityRating,
+		Message:            review.Message,
+		Status:             review.Status,
+		CreatedAt:          review.CreatedAt,
 	}
 }
 
-type BriefReviewResponse struct {
-	ID        string    `json:"id"`
-	Rating    int       `json:"rating"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"createdAt"`
+type TransferReservationResponse struct {
+	ID          string `json:"id"`
+	CompanyName string `json:"companyName"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate"`
+	Building    struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"building"`
 }
 
-func NewBriefReviewResponse(review *entity.Review) *BriefReviewResponse {
-	return &BriefReviewResponse{
-		ID:        review.ID,
-		Rating:    review.Rating,
-		Message:   review.Message,
-		CreatedAt: review.CreatedAt,
+type ReservationTransferResponse struct {
+	ID          string                      `json:"id"`
+	Reservation TransferReservationResponse `json:"reservation"`
+	From        TenantResponse              `json:"from"`
+	To          TenantResponse              `json:"to"`
+	CompanyName string                      `json:"companyName"`
+	Status      string                      `json:"status"`
+	RespondedAt string                      `json:"respondedAt"`
+	CreatedAt   string                      `json:"createdAt"`
+}
+
+func NewReservationTransferResponse(transfer *entity.ReservationTransfer) *ReservationTransferResponse {
+	reservation := TransferReservationResponse{
+		ID:          transfer.Reservation.ID,
+		CompanyName: transfer.Reservation.CompanyName,
+		StartDate:   transfer.Reservation.StartDate.Format(constant.DATE_RESPONSE_FORMAT),
+		EndDate:     transfer.Reservation.EndDate.Format(constant.DATE_RESPONSE_FORMAT),
 	}
+	reservation.Building.ID = transfer.Reservation.Building.ID
+	reservation.Building.Name = transfer.Reservation.Building.Name
+
+	respondedAt := ""
+	if !transfer.RespondedAt.IsZero() {
+		respondedAt = transfer.RespondedAt.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	return &ReservationTransferResponse{
+		ID:          transfer.ID,
+		Reservation: reservation,
+		From:        *NewTenantResponse(transfer.FromUser),
+		To:          *NewTenantResponse(transfer.ToUser),
+		CompanyName: transfer.CompanyName,
+		Status:      transfer.Status,
+		RespondedAt: respondedAt,
+		CreatedAt:   transfer.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ReservationTransfersResponse []ReservationTransferResponse
+
+func NewReservationTransfersResponse(transfers *entity.ReservationTransfers) *ReservationTransfersResponse {
+	response := new(ReservationTransfersResponse)
+	for _, transfer := range *transfers {
+		*response = append(*response, *NewReservationTransferResponse(&transfer))
+	}
+	return response
 }
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"office-booking-backend/internal/reservation/dto"
 	"office-booking-backend/internal/reservation/repository"
+	reviewRepo "office-booking-backend/internal/review/repository"
 	"office-booking-backend/pkg/constant"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/timeseries"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 
 	"golang.org/x/net/context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReservationRepositoryImpl struct {
@@ -91,6 +94,10 @@ func (r *ReservationRepositoryImpl) CountReservation(ctx context.Context, filter
 		query = query.Where("ud.name LIKE ?", "%"+filter.UserName+"%")
 	}
 
+	if filter.OrganizationID != "" {
+		query = query.Where(sq.Eq{"r.organization_id": filter.OrganizationID})
+	}
+
 	if filter.BuildingID != "" {
 		query = query.Where(sq.Eq{"r.building_id": filter.BuildingID})
 	}
@@ -99,6 +106,10 @@ func (r *ReservationRepositoryImpl) CountReservation(ctx context.Context, filter
 		query = query.Where(sq.Eq{"r.status_id": filter.StatusID})
 	}
 
+	if len(filter.ExcludedStatusIDs) > 0 {
+		query = query.Where(sq.NotEq{"r.status_id": filter.ExcludedStatusIDs})
+	}
+
 	if !filter.StartDate.ToTime().IsZero() {
 		query = query.Where(sq.GtOrEq{"r.start_date": filter.StartDate.ToTime()})
 	}
@@ -177,6 +188,10 @@ func (r *ReservationRepositoryImpl) GetReservations(ctx context.Context, filter
 		query = query.Where("ud.name LIKE ?", "%"+filter.UserName+"%")
 	}
 
+	if filter.OrganizationID != "" {
+		query = query.Where(sq.Eq{"r.organization_id": filter.OrganizationID})
+	}
+
 	if filter.BuildingID != "" {
 		query = query.Where(sq.Eq{"r.building_id": filter.BuildingID})
 	}
@@ -185,6 +200,10 @@ func (r *ReservationRepositoryImpl) GetReservations(ctx context.Context, filter
 		query = query.Where(sq.Eq{"r.status_id": filter.StatusID})
 	}
 
+	if len(filter.ExcludedStatusIDs) > 0 {
+		query = query.Where(sq.NotEq{"r.status_id": filter.ExcludedStatusIDs})
+	}
+
 	if !filter.StartDate.ToTime().IsZero() {
 		query = query.Where(sq.GtOrEq{"r.start_date": filter.StartDate.ToTime()})
 	}
@@ -214,17 +233,35 @@ func (r *ReservationRepositoryImpl) GetReservations(ctx context.Context, filter
 		filter.SortBy = ""
 	}
 
-	if filter.SortBy != "" {
-		if filter.SortOrder == "desc" {
-			query = query.OrderBy(filter.SortBy + " DESC")
-		} else {
-			query = query.OrderBy(filter.SortBy + " ASC")
+	if filter.Enabled {
+		// cursor pages need a total order, newest first unless a sort is given
+		column, desc := filter.SortBy, filter.SortOrder == "desc"
+		if column == "" {
+			column, desc = "r.created_at", true
 		}
+
+		if condition, args := filter.Where(column, "r.id", desc); condition != "" {
+			query = query.Where(condition, args...)
+		}
+
+		query = query.
+			OrderBy(filter.OrderBy(column, "r.id", desc)...).
+			Limit(uint64(filter.Limit + 1))
+	} else {
+		if filter.SortBy != "" {
+			if filter.SortOrder == "desc" {
+				query = query.OrderBy(filter.SortBy + " DESC")
+			} else {
+				query = query.OrderBy(filter.SortBy + " ASC")
+			}
+		}
+
+		query = query.
+			Offset(uint64(filter.Offset)).
+			Limit(uint64(filter.Limit))
 	}
 
 	rows, err := query.
-		Offset(uint64(filter.Offset)).
-		Limit(uint64(filter.Limit)).
 		RunWith(db).QueryContext(ctx)
 	if err != nil {
 		return nil, err
@@ -304,7 +341,7 @@ func (r *ReservationRepositoryImpl) GetReservationByID(ctx context.Context, rese
 	if err != nil {
 		return nil, err
 	}
-	rows, err := sq.Select("r.id, r.company_name, r.building_id, r.start_date, r.end_date, r.accepted_at, r.expired_at, r.amount, r.user_id, r.status_id, r.message, r.created_at, r.updated_at, s.id, s.message, b.id, b.name, b.address, p.thumbnail_url, c.name, d.name, u.id, u.email, ud.name, pp.url").
+	rows, err := sq.Select("r.id, r.company_name, r.building_id, r.start_date, r.end_date, r.accepted_at, r.expired_at, r.amount, r.user_id, r.status_id, r.organization_id, r.message, r.created_at, r.updated_at, s.id, s.message, b.id, b.name, b.address, p.thumbnail_url, c.name, d.name, u.id, u.email, ud.name, pp.url").
 		From("reservations r").
 		Join("statuses s ON s.id = r.status_id").
 		Join("buildings b ON b.id = r.building_id").
@@ -329,10 +366,11 @@ func (r *ReservationRepositoryImpl) GetReservationByID(ctx context.Context, rese
 	var reservation entity.Reservation
 	var NullAbleAcceptedAt sql.NullTime
 	var NullAbleExpiredAt sql.NullTime
+	var NullAbleOrganizationID sql.NullString
 	var NullAbleProfilePicture entity.NullAbleProfilePicture
 	reservation.Building.Pictures = append(reservation.Building.Pictures, entity.Picture{})
 	err = rows.Scan(&reservation.ID, &reservation.CompanyName, &reservation.BuildingID, &reservation.StartDate, &reservation.EndDate, &NullAbleAcceptedAt, &NullAbleExpiredAt, &reservation.Amount,
-		&reservation.UserID, &reservation.StatusID, &reservation.Message, &reservation.CreatedAt, &reservation.UpdatedAt, &reservation.Status.ID, &reservation.Status.Message,
+		&reservation.UserID, &reservation.StatusID, &NullAbleOrganizationID, &reservation.Message, &reservation.CreatedAt, &reservation.UpdatedAt, &reservation.Status.ID, &reservation.Status.Message,
 		&reservation.Building.ID, &reservation.Building.Name, &reservation.Building.Address, &reservation.Building.Pictures[0].ThumbnailUrl,
 		&reservation.Building.City.Name, &reservation.Building.District.Name, &reservation.User.ID, &reservation.User.Email, &reservation.User.Detail.Name,
 		&NullAbleProfilePicture.Url)
@@ -342,6 +380,7 @@ func (r *ReservationRepositoryImpl) GetReservationByID(ctx context.Context, rese
 
 	reservation.AcceptedAt = NullAbleAcceptedAt.Time
 	reservation.ExpiredAt = NullAbleExpiredAt.Time
+	reservation.OrganizationID = NullAbleOrganizationID.String
 	reservation.User.Detail.Picture = NullAbleProfilePicture.ConvertToProfilePicture()
 
 	return &reservation, nil
@@ -425,60 +464,37 @@ func (r *ReservationRepositoryImpl) GetReservationCountByStatus(ctx context.Cont
 	return &stat, nil
 }
 
-func (r *ReservationRepositoryImpl) GetReservationCountByTime(ctx context.Context) (*entity.TimeframeStat, error) {
-	rows, err := r.db.WithContext(ctx).
-		Table(
-			"(?) AS today, (?) AS thisWeek, (?) AS thisMonth, (?) AS thisYear, (?) AS allTime",
-			r.db.Table("reservations").Select("count(*)").Where("DATE(created_at) = DATE(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("count(*)").Where("YEARWEEK(created_at) = YEARWEEK(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("count(*)").Where("MONTH(created_at) = MONTH(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("count(*)").Where("YEAR(created_at) = YEAR(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("count(*)").Where("deleted_at IS NULL"),
-		).Rows()
+func (r *ReservationRepositoryImpl) GetReservationSeries(ctx context.Context, tr *timeseries.Range) (*entity.TimeBucketStats, error) {
+	stats := new(entity.TimeBucketStats)
+	err := r.db.WithContext(ctx).
+		Model(&entity.Reservation{}).
+		Select(tr.Bucket("created_at")+" AS bucket, COUNT(*) AS total").
+		Where("created_at >= ? AND created_at < ?", tr.Start, tr.End).
+		Group("bucket").
+		Scan(stats).Error
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = rows.Close()
-	}()
 
-	stat := new(entity.TimeframeStat)
-	for rows.Next() {
-		err = rows.Scan(&stat.Day, &stat.Week, &stat.Month, &stat.Year, &stat.All)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return stat, nil
+	return stats, nil
 }
 
-func (r *ReservationRepositoryImpl) GetTotalRevenue(ctx context.Context) (*entity.TimeframeStat, error) {
-	rows, err := r.db.WithContext(ctx).
-		Table(
-			"(?) AS today, (?) AS thisWeek, (?) AS thisMonth, (?) AS thisYear, (?) AS allTime",
-			r.db.Table("reservations").Select("SUM(amount)").Where("status_id >= 5").Where("DATE(created_at) = DATE(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("SUM(amount)").Where("status_id >= 5").Where("YEARWEEK(created_at) = YEARWEEK(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("SUM(amount)").Where("status_id >= 5").Where("MONTH(created_at) = MONTH(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("SUM(amount)").Where("status_id >= 5").Where("YEAR(created_at) = YEAR(?)", time.Now().Format("2006-01-02")).Where("deleted_at IS NULL"),
-			r.db.Table("reservations").Select("SUM(amount)").Where("status_id >= 5").Where("deleted_at IS NULL"),
-		).Rows()
+func (r *ReservationRepositoryImpl) GetRevenueSeries(ctx context.Context, tr *timeseries.Range) (*entity.TimeBucketStats, error) {
+	// only paid reservations count as revenue
+	status := []int{constant.ACTIVE_STATUS, constant.COMPLETED_STATUS}
+	stats := new(entity.TimeBucketStats)
+	err := r.db.WithContext(ctx).
+		Model(&entity.Reservation{}).
+		Select(tr.Bucket("created_at")+" AS bucket, SUM(amount) AS total").
+		Where("status_id IN ?", status).
+		Where("created_at >= ? AND created_at < ?", tr.Start, tr.End).
+		Group("bucket").
+		Scan(stats).Error
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = rows.Close()
-	}()
-
-	stat := new(entity.TimeframeStat)
-	for rows.Next() {
-		err = rows.Scan(&stat.Day, &stat.Week, &stat.Month, &stat.Year, &stat.All)
-		if err != nil {
-			return nil, err
-		}
-	}
 
-	return stat, nil
+	return stats, nil
 }
 
 func (r *ReservationRepositoryImpl) AddBuildingReservation(ctx context.Context, reservation *entity.Reservation) error {
@@ -497,6 +513,56 @@ func (r *ReservationRepositoryImpl) AddBuildingReservation(ctx context.Context,
 	return nil
 }
 
+// AddOrganizationReservation locks the organization while its monthly spending is checked against the policies
+// and the reservation is added, a concurrent booking waits for the lock and sees this one in its spending
+func (r *ReservationRepositoryImpl) AddOrganizationReservation(ctx context.Context, reservation *entity.Reservation, checkPolicies func(reservation *entity.Reservation, spending int64) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		organization := new(entity.Organization)
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", reservation.OrganizationID).
+			First(organization).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return err2.ErrOrganizationNotFound
+			}
+			return err
+		}
+
+		// rejected and canceled reservations don't count toward the budget
+		var spending sql.NullInt64
+		now := time.Now()
+		err = tx.Model(&entity.Reservation{}).
+			Select("SUM(amount)").
+			Where("organization_id = ?", reservation.OrganizationID).
+			Where("status_id NOT IN (?)", []int{constant.REJECTED_STATUS, constant.CANCELED_STATUS}).
+			Where("YEAR(created_at) = YEAR(?) AND MONTH(created_at) = MONTH(?)", now, now).
+			Scan(&spending).Error
+		if err != nil {
+			return err
+		}
+
+		err = checkPolicies(reservation, spending.Int64)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(reservation).Error
+		if err != nil {
+			switch {
+			case strings.Contains(err.Error(), "CONSTRAINT `fk_reservations_building`"):
+				return err2.ErrBuildingNotFound
+			case strings.Contains(err.Error(), "CONSTRAINT `fk_reservations_user`"):
+				return err2.ErrInvalidUserID
+			default:
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (r *ReservationRepositoryImpl) UpdateReservation(ctx context.Context, reservation *entity.Reservation) error {
 	res := r.db.WithContext(ctx).
 		Model(entity.Reservation{}).
@@ -587,32 +653,7 @@ func (r *ReservationRepositoryImpl) AddReservationReviews(ctx context.Context, r
 			}
 		}
 
-		// get current count and average rating of the building
-		stat := struct {
-			Count int     `gorm:"column:count"`
-			Avg   float64 `gorm:"column:avg"`
-		}{}
-		err = tx.Table("reviews").
-			Where("building_id = ?", review.BuildingID).
-			Where("deleted_at IS NULL").
-			Select("COUNT(id) AS count, AVG(rating) AS avg").
-			Scan(&stat).Error
-		if err != nil {
-			return err
-		}
-
-		// update building rating
-		err = tx.Model(&entity.Building{}).
-			Where("id = ?", review.BuildingID).
-			Updates(&entity.Building{
-				Rating:      stat.Avg,
-				ReviewCount: stat.Count,
-			}).Error
-		if err != nil {
-			return err
-		}
-
-		return nil
+		return reviewRepo.UpdateBuildingRating(tx, review.BuildingID)
 	})
 
 	if err != nil {
@@ -640,20 +681,193 @@ func (r *ReservationRepositoryImpl) UpdateReservationReviews(ctx context.Context
 			return err2.ErrReviewNotFound
 		}
 
-		// get current average rating of the building
-		var avg float64
-		err := tx.Table("reviews").
-			Where("building_id = ?", review.BuildingID).
-			Where("deleted_at IS NULL").
-			Select("AVG(rating) AS avg").
-			Scan(&avg).Error
+		return reviewRepo.UpdateBuildingRating(tx, review.BuildingID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReservationRepositoryImpl) GetReservationTransferByID(ctx context.Context, transferID string) (*entity.ReservationTransfer, error) {
+	transfer := new(entity.ReservationTransfer)
+	err := r.db.WithContext(ctx).
+		Preload("Reservation.Building").
+		Preload("FromUser.Detail.Picture").
+		Preload("ToUser.Detail.Picture").
+		Where("id = ?", transferID).
+		First(transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrTransferNotFound
+		}
+
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (r *ReservationRepositoryImpl) GetPendingReservationTransfer(ctx context.Context, reservationID string) (*entity.ReservationTransfer, error) {
+	transfer := new(entity.ReservationTransfer)
+	err := r.db.WithContext(ctx).
+		Preload("Reservation.Building").
+		Preload("FromUser.Detail.Picture").
+		Preload("ToUser.Detail.Picture").
+		Where("reservation_id = ?", reservationID).
+		Where("status = ?", constant.TRANSFER_PENDING_STATUS).
+		First(transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrTransferNotFound
+		}
+
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (r *ReservationRepositoryImpl) GetReservationTransfers(ctx context.Context, reservationID string) (*entity.ReservationTransfers, error) {
+	transfers := new(entity.ReservationTransfers)
+	err := r.db.WithContext(ctx).
+		Preload("Reservation.Building").
+		Preload("FromUser.Detail.Picture").
+		Preload("ToUser.Detail.Picture").
+		Where("reservation_id = ?", reservationID).
+		Order("created_at DESC").
+		Find(transfers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (r *ReservationRepositoryImpl) GetUserReservationTransfers(ctx context.Context, userID string) (*entity.ReservationTransfers, error) {
+	transfers := new(entity.ReservationTransfers)
+	err := r.db.WithContext(ctx).
+		Preload("Reservation.Building").
+		Preload("FromUser.Detail.Picture").
+		Preload("ToUser.Detail.Picture").
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(transfers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (r *ReservationRepositoryImpl) AddReservationTransfer(ctx context.Context, transfer *entity.ReservationTransfer) error {
+	err := r.db.WithContext(ctx).Create(transfer).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_reservation_transfers_reservation`"):
+			return err2.ErrReservationNotFound
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_reservation_transfers_to_user`"):
+			return err2.ErrUserNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ReservationRepositoryImpl) UpdateReservationTransferStatus(ctx context.Context, transferID string, status string) error {
+	res := r.db.WithContext(ctx).
+		Model(&entity.ReservationTransfer{}).
+		Where("id = ?", transferID).
+		Where("status = ?", constant.TRANSFER_PENDING_STATUS).
+		Updates(&entity.ReservationTransfer{
+			Status:      status,
+			RespondedAt: time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrTransferNotPending
+	}
+
+	return nil
+}
+
+func (r *ReservationRepositoryImpl) AcceptReservationTransfer(ctx context.Context, transfer *entity.ReservationTransfer) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// lock the reservation so the ownership can't be changed concurrently
+		reservation := new(entity.Reservation)
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", transfer.ReservationID).
+			First(reservation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return err2.ErrReservationNotFound
+			}
+			return err
+		}
+
+		if reservation.UserID != transfer.FromUserID {
+			return err2.ErrTransferNotPending
+		}
+
+		// an organization reservation counts toward the spending of the organization, it can't leave it
+		if reservation.OrganizationID != "" {
+			return err2.ErrReservationNotTransferable
+		}
+
+		// the transfer may have been requested long before, the lease must still be running when it's accepted
+		switch reservation.StatusID {
+		case constant.PENDING_STATUS, constant.AWAITING_PAYMENT_STATUS, constant.ACTIVE_STATUS:
+			if !reservation.EndDate.After(time.Now()) {
+				return err2.ErrReservationNotTransferable
+			}
+		default:
+			return err2.ErrReservationNotTransferable
+		}
+
+		// a review belongs to the stay of its author, a reviewed reservation stays with them
+		var reviewCount int64
+		err = tx.Model(&entity.Review{}).
+			Where("reservation_id = ?", transfer.ReservationID).
+			Count(&reviewCount).Error
 		if err != nil {
 			return err
 		}
 
-		err = tx.Model(&entity.Building{}).
-			Where("id = ?", review.BuildingID).
-			Update("rating", avg).Error
+		if reviewCount > 0 {
+			return err2.ErrReservationNotTransferable
+		}
+
+		res := tx.Model(&entity.ReservationTransfer{}).
+			Where("id = ?", transfer.ID).
+			Where("status = ?", constant.TRANSFER_PENDING_STATUS).
+			Updates(&entity.ReservationTransfer{
+				Status:      constant.TRANSFER_ACCEPTED_STATUS,
+				RespondedAt: time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return err2.ErrTransferNotPending
+		}
+
+		// the payment transaction is bound to the reservation, it follows the new owner with the user_id below
+		updated := &entity.Reservation{UserID: transfer.ToUserID}
+		if transfer.CompanyName != "" {
+			updated.CompanyName = transfer.CompanyName
+		}
+
+		err = tx.Model(&entity.Reservation{}).
+			Where("id = ?", transfer.ReservationID).
+			Updates(updated).Error
 		if err != nil {
 			return err
 		}
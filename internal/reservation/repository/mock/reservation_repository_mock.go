@@ -3,6 +3,7 @@ package mock
 import (
 	"office-booking-backend/internal/reservation/dto"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -28,6 +29,14 @@ func (r *ReservationRepositoryMock) AddBuildingReservation(ctx context.Context,
 	return args.Error(0)
 }
 
+func (r *ReservationRepositoryMock) AddOrganizationReservation(ctx context.Context, reservation *entity.Reservation, checkPolicies func(reservation *entity.Reservation, spending int64) error) error {
+	args := r.Called(ctx, reservation)
+	if err := checkPolicies(reservation, args.Get(0).(int64)); err != nil {
+		return err
+	}
+	return args.Error(1)
+}
+
 func (r *ReservationRepositoryMock) CountUserReservation(ctx context.Context, userID string) (int64, error) {
 	args := r.Called(ctx, userID)
 	return args.Get(0).(int64), args.Error(1)
@@ -63,14 +72,14 @@ func (r *ReservationRepositoryMock) GetReservationCountByStatus(ctx context.Cont
 	return args.Get(0).(*entity.StatusesStat), args.Error(1)
 }
 
-func (r *ReservationRepositoryMock) GetReservationCountByTime(ctx context.Context) (*entity.TimeframeStat, error) {
-	args := r.Called(ctx)
-	return args.Get(0).(*entity.TimeframeStat), args.Error(1)
+func (r *ReservationRepositoryMock) GetReservationSeries(ctx context.Context, tr *timeseries.Range) (*entity.TimeBucketStats, error) {
+	args := r.Called(ctx, tr)
+	return args.Get(0).(*entity.TimeBucketStats), args.Error(1)
 }
 
-func (r *ReservationRepositoryMock) GetTotalRevenue(ctx context.Context) (*entity.TimeframeStat, error) {
-	args := r.Called(ctx)
-	return args.Get(0).(*entity.TimeframeStat), args.Error(1)
+func (r *ReservationRepositoryMock) GetRevenueSeries(ctx context.Context, tr *timeseries.Range) (*entity.TimeBucketStats, error) {
+	args := r.Called(ctx, tr)
+	return args.Get(0).(*entity.TimeBucketStats), args.Error(1)
 }
 
 func (r *ReservationRepositoryMock) GetReservationTaskUntilToday(ctx context.Context) (*entity.Reservations, error) {
@@ -92,3 +101,53 @@ func (r *ReservationRepositoryMock) GetReservations(ctx context.Context, filter
 	args := r.Called(ctx, filter)
 	return args.Get(0).(*entity.Reservations), args.Error(1)
 }
+
+func (r *ReservationRepositoryMock) GetReservationReview(ctx context.Context, reservations *entity.Reservation) (*entity.Review, error) {
+	args := r.Called(ctx, reservations)
+	return args.Get(0).(*entity.Review), args.Error(1)
+}
+
+func (r *ReservationRepositoryMock) AddReservationReviews(ctx context.Context, review *entity.Review) error {
+	args := r.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (r *ReservationRepositoryMock) UpdateReservationReviews(ctx context.Context, review *entity.Review) error {
+	args := r.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (r *ReservationRepositoryMock) GetReservationTransferByID(ctx context.Context, transferID string) (*entity.ReservationTransfer, error) {
+	args := r.Called(ctx, transferID)
+	return args.Get(0).(*entity.ReservationTransfer), args.Error(1)
+}
+
+func (r *ReservationRepositoryMock) GetPendingReservationTransfer(ctx context.Context, reservationID string) (*entity.ReservationTransfer, error) {
+	args := r.Called(ctx, reservationID)
+	return args.Get(0).(*entity.ReservationTransfer), args.Error(1)
+}
+
+func (r *ReservationRepositoryMock) GetReservationTransfers(ctx context.Context, reservationID string) (*entity.ReservationTransfers, error) {
+	args := r.Called(ctx, reservationID)
+	return args.Get(0).(*entity.ReservationTransfers), args.Error(1)
+}
+
+func (r *ReservationRepositoryMock) GetUserReservationTransfers(ctx context.Context, userID string) (*entity.ReservationTransfers, error) {
+	args := r.Called(ctx, userID)
+	return args.Get(0).(*entity.ReservationTransfers), args.Error(1)
+}
+
+func (r *ReservationRepositoryMock) AddReservationTransfer(ctx context.Context, transfer *entity.ReservationTransfer) error {
+	args := r.Called(ctx, transfer)
+	return args.Error(0)
+}
+
+func (r *ReservationRepositoryMock) UpdateReservationTransferStatus(ctx context.Context, transferID string, status string) error {
+	args := r.Called(ctx, transferID, status)
+	return args.Error(0)
+}
+
+func (r *ReservationRepositoryMock) AcceptReservationTransfer(ctx context.Context, transfer *entity.ReservationTransfer) error {
+	args := r.Called(ctx, transfer)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"office-booking-backend/internal/reservation/dto"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
 	"time"
 
 	"golang.org/x/net/context"
@@ -20,12 +21,20 @@ type ReservationRepository interface {
 	GetUserReservationByID(ctx context.Context, reservationID string, userID string) (*entity.Reservation, error)
 	GetReservationReview(ctx context.Context, reservations *entity.Reservation) (*entity.Review, error)
 	GetReservationCountByStatus(ctx context.Context) (*entity.StatusesStat, error)
-	GetReservationCountByTime(ctx context.Context) (*entity.TimeframeStat, error)
-	GetTotalRevenue(ctx context.Context) (*entity.TimeframeStat, error)
+	GetReservationSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error)
+	GetRevenueSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error)
 	GetReservationTaskUntilToday(ctx context.Context) (*entity.Reservations, error)
 	AddBuildingReservation(ctx context.Context, reservation *entity.Reservation) error
+	AddOrganizationReservation(ctx context.Context, reservation *entity.Reservation, checkPolicies func(reservation *entity.Reservation, spending int64) error) error
 	AddReservationReviews(ctx context.Context, review *entity.Review) error
 	UpdateReservation(ctx context.Context, reservation *entity.Reservation) error
 	UpdateReservationReviews(ctx context.Context, review *entity.Review) error
 	DeleteReservationByID(ctx context.Context, reservationID string) error
+	GetReservationTransferByID(ctx context.Context, transferID string) (*entity.ReservationTransfer, error)
+	GetPendingReservationTransfer(ctx context.Context, reservationID string) (*entity.ReservationTransfer, error)
+	GetReservationTransfers(ctx context.Context, reservationID string) (*entity.ReservationTransfers, error)
+	GetUserReservationTransfers(ctx context.Context, userID string) (*entity.ReservationTransfers, error)
+	AddReservationTransfer(ctx context.Context, transfer *entity.ReservationTransfer) error
+	UpdateReservationTransferStatus(ctx context.Context, transferID string, status string) error
+	AcceptReservationTransfer(ctx context.Context, transfer *entity.ReservationTransfer) error
 }
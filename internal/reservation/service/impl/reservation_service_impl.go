@@ -3,14 +3,20 @@ package impl
 import (
 	"fmt"
 	"log"
+	service2 "office-booking-backend/internal/analytics/service"
+	dto2 "office-booking-backend/internal/building/dto"
 	repository2 "office-booking-backend/internal/building/repository"
 	"office-booking-backend/internal/reservation/dto"
 	"office-booking-backend/internal/reservation/repository"
 	"office-booking-backend/internal/reservation/service"
+	repository3 "office-booking-backend/internal/user/repository"
 	"office-booking-backend/pkg/constant"
 	"office-booking-backend/pkg/custom"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/cursor"
+	"office-booking-backend/pkg/utils/mail"
+	"office-booking-backend/pkg/utils/timeseries"
 	"time"
 
 	"github.com/spf13/viper"
@@ -23,12 +29,18 @@ type ReservationServiceImpl struct {
 	config       *viper.Viper
 	repo         repository.ReservationRepository
 	buildingRepo repository2.BuildingRepository
+	userRepo     repository3.UserRepository
+	mail         mail.Client
+	analytics    service2.AnalyticsService
 }
 
-func NewReservationServiceImpl(reservationRepository repository.ReservationRepository, buildingRepository repository2.BuildingRepository, config *viper.Viper) service.ReservationService {
+func NewReservationServiceImpl(reservationRepository repository.ReservationRepository, buildingRepository repository2.BuildingRepository, userRepository repository3.UserRepository, mailClient mail.Client, analytics service2.AnalyticsService, config *viper.Viper) service.ReservationService {
 	return &ReservationServiceImpl{
 		repo:         reservationRepository,
 		buildingRepo: buildingRepository,
+		userRepo:     userRepository,
+		mail:         mailClient,
+		analytics:    analytics,
 		config:       config,
 	}
 }
@@ -77,6 +89,10 @@ func (r *ReservationServiceImpl) GetUserReservations(ctx context.Context, userID
 }
 
 func (r *ReservationServiceImpl) GetReservations(ctx context.Context, filter *dto.ReservationQueryParam) (*dto.BriefAdminReservationsResponse, int64, error) {
+	if filter.Enabled {
+		return r.getReservationsByCursor(ctx, filter)
+	}
+
 	count, err := r.repo.CountReservation(ctx, filter)
 	if err != nil {
 		log.Println("error while counting reservations: ", err)
@@ -98,6 +114,39 @@ func (r *ReservationServiceImpl) GetReservations(ctx context.Context, filter *dt
 	return reservationDto, count, nil
 }
 
+// getReservationsByCursor returns a cursor page of reservations, the total isn't counted in cursor mode
+func (r *ReservationServiceImpl) getReservationsByCursor(ctx context.Context, filter *dto.ReservationQueryParam) (*dto.BriefAdminReservationsResponse, int64, error) {
+	if err := filter.Decode(); err != nil {
+		return nil, 0, err
+	}
+
+	// the repository replaces the sort key with its column name
+	sortBy := filter.SortBy
+	reservations, err := r.repo.GetReservations(ctx, filter)
+	if err != nil {
+		log.Println("error while getting reservations: ", err)
+		return nil, 0, err
+	}
+
+	*reservations = cursor.Paginate(&filter.Pagination, *reservations, filter.Limit, func(reservation *entity.Reservation) (string, string) {
+		switch sortBy {
+		case "start_date":
+			return cursor.Time(reservation.StartDate), reservation.ID
+		case "end_date":
+			return cursor.Time(reservation.EndDate), reservation.ID
+		case "building_name":
+			return reservation.Building.Name, reservation.ID
+		case "user_name":
+			return reservation.User.Detail.Name, reservation.ID
+		default:
+			return cursor.Time(reservation.CreatedAt), reservation.ID
+		}
+	})
+
+	reservationDto := dto.NewBriefAdminReservationsResponse(reservations)
+	return reservationDto, 0, nil
+}
+
 func (r *ReservationServiceImpl) GetUserReservationByID(ctx context.Context, reservationID string, userID string) (*dto.FullReservationResponse, error) {
 	reservation, err := r.repo.GetUserReservationByID(ctx, reservationID, userID)
 	if err != nil {
@@ -120,19 +169,24 @@ func (r *ReservationServiceImpl) GetReservationByID(ctx context.Context, reserva
 	return reservationDto, nil
 }
 
-func (r *ReservationServiceImpl) GetReservationStat(ctx context.Context) (*dto.ReservationStatResponse, error) {
+func (r *ReservationServiceImpl) GetReservationStat(ctx context.Context, filter *timeseries.Query) (*dto.ReservationStatResponse, error) {
+	tr, err := filter.Range(time.Local)
+	if err != nil {
+		return nil, err
+	}
+
 	statByStatus := new(dto.ReservationTotals)
-	statByTimeframe := new(dto.TimeframeStat)
+	statByTime := new(timeseries.Series)
 
 	errGroup, c := errgroup.WithContext(ctx)
 	errGroup.Go(func() error {
-		total, err := r.repo.GetReservationCountByTime(c)
+		total, err := r.repo.GetReservationSeries(c, tr)
 		if err != nil {
-			log.Println("error while getting this year reservation count: ", err)
+			log.Println("error while getting reservation count by time: ", err)
 			return err
 		}
 
-		statByTimeframe = dto.NewTimeframeStat(total)
+		statByTime = tr.Fill(total.Values())
 		return nil
 	})
 
@@ -146,31 +200,71 @@ func (r *ReservationServiceImpl) GetReservationStat(ctx context.Context) (*dto.R
 		return nil
 	})
 
-	err := errGroup.Wait()
+	err = errGroup.Wait()
 	if err != nil {
 		return nil, err
 	}
 
 	res := &dto.ReservationStatResponse{
-		ByTimeframe: statByTimeframe,
-		ByStatus:    statByStatus,
+		ByTime:   statByTime,
+		ByStatus: statByStatus,
 	}
 
 	return res, nil
 }
 
-func (r *ReservationServiceImpl) GetTotalRevenueByTime(ctx context.Context) (*dto.TimeframeStat, error) {
-	total, err := r.repo.GetTotalRevenue(ctx)
+func (r *ReservationServiceImpl) GetTotalRevenueByTime(ctx context.Context, filter *timeseries.Query) (*timeseries.Series, error) {
+	tr, err := filter.Range(time.Local)
 	if err != nil {
-		log.Println("error while getting this year revenue: ", err)
 		return nil, err
 	}
 
-	res := dto.NewTimeframeStat(total)
-	return res, nil
+	total, err := r.repo.GetRevenueSeries(ctx, tr)
+	if err != nil {
+		log.Println("error while getting revenue by time: ", err)
+		return nil, err
+	}
+
+	return tr.Fill(total.Values()), nil
 }
 
 func (r *ReservationServiceImpl) CreateReservation(ctx context.Context, userID string, reservation *dto.AddReservartionRequest) (string, error) {
+	reservationEntity, err := r.newReservation(ctx, userID, reservation)
+	if err != nil {
+		return "", err
+	}
+
+	err = r.repo.AddBuildingReservation(ctx, reservationEntity)
+	if err != nil {
+		log.Println("error while creating reservation: ", err)
+		return "", err
+	}
+
+	r.analytics.TrackBuildingEvent(constant.RESERVATION_START_EVENT, reservation.BuildingID)
+	return reservationEntity.ID, nil
+}
+
+// CreateOrganizationReservation books on behalf of an organization. checkPolicies runs with the organization
+// locked and its monthly spending, so concurrent bookings can't overrun the budget together
+func (r *ReservationServiceImpl) CreateOrganizationReservation(ctx context.Context, userID string, organizationID string, reservation *dto.AddReservartionRequest, checkPolicies func(reservation *entity.Reservation, spending int64) error) (string, error) {
+	reservationEntity, err := r.newReservation(ctx, userID, reservation)
+	if err != nil {
+		return "", err
+	}
+
+	reservationEntity.OrganizationID = organizationID
+	err = r.repo.AddOrganizationReservation(ctx, reservationEntity, checkPolicies)
+	if err != nil {
+		log.Println("error while creating organization reservation: ", err)
+		return "", err
+	}
+
+	r.analytics.TrackBuildingEvent(constant.RESERVATION_START_EVENT, reservation.BuildingID)
+	return reservationEntity.ID, nil
+}
+
+// newReservation checks the building can be booked and returns the reservation with its amount
+func (r *ReservationServiceImpl) newReservation(ctx context.Context, userID string, reservation *dto.AddReservartionRequest) (*entity.Reservation, error) {
 	errGroup, c := errgroup.WithContext(ctx)
 	var building *entity.Building
 	errGroup.Go(func() error {
@@ -204,7 +298,11 @@ func (r *ReservationServiceImpl) CreateReservation(ctx context.Context, userID s
 
 	err := errGroup.Wait()
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if err := checkOpeningHours(building, reservation.StartDate.ToTime()); err != nil {
+		return nil, err
 	}
 
 	reservationEntity := reservation.ToEntity(userID)
@@ -213,12 +311,7 @@ func (r *ReservationServiceImpl) CreateReservation(ctx context.Context, userID s
 	ammount := building.MonthlyPrice*monthDuration + building.AnnualPrice*yearDuration
 	reservationEntity.Amount = ammount
 	fmt.Printf("year: %d, month: %d, ammount: %d", yearDuration, monthDuration, ammount)
-	err = r.repo.AddBuildingReservation(ctx, reservationEntity)
-	if err != nil {
-		log.Println("error while creating reservation: ", err)
-		return "", err
-	}
-	return reservationEntity.ID, nil
+	return reservationEntity, nil
 }
 
 func (r *ReservationServiceImpl) CreateAdminReservation(ctx context.Context, reservation *dto.AddAdminReservartionRequest) (string, error) {
@@ -257,6 +350,10 @@ func (r *ReservationServiceImpl) CreateAdminReservation(ctx context.Context, res
 		return "", err
 	}
 
+	if err := checkOpeningHours(building, reservation.StartDate.ToTime()); err != nil {
+		return "", err
+	}
+
 	reservationEntity := reservation.ToEntity()
 	yearDuration := reservation.Duration / 12
 	monthDuration := reservation.Duration - (yearDuration * 12)
@@ -271,6 +368,22 @@ func (r *ReservationServiceImpl) CreateAdminReservation(ctx context.Context, res
 	return reservationEntity.ID, nil
 }
 
+// checkOpeningHours rejects a reservation starting on a date the building is closed,
+// the start date is the move-in day so the building has to open at some time that day
+func checkOpeningHours(building *entity.Building, startDate time.Time) error {
+	schedule, err := dto2.NewOpeningSchedule(building)
+	if err != nil {
+		log.Println("error while reading building opening hours: ", err)
+		return err
+	}
+
+	if !schedule.IsOpenOn(startDate) {
+		return err2.ErrBuildingClosed
+	}
+
+	return nil
+}
+
 func (r *ReservationServiceImpl) CancelReservation(ctx context.Context, userID string, reservationID string) error {
 	reservation, err := r.repo.GetReservationByID(ctx, reservationID)
 	if err != nil {
@@ -286,16 +399,20 @@ func (r *ReservationServiceImpl) CancelReservation(ctx context.Context, userID s
 		return err2.ErrNoPermission
 	}
 
+	return r.cancelReservation(ctx, reservation)
+}
+
+func (r *ReservationServiceImpl) cancelReservation(ctx context.Context, reservation *entity.Reservation) error {
 	if reservation.StatusID == 5 {
 		return err2.ErrReservationActive
 	}
 
 	newReservation := &entity.Reservation{
-		ID:       reservationID,
+		ID:       reservation.ID,
 		StatusID: 3,
 	}
 
-	err = r.repo.UpdateReservation(ctx, newReservation)
+	err := r.repo.UpdateReservation(ctx, newReservation)
 	if err != nil {
 		log.Println("error while updating reservation: ", err)
 		return err
@@ -304,6 +421,34 @@ func (r *ReservationServiceImpl) CancelReservation(ctx context.Context, userID s
 	return nil
 }
 
+func (r *ReservationServiceImpl) GetOrganizationReservationByID(ctx context.Context, organizationID string, reservationID string) (*dto.FullAdminReservationResponse, error) {
+	reservation, err := r.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation by id: ", err)
+		return nil, err
+	}
+
+	if reservation.OrganizationID != organizationID {
+		return nil, err2.ErrReservationNotFound
+	}
+
+	return dto.NewFullAdminReservationResponse(reservation), nil
+}
+
+func (r *ReservationServiceImpl) CancelOrganizationReservation(ctx context.Context, organizationID string, reservationID string) error {
+	reservation, err := r.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation by id: ", err)
+		return err
+	}
+
+	if reservation.OrganizationID != organizationID {
+		return err2.ErrReservationNotFound
+	}
+
+	return r.cancelReservation(ctx, reservation)
+}
+
 func (r *ReservationServiceImpl) UpdateReservation(ctx context.Context, reservationID string, reservation *dto.UpdateReservationRequest) error {
 	savedReservation, err := r.repo.GetReservationByID(ctx, reservationID)
 	if err != nil {
@@ -369,6 +514,10 @@ func (r *ReservationServiceImpl) UpdateReservation(ctx context.Context, reservat
 			return err
 		}
 
+		if err := checkOpeningHours(building, startDate); err != nil {
+			return err
+		}
+
 		yearDuration := reservation.Duration / 12
 		monthDuration := reservation.Duration - (yearDuration * 12)
 		ammount := building.MonthlyPrice*monthDuration + building.AnnualPrice*yearDuration
@@ -385,19 +534,35 @@ func (r *ReservationServiceImpl) UpdateReservation(ctx context.Context, reservat
 }
 
 func (r *ReservationServiceImpl) UpdateReservationStatus(ctx context.Context, reservationID string, statusRequest *dto.UpdateReservationStatusRequest) error {
-	// 1 = pending, 2 = rejected, 3 = cancelled, 4 = awaiting payment, 5 = active, 6 = completed
+	// 1 = pending, 2 = rejected, 3 = cancelled, 4 = awaiting payment, 5 = active, 6 = completed,
+	// 7 = pending internal approval is only left through the organization approval
+	savedReservation, err := r.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation by id: ", err)
+		return err
+	}
+
+	if savedReservation.StatusID == constant.PENDING_APPROVAL_STATUS {
+		return err2.ErrReservationAwaitingApproval
+	}
+
 	reservationEntity := statusRequest.ToEntity(reservationID)
 	if statusRequest.StatusID == constant.AWAITING_PAYMENT_STATUS {
 		reservationEntity.AcceptedAt = time.Now()
 		reservationEntity.ExpiredAt = time.Now().Add(r.config.GetDuration("payment.expiredIn"))
 	}
 
-	err := r.repo.UpdateReservation(ctx, reservationEntity)
+	err = r.repo.UpdateReservation(ctx, reservationEntity)
 	if err != nil {
 		log.Println("error while updating reservation status: ", err)
 		return err
 	}
 
+	// activating a reservation completes it in the building analytics
+	if statusRequest.StatusID == constant.ACTIVE_STATUS && savedReservation.StatusID != constant.ACTIVE_STATUS {
+		r.analytics.TrackBuildingEvent(constant.RESERVATION_COMPLETION_EVENT, savedReservation.BuildingID)
+	}
+
 	return nil
 }
 
@@ -514,3 +679,222 @@ func (r *ReservationServiceImpl) UpdateReservationReview(ctx context.Context, re
 
 	return nil
 }
+
+// isReservationTransferable reports whether the lease can change hands, an organization reservation
+// stays with the organization that pays for it
+func isReservationTransferable(reservation *entity.Reservation) bool {
+	if reservation.OrganizationID != "" {
+		return false
+	}
+
+	switch reservation.StatusID {
+	case constant.PENDING_STATUS, constant.AWAITING_PAYMENT_STATUS, constant.ACTIVE_STATUS:
+		return reservation.EndDate.After(time.Now())
+	default:
+		return false
+	}
+}
+
+func (r *ReservationServiceImpl) sendTransferMail(ctx context.Context, subject string, template string, recipient string, transfer *entity.ReservationTransfer) {
+	msg := &mail.Mail{
+		Subject:  subject,
+		Template: template,
+		Variable: map[string]string{
+			"transferId":    transfer.ID,
+			"reservationId": transfer.ReservationID,
+			"buildingName":  transfer.Reservation.Building.Name,
+			"fromName":      transfer.FromUser.Detail.Name,
+			"fromEmail":     transfer.FromUser.Email,
+			"toName":        transfer.ToUser.Detail.Name,
+			"toEmail":       transfer.ToUser.Email,
+		},
+		Recipient: recipient,
+	}
+
+	// the transfer itself is already stored, so a failed notification must not fail the request
+	err := r.mail.SendMail(ctx, msg)
+	if err != nil {
+		log.Println("error while sending reservation transfer mail: ", err)
+	}
+}
+
+func (r *ReservationServiceImpl) CreateReservationTransfer(ctx context.Context, userID string, reservationID string, transfer *dto.AddReservationTransferRequest) (string, error) {
+	reservation, err := r.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation by id: ", err)
+		return "", err
+	}
+
+	if reservation.UserID != userID {
+		return "", err2.ErrNoPermission
+	}
+
+	if !isReservationTransferable(reservation) {
+		return "", err2.ErrReservationNotTransferable
+	}
+
+	recipient, err := r.userRepo.GetFullUserByEmail(ctx, transfer.Email)
+	if err != nil {
+		log.Println("error while getting recipient by email: ", err)
+		return "", err
+	}
+
+	if recipient.ID == userID {
+		return "", err2.ErrTransferToSelf
+	}
+
+	_, err = r.repo.GetPendingReservationTransfer(ctx, reservationID)
+	if err == nil {
+		return "", err2.ErrTransferAlreadyPending
+	}
+	if err != err2.ErrTransferNotFound {
+		log.Println("error while getting pending reservation transfer: ", err)
+		return "", err
+	}
+
+	transferEntity := transfer.ToEntity(reservation, recipient.ID)
+	err = r.repo.AddReservationTransfer(ctx, transferEntity)
+	if err != nil {
+		log.Println("error while adding reservation transfer: ", err)
+		return "", err
+	}
+
+	created, err := r.repo.GetReservationTransferByID(ctx, transferEntity.ID)
+	if err != nil {
+		log.Println("error while getting reservation transfer by id: ", err)
+		return transferEntity.ID, nil
+	}
+
+	r.sendTransferMail(ctx, "Reservation Transfer Request", "reservation-transfer-request", created.ToUser.Email, created)
+
+	return transferEntity.ID, nil
+}
+
+func (r *ReservationServiceImpl) GetUserReservationTransfers(ctx context.Context, userID string) (*dto.ReservationTransfersResponse, error) {
+	transfers, err := r.repo.GetUserReservationTransfers(ctx, userID)
+	if err != nil {
+		log.Println("error while getting user reservation transfers: ", err)
+		return nil, err
+	}
+
+	return dto.NewReservationTransfersResponse(transfers), nil
+}
+
+func (r *ReservationServiceImpl) GetReservationTransfers(ctx context.Context, reservationID string) (*dto.ReservationTransfersResponse, error) {
+	_, err := r.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation by id: ", err)
+		return nil, err
+	}
+
+	transfers, err := r.repo.GetReservationTransfers(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation transfers: ", err)
+		return nil, err
+	}
+
+	return dto.NewReservationTransfersResponse(transfers), nil
+}
+
+func (r *ReservationServiceImpl) AcceptReservationTransfer(ctx context.Context, userID string, transferID string) error {
+	transfer, err := r.repo.GetReservationTransferByID(ctx, transferID)
+	if err != nil {
+		log.Println("error while getting reservation transfer by id: ", err)
+		return err
+	}
+
+	if transfer.ToUserID != userID {
+		return err2.ErrNoPermission
+	}
+
+	if transfer.Status != constant.TRANSFER_PENDING_STATUS {
+		return err2.ErrTransferNotPending
+	}
+
+	if !isReservationTransferable(&transfer.Reservation) {
+		return err2.ErrReservationNotTransferable
+	}
+
+	err = r.repo.AcceptReservationTransfer(ctx, transfer)
+	if err != nil {
+		log.Println("error while accepting reservation transfer: ", err)
+		return err
+	}
+
+	r.sendTransferMail(ctx, "Reservation Transfer Accepted", "reservation-transfer-accepted", transfer.FromUser.Email, transfer)
+	r.sendTransferMail(ctx, "Reservation Transfer Accepted", "reservation-transfer-accepted", transfer.ToUser.Email, transfer)
+
+	return nil
+}
+
+func (r *ReservationServiceImpl) DeclineReservationTransfer(ctx context.Context, userID string, transferID string) error {
+	transfer, err := r.repo.GetReservationTransferByID(ctx, transferID)
+	if err != nil {
+		log.Println("error while getting reservation transfer by id: ", err)
+		return err
+	}
+
+	if transfer.ToUserID != userID {
+		return err2.ErrNoPermission
+	}
+
+	err = r.repo.UpdateReservationTransferStatus(ctx, transferID, constant.TRANSFER_DECLINED_STATUS)
+	if err != nil {
+		log.Println("error while declining reservation transfer: ", err)
+		return err
+	}
+
+	r.sendTransferMail(ctx, "Reservation Transfer Declined", "reservation-transfer-declined", transfer.FromUser.Email, transfer)
+
+	return nil
+}
+
+func (r *ReservationServiceImpl) CancelReservationTransfer(ctx context.Context, userID string, reservationID string) error {
+	transfer, err := r.repo.GetPendingReservationTransfer(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting pending reservation transfer: ", err)
+		return err
+	}
+
+	if transfer.FromUserID != userID {
+		return err2.ErrNoPermission
+	}
+
+	err = r.repo.UpdateReservationTransferStatus(ctx, transfer.ID, constant.TRANSFER_CANCELED_STATUS)
+	if err != nil {
+		log.Println("error while canceling reservation transfer: ", err)
+		return err
+	}
+
+	r.sendTransferMail(ctx, "Reservation Transfer Canceled", "reservation-transfer-canceled", transfer.ToUser.Email, transfer)
+
+	return nil
+}
+
+func (r *ReservationServiceImpl) UpdateOrganizationReservationApproval(ctx context.Context, organizationID string, reservationID string, statusID int, message string) error {
+	reservation, err := r.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		log.Println("error while getting reservation by id: ", err)
+		return err
+	}
+
+	if reservation.OrganizationID != organizationID {
+		return err2.ErrReservationNotFound
+	}
+
+	if reservation.StatusID != constant.PENDING_APPROVAL_STATUS {
+		return err2.ErrReservationNotAwaitingApproval
+	}
+
+	err = r.repo.UpdateReservation(ctx, &entity.Reservation{
+		ID:       reservationID,
+		StatusID: statusID,
+		Message:  message,
+	})
+	if err != nil {
+		log.Println("error while updating reservation: ", err)
+		return err
+	}
+
+	return nil
+}
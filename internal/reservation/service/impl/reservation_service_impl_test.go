@@ -0,0 +1,247 @@
+package impl
+
+import (
+	"context"
+	"office-booking-backend/internal/reservation/dto"
+	mockRepo "office-booking-backend/internal/reservation/repository/mock"
+	"office-booking-backend/internal/reservation/service"
+	mockUserRepo "office-booking-backend/internal/user/repository/mock"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	mockMail "office-booking-backend/pkg/utils/mail"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteReservationService struct {
+	suite.Suite
+	mockRepo           *mockRepo.ReservationRepositoryMock
+	mockUserRepo       *mockUserRepo.UserRepositoryMock
+	mockMail           *mockMail.ClientMock
+	config             *viper.Viper
+	reservationService service.ReservationService
+}
+
+func (s *TestSuiteReservationService) SetupTest() {
+	s.mockRepo = new(mockRepo.ReservationRepositoryMock)
+	s.mockUserRepo = new(mockUserRepo.UserRepositoryMock)
+	s.mockMail = new(mockMail.ClientMock)
+	s.config = viper.New()
+	s.reservationService = NewReservationServiceImpl(s.mockRepo, nil, s.mockUserRepo, s.mockMail, nil, s.config)
+}
+
+func (s *TestSuiteReservationService) TearDownTest() {
+	s.mockRepo = nil
+	s.mockUserRepo = nil
+	s.mockMail = nil
+	s.config = nil
+	s.reservationService = nil
+}
+
+func TestReservationService(t *testing.T) {
+	suite.Run(t, new(TestSuiteReservationService))
+}
+
+func pendingTransfer(statusID int, endDate time.Time) *entity.ReservationTransfer {
+	return &entity.ReservationTransfer{
+		ID:            "transfer",
+		ReservationID: "reservation",
+		FromUserID:    "from",
+		ToUserID:      "to",
+		Status:        constant.TRANSFER_PENDING_STATUS,
+		Reservation: entity.Reservation{
+			ID:       "reservation",
+			UserID:   "from",
+			StatusID: statusID,
+			EndDate:  endDate,
+		},
+	}
+}
+
+func (s *TestSuiteReservationService) TestCreateReservationTransfer_Success() {
+	s.mockRepo.On("GetReservationByID", mock.Anything, "reservation").Return(&entity.Reservation{
+		ID:       "reservation",
+		UserID:   "from",
+		StatusID: constant.ACTIVE_STATUS,
+		EndDate:  time.Now().AddDate(0, 1, 0),
+	}, nil)
+	s.mockUserRepo.On("GetFullUserByEmail", mock.Anything, "to@mail.com").Return(&entity.User{ID: "to"}, nil)
+	s.mockRepo.On("GetPendingReservationTransfer", mock.Anything, "reservation").Return((*entity.ReservationTransfer)(nil), err2.ErrTransferNotFound)
+	s.mockRepo.On("AddReservationTransfer", mock.Anything, mock.Anything).Return(nil)
+	s.mockRepo.On("GetReservationTransferByID", mock.Anything, mock.Anything).Return(pendingTransfer(constant.ACTIVE_STATUS, time.Now()), nil)
+	s.mockMail.On("SendMail", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := s.reservationService.CreateReservationTransfer(context.Background(), "from", "reservation", &dto.AddReservationTransferRequest{Email: "to@mail.com"})
+	s.NoError(err)
+}
+
+func (s *TestSuiteReservationService) TestCreateReservationTransfer_Fail() {
+	for _, tc := range []struct {
+		Name        string
+		Reservation *entity.Reservation
+		Recipient   *entity.User
+		Pending     error
+		Err         error
+	}{
+		{
+			Name:        "not owner",
+			Reservation: &entity.Reservation{UserID: "other", StatusID: constant.ACTIVE_STATUS, EndDate: time.Now().AddDate(0, 1, 0)},
+			Err:         err2.ErrNoPermission,
+		},
+		{
+			Name:        "ended reservation",
+			Reservation: &entity.Reservation{UserID: "from", StatusID: constant.ACTIVE_STATUS, EndDate: time.Now().AddDate(0, 0, -1)},
+			Err:         err2.ErrReservationNotTransferable,
+		},
+		{
+			Name:        "completed reservation",
+			Reservation: &entity.Reservation{UserID: "from", StatusID: constant.COMPLETED_STATUS, EndDate: time.Now().AddDate(0, 1, 0)},
+			Err:         err2.ErrReservationNotTransferable,
+		},
+		{
+			Name:        "organization reservation",
+			Reservation: &entity.Reservation{UserID: "from", OrganizationID: "organization", StatusID: constant.ACTIVE_STATUS, EndDate: time.Now().AddDate(0, 1, 0)},
+			Err:         err2.ErrReservationNotTransferable,
+		},
+		{
+			Name:        "transfer to self",
+			Reservation: &entity.Reservation{UserID: "from", StatusID: constant.ACTIVE_STATUS, EndDate: time.Now().AddDate(0, 1, 0)},
+			Recipient:   &entity.User{ID: "from"},
+			Err:         err2.ErrTransferToSelf,
+		},
+		{
+			Name:        "already pending",
+			Reservation: &entity.Reservation{UserID: "from", StatusID: constant.ACTIVE_STATUS, EndDate: time.Now().AddDate(0, 1, 0)},
+			Recipient:   &entity.User{ID: "to"},
+			Pending:     nil,
+			Err:         err2.ErrTransferAlreadyPending,
+		},
+	} {
+		s.Run(tc.Name, func() {
+			s.SetupTest()
+			s.mockRepo.On("GetReservationByID", mock.Anything, "reservation").Return(tc.Reservation, nil)
+			s.mockUserRepo.On("GetFullUserByEmail", mock.Anything, mock.Anything).Return(tc.Recipient, nil)
+			s.mockRepo.On("GetPendingReservationTransfer", mock.Anything, "reservation").Return(&entity.ReservationTransfer{}, tc.Pending)
+
+			_, err := s.reservationService.CreateReservationTransfer(context.Background(), "from", "reservation", &dto.AddReservationTransferRequest{Email: "to@mail.com"})
+			s.Equal(tc.Err, err)
+			s.mockRepo.AssertNotCalled(s.T(), "AddReservationTransfer", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (s *TestSuiteReservationService) TestAcceptReservationTransfer_Success() {
+	transfer := pendingTransfer(constant.ACTIVE_STATUS, time.Now().AddDate(0, 1, 0))
+	s.mockRepo.On("GetReservationTransferByID", mock.Anything, "transfer").Return(transfer, nil)
+	s.mockRepo.On("AcceptReservationTransfer", mock.Anything, transfer).Return(nil)
+	s.mockMail.On("SendMail", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.reservationService.AcceptReservationTransfer(context.Background(), "to", "transfer")
+	s.NoError(err)
+	s.mockMail.AssertNumberOfCalls(s.T(), "SendMail", 2)
+}
+
+func (s *TestSuiteReservationService) TestAcceptReservationTransfer_Fail() {
+	for _, tc := range []struct {
+		Name     string
+		Transfer *entity.ReservationTransfer
+		UserID   string
+		Err      error
+	}{
+		{
+			Name:     "not recipient",
+			Transfer: pendingTransfer(constant.ACTIVE_STATUS, time.Now().AddDate(0, 1, 0)),
+			UserID:   "other",
+			Err:      err2.ErrNoPermission,
+		},
+		{
+			Name: "not pending",
+			Transfer: func() *entity.ReservationTransfer {
+				t := pendingTransfer(constant.ACTIVE_STATUS, time.Now().AddDate(0, 1, 0))
+				t.Status = constant.TRANSFER_DECLINED_STATUS
+				return t
+			}(),
+			UserID: "to",
+			Err:    err2.ErrTransferNotPending,
+		},
+		{
+			Name:     "reservation ended after the request",
+			Transfer: pendingTransfer(constant.ACTIVE_STATUS, time.Now().Add(-time.Hour)),
+			UserID:   "to",
+			Err:      err2.ErrReservationNotTransferable,
+		},
+		{
+			Name: "organization reservation",
+			Transfer: func() *entity.ReservationTransfer {
+				t := pendingTransfer(constant.ACTIVE_STATUS, time.Now().AddDate(0, 1, 0))
+				t.Reservation.OrganizationID = "organization"
+				return t
+			}(),
+			UserID: "to",
+			Err:    err2.ErrReservationNotTransferable,
+		},
+		{
+			Name:     "reservation canceled after the request",
+			Transfer: pendingTransfer(constant.CANCELED_STATUS, time.Now().AddDate(0, 1, 0)),
+			UserID:   "to",
+			Err:      err2.ErrReservationNotTransferable,
+		},
+	} {
+		s.Run(tc.Name, func() {
+			s.SetupTest()
+			s.mockRepo.On("GetReservationTransferByID", mock.Anything, "transfer").Return(tc.Transfer, nil)
+
+			err := s.reservationService.AcceptReservationTransfer(context.Background(), tc.UserID, "transfer")
+			s.Equal(tc.Err, err)
+			s.mockRepo.AssertNotCalled(s.T(), "AcceptReservationTransfer", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (s *TestSuiteReservationService) TestAcceptReservationTransfer_RepositoryFail() {
+	transfer := pendingTransfer(constant.ACTIVE_STATUS, time.Now().AddDate(0, 1, 0))
+	s.mockRepo.On("GetReservationTransferByID", mock.Anything, "transfer").Return(transfer, nil)
+	s.mockRepo.On("AcceptReservationTransfer", mock.Anything, transfer).Return(err2.ErrReservationNotTransferable)
+
+	err := s.reservationService.AcceptReservationTransfer(context.Background(), "to", "transfer")
+	s.Equal(err2.ErrReservationNotTransferable, err)
+	s.mockMail.AssertNotCalled(s.T(), "SendMail", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReservationService) TestCancelReservationTransfer_NotSender() {
+	s.mockRepo.On("GetPendingReservationTransfer", mock.Anything, "reservation").Return(pendingTransfer(constant.ACTIVE_STATUS, time.Now()), nil)
+
+	err := s.reservationService.CancelReservationTransfer(context.Background(), "to", "reservation")
+	s.Equal(err2.ErrNoPermission, err)
+}
+
+func (s *TestSuiteReservationService) TestUpdateReservationStatus_AwaitingApproval() {
+	s.mockRepo.On("GetReservationByID", mock.Anything, "reservation").Return(&entity.Reservation{
+		ID:             "reservation",
+		OrganizationID: "organization",
+		StatusID:       constant.PENDING_APPROVAL_STATUS,
+	}, nil)
+
+	err := s.reservationService.UpdateReservationStatus(context.Background(), "reservation", &dto.UpdateReservationStatusRequest{StatusID: constant.AWAITING_PAYMENT_STATUS})
+	s.Equal(err2.ErrReservationAwaitingApproval, err)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateReservation", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReservationService) TestUpdateReservationStatus_Success() {
+	s.mockRepo.On("GetReservationByID", mock.Anything, "reservation").Return(&entity.Reservation{
+		ID:       "reservation",
+		StatusID: constant.PENDING_STATUS,
+	}, nil)
+	s.mockRepo.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.reservationService.UpdateReservationStatus(context.Background(), "reservation", &dto.UpdateReservationStatusRequest{StatusID: constant.AWAITING_PAYMENT_STATUS})
+	s.NoError(err)
+	s.mockRepo.AssertCalled(s.T(), "UpdateReservation", mock.Anything, mock.MatchedBy(func(reservation *entity.Reservation) bool {
+		return reservation.StatusID == constant.AWAITING_PAYMENT_STATUS && !reservation.AcceptedAt.IsZero()
+	}))
+}
@@ -2,6 +2,8 @@ package mock
 
 import (
 	"office-booking-backend/internal/reservation/dto"
+	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -67,14 +69,14 @@ func (r *ReservationServiceMock) GetReservations(ctx context.Context, filter *dt
 	return args.Get(0).(*dto.BriefAdminReservationsResponse), args.Get(1).(int64), args.Error(2)
 }
 
-func (r *ReservationServiceMock) GetReservationStat(ctx context.Context) (*dto.ReservationStatResponse, error) {
-	args := r.Called(ctx)
+func (r *ReservationServiceMock) GetReservationStat(ctx context.Context, filter *timeseries.Query) (*dto.ReservationStatResponse, error) {
+	args := r.Called(ctx, filter)
 	return args.Get(0).(*dto.ReservationStatResponse), args.Error(1)
 }
 
-func (r *ReservationServiceMock) GetTotalRevenueByTime(ctx context.Context) (*dto.TimeframeStat, error) {
-	args := r.Called(ctx)
-	return args.Get(0).(*dto.TimeframeStat), args.Error(1)
+func (r *ReservationServiceMock) GetTotalRevenueByTime(ctx context.Context, filter *timeseries.Query) (*timeseries.Series, error) {
+	args := r.Called(ctx, filter)
+	return args.Get(0).(*timeseries.Series), args.Error(1)
 }
 
 func (r *ReservationServiceMock) UpdateReservationStatus(ctx context.Context, reservationID string, statusRequest *dto.UpdateReservationStatusRequest) error {
@@ -96,3 +98,57 @@ func (r *ReservationServiceMock) UpdateReservationReview(ctx context.Context, re
 	args := r.Called(ctx, review, reservationID, userID)
 	return args.Error(0)
 }
+
+func (r *ReservationServiceMock) CreateReservationTransfer(ctx context.Context, userID string, reservationID string, transfer *dto.AddReservationTransferRequest) (string, error) {
+	args := r.Called(ctx, userID, reservationID, transfer)
+	return args.Get(0).(string), args.Error(1)
+}
+
+func (r *ReservationServiceMock) GetUserReservationTransfers(ctx context.Context, userID string) (*dto.ReservationTransfersResponse, error) {
+	args := r.Called(ctx, userID)
+	return args.Get(0).(*dto.ReservationTransfersResponse), args.Error(1)
+}
+
+func (r *ReservationServiceMock) GetReservationTransfers(ctx context.Context, reservationID string) (*dto.ReservationTransfersResponse, error) {
+	args := r.Called(ctx, reservationID)
+	return args.Get(0).(*dto.ReservationTransfersResponse), args.Error(1)
+}
+
+func (r *ReservationServiceMock) AcceptReservationTransfer(ctx context.Context, userID string, transferID string) error {
+	args := r.Called(ctx, userID, transferID)
+	return args.Error(0)
+}
+
+func (r *ReservationServiceMock) DeclineReservationTransfer(ctx context.Context, userID string, transferID string) error {
+	args := r.Called(ctx, userID, transferID)
+	return args.Error(0)
+}
+
+func (r *ReservationServiceMock) CancelReservationTransfer(ctx context.Context, userID string, reservationID string) error {
+	args := r.Called(ctx, userID, reservationID)
+	return args.Error(0)
+}
+
+func (r *ReservationServiceMock) GetOrganizationReservationByID(ctx context.Context, organizationID string, reservationID string) (*dto.FullAdminReservationResponse, error) {
+	args := r.Called(ctx, organizationID, reservationID)
+	return args.Get(0).(*dto.FullAdminReservationResponse), args.Error(1)
+}
+
+func (r *ReservationServiceMock) CancelOrganizationReservation(ctx context.Context, organizationID string, reservationID string) error {
+	args := r.Called(ctx, organizationID, reservationID)
+	return args.Error(0)
+}
+
+// CreateOrganizationReservation runs checkPolicies on the reservation and spending given to the mock
+func (r *ReservationServiceMock) CreateOrganizationReservation(ctx context.Context, userID string, organizationID string, reservation *dto.AddReservartionRequest, checkPolicies func(reservation *entity.Reservation, spending int64) error) (string, error) {
+	args := r.Called(ctx, userID, organizationID, reservation)
+	if err := checkPolicies(args.Get(0).(*entity.Reservation), args.Get(1).(int64)); err != nil {
+		return "", err
+	}
+	return args.String(2), args.Error(3)
+}
+
+func (r *ReservationServiceMock) UpdateOrganizationReservationApproval(ctx context.Context, organizationID string, reservationID string, statusID int, message string) error {
+	args := r.Called(ctx, organizationID, reservationID, statusID, message)
+	return args.Error(0)
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"office-booking-backend/internal/reservation/dto"
+	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
 	"time"
 
 	"golang.org/x/net/context"
@@ -13,16 +15,26 @@ type ReservationService interface {
 	GetReservations(ctx context.Context, filter *dto.ReservationQueryParam) (*dto.BriefAdminReservationsResponse, int64, error)
 	GetUserReservationByID(ctx context.Context, userID string, reservationID string) (*dto.FullReservationResponse, error)
 	GetUserReservations(ctx context.Context, userID string, page int, limit int) (*dto.BriefReservationsResponse, int64, error)
-	GetReservationStat(ctx context.Context) (*dto.ReservationStatResponse, error)
-	GetTotalRevenueByTime(ctx context.Context) (*dto.TimeframeStat, error)
+	GetReservationStat(ctx context.Context, filter *timeseries.Query) (*dto.ReservationStatResponse, error)
+	GetTotalRevenueByTime(ctx context.Context, filter *timeseries.Query) (*timeseries.Series, error)
 	GetReservationReview(ctx context.Context, reservationID string, userID string) (*dto.BriefReviewResponse, error)
 	IsBuildingAvailable(ctx context.Context, buildingID string, startDate time.Time, duration int) (bool, error)
 	CreateReservation(ctx context.Context, userID string, reservation *dto.AddReservartionRequest) (string, error)
+	CreateOrganizationReservation(ctx context.Context, userID string, organizationID string, reservation *dto.AddReservartionRequest, checkPolicies func(reservation *entity.Reservation, spending int64) error) (string, error)
 	CreateAdminReservation(ctx context.Context, reservation *dto.AddAdminReservartionRequest) (string, error)
 	CreateReservationReview(ctx context.Context, review *dto.AddReviewRequest, reservationID string, userID string) error
 	CancelReservation(ctx context.Context, userID string, reservationID string) error
+	GetOrganizationReservationByID(ctx context.Context, organizationID string, reservationID string) (*dto.FullAdminReservationResponse, error)
+	CancelOrganizationReservation(ctx context.Context, organizationID string, reservationID string) error
+	UpdateOrganizationReservationApproval(ctx context.Context, organizationID string, reservationID string, statusID int, message string) error
 	UpdateReservation(ctx context.Context, reservationID string, reservation *dto.UpdateReservationRequest) error
 	UpdateReservationStatus(ctx context.Context, reservationID string, statusRequest *dto.UpdateReservationStatusRequest) error
 	UpdateReservationReview(ctx context.Context, review *dto.UpdateReviewRequest, reservationID string, userID string) error
 	DeleteReservationByID(ctx context.Context, reservationID string) error
+	CreateReservationTransfer(ctx context.Context, userID string, reservationID string, transfer *dto.AddReservationTransferRequest) (string, error)
+	GetUserReservationTransfers(ctx context.Context, userID string) (*dto.ReservationTransfersResponse, error)
+	GetReservationTransfers(ctx context.Context, reservationID string) (*dto.ReservationTransfersResponse, error)
+	AcceptReservationTransfer(ctx context.Context, userID string, transferID string) error
+	DeclineReservationTransfer(ctx context.Context, userID string, transferID string) error
+	CancelReservationTransfer(ctx context.Context, userID string, reservationID string) error
 }
@@ -0,0 +1,306 @@
+package controller
+
+import (
+	"mime/multipart"
+	"office-booking-backend/internal/review/dto"
+	"office-booking-backend/internal/review/service"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type ReviewController struct {
+	service   service.ReviewService
+	validator validator.Validator
+}
+
+func NewReviewController(reviewService service.ReviewService, validator validator.Validator) *ReviewController {
+	return &ReviewController{
+		service:   reviewService,
+		validator: validator,
+	}
+}
+
+func (r *ReviewController) GetReviews(c *fiber.Ctx) error {
+	filter := new(dto.ReviewQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := r.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	reviews, total, err := r.service.GetReviews(c.Context(), filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "reviews fetched successfully",
+		Data:    reviews,
+		Meta: fiber.Map{
+			"total": total,
+			"page":  filter.Page,
+			"limit": filter.Limit,
+		},
+	})
+}
+
+func (r *ReviewController) GetReviewByID(c *fiber.Ctx) error {
+	reviewID := c.Params("reviewID")
+	review, err := r.service.GetReviewByID(c.Context(), reviewID)
+	if err != nil {
+		if err == err2.ErrReviewNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "review fetched successfully",
+		Data:    review,
+	})
+}
+
+func (r *ReviewController) ReportReview(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	reviewID := c.Params("reviewID")
+
+	report := new(dto.ReportReviewRequest)
+	if err := c.BodyParser(report); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*report); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := r.service.ReportReview(c.Context(), reviewID, userID, report)
+	if err != nil {
+		switch err {
+		case err2.ErrReviewNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrReviewAlreadyReported:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "review reported successfully",
+	})
+}
+
+func (r *ReviewController) ReplyReview(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+	role := int(claims["role"].(float64))
+
+	reviewID := c.Params("reviewID")
+
+	reply := new(dto.ReviewReplyRequest)
+	if err := c.BodyParser(reply); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*reply); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := r.service.ReplyReview(c.Context(), reviewID, userID, role, reply)
+	if err != nil {
+		switch err {
+		case err2.ErrReviewNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrReviewReplyAlreadyExist:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "review replied successfully",
+	})
+}
+
+func (r *ReviewController) UpdateReviewReply(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	reviewID := c.Params("reviewID")
+
+	reply := new(dto.ReviewReplyRequest)
+	if err := c.BodyParser(reply); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*reply); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := r.service.UpdateReviewReply(c.Context(), reviewID, userID, reply)
+	if err != nil {
+		switch err {
+		case err2.ErrReviewReplyNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission, err2.ErrReviewReplyNotEditable:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "review reply updated successfully",
+	})
+}
+
+func (r *ReviewController) AddReviewPicture(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	reviewID := c.Params("reviewID")
+
+	fileHeader, err := c.FormFile("picture")
+	validatorDto := struct {
+		Picture *multipart.FileHeader `json:"picture" validate:"multipartImage"`
+	}{
+		Picture: fileHeader,
+	}
+
+	errs := r.validator.ValidateJSON(validatorDto)
+	if errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+	defer file.Close()
+
+	result, err := r.service.AddReviewPicture(c.Context(), reviewID, userID, file)
+	if err != nil {
+		switch err {
+		case err2.ErrReviewNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case err2.ErrPicureLimitExceeded:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "review picture uploaded successfully",
+		Data:    result,
+	})
+}
+
+func (r *ReviewController) DeleteReviewPicture(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	reviewID := c.Params("reviewID")
+	pictureID := c.Params("pictureID")
+
+	err := r.service.DeleteReviewPicture(c.Context(), reviewID, pictureID, userID)
+	if err != nil {
+		switch err {
+		case err2.ErrReviewNotFound, err2.ErrReviewPictureNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case err2.ErrNoPermission:
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "review picture deleted successfully",
+	})
+}
+
+func (r *ReviewController) UpdateReviewStatus(c *fiber.Ctx) error {
+	reviewID := c.Params("reviewID")
+
+	status := new(dto.UpdateReviewStatusRequest)
+	if err := c.BodyParser(status); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := r.validator.ValidateJSON(*status); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := r.service.UpdateReviewStatus(c.Context(), reviewID, status)
+	if err != nil {
+		if err == err2.ErrReviewNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "review status updated successfully",
+	})
+}
+
+func (r *ReviewController) DeleteReview(c *fiber.Ctx) error {
+	reviewID := c.Params("reviewID")
+	err := r.service.DeleteReview(c.Context(), reviewID)
+	if err != nil {
+		if err == err2.ErrReviewNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "review deleted successfully",
+	})
+}
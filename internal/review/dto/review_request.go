@@ -0,0 +1,59 @@
+package dto
+
+import "office-booking-backend/pkg/entity"
+
+const defaultReviewLimit = 20
+
+// ReviewQueryParam filters the moderation queue, the reviews with the most open reports come first
+type ReviewQueryParam struct {
+	BuildingID string `query:"buildingId" validate:"omitempty,uuid4"`
+	Status     string `query:"status" validate:"omitempty,oneof=published approved hidden"`
+	// Reported keeps the reviews with open reports only
+	Reported bool `query:"reported"`
+	Page     int  `query:"page" validate:"omitempty,gte=1"`
+	Limit    int  `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset   int  `query:"-"`
+}
+
+// SetDefault returns the first page of 20 reviews when not specified
+func (r *ReviewQueryParam) SetDefault() {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+
+	if r.Limit == 0 {
+		r.Limit = defaultReviewLimit
+	}
+
+	r.Offset = (r.Page - 1) * r.Limit
+}
+
+type ReportReviewRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam offensive off_topic fake other"`
+	Message string `json:"message" validate:"omitempty,max=255"`
+}
+
+func (r *ReportReviewRequest) ToEntity(reviewID string, userID string) *entity.ReviewReport {
+	return &entity.ReviewReport{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Reason:   r.Reason,
+		Message:  r.Message,
+	}
+}
+
+type UpdateReviewStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=approved hidden"`
+}
+
+type ReviewReplyRequest struct {
+	Message string `json:"message" validate:"required,max=2000"`
+}
+
+func (r *ReviewReplyRequest) ToEntity(reviewID string, userID string) *entity.ReviewReply {
+	return &entity.ReviewReply{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Message:  r.Message,
+	}
+}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+)
+
+type ReviewUserResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func NewReviewUserResponse(user *entity.User) *ReviewUserResponse {
+	return &ReviewUserResponse{
+		ID:    user.ID,
+		Name:  user.Detail.Name,
+		Email: user.Email,
+	}
+}
+
+type ReviewReportResponse struct {
+	ID         string             `json:"id"`
+	User       ReviewUserResponse `json:"user"`
+	Reason     string             `json:"reason"`
+	Message    string             `json:"message"`
+	ResolvedAt string             `json:"resolvedAt,omitempty"`
+	CreatedAt  string             `json:"createdAt"`
+}
+
+func NewReviewReportResponse(report *entity.ReviewReport) *ReviewReportResponse {
+	resolvedAt := ""
+	if report.ResolvedAt.Valid {
+		resolvedAt = report.ResolvedAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	return &ReviewReportResponse{
+		ID:         report.ID,
+		User:       *NewReviewUserResponse(&report.User),
+		Reason:     report.Reason,
+		Message:    report.Message,
+		ResolvedAt: resolvedAt,
+		CreatedAt:  report.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ReviewReplyResponse struct {
+	ID        string             `json:"id"`
+	User      ReviewUserResponse `json:"user"`
+	Message   string             `json:"message"`
+	CreatedAt string             `json:"createdAt"`
+	UpdatedAt string             `json:"updatedAt"`
+}
+
+func NewReviewReplyResponse(reply *entity.ReviewReply) *ReviewReplyResponse {
+	return &ReviewReplyResponse{
+		ID:        reply.ID,
+		User:      *NewReviewUserResponse(&reply.User),
+		Message:   reply.Message,
+		CreatedAt: reply.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+		UpdatedAt: reply.UpdatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type ReviewPictureResponse struct {
+	ID           string `json:"id"`
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnailUrl"`
+}
+
+func NewReviewPictureResponse(picture *entity.ReviewPicture) *ReviewPictureResponse {
+	return &ReviewPictureResponse{
+		ID:           picture.ID,
+		Url:          picture.Url,
+		ThumbnailUrl: picture.ThumbnailUrl,
+	}
+}
+
+type ReviewResponse struct {
+	ID           string             `json:"id"`
+	BuildingID   string             `json:"buildingId"`
+	BuildingName string             `json:"buildingName"`
+	User         ReviewUserResponse `json:"user"`
+	Rating       int                `json:"rating"`
+	Message      string             `json:"message"`
+	Status       string             `json:"status"`
+	// OpenReports is the number of reports waiting for moderation
+	OpenReports int                     `json:"openReports"`
+	Reports     []ReviewReportResponse  `json:"reports,omitempty"`
+	Reply       *ReviewReplyResponse    `json:"reply,omitempty"`
+	Pictures    []ReviewPictureResponse `json:"pictures,omitempty"`
+	ModeratedAt string                  `json:"moderatedAt,omitempty"`
+	CreatedAt   string                  `json:"createdAt"`
+}
+
+// NewReviewResponse returns the review with its reports, the list endpoint only loads the open reports
+func NewReviewResponse(review *entity.Review, withReports bool) *ReviewResponse {
+	moderatedAt := ""
+	if review.ModeratedAt.Valid {
+		moderatedAt = review.ModeratedAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	response := &ReviewResponse{
+		ID:           review.ID,
+		BuildingID:   review.BuildingID,
+		BuildingName: review.Building.Name,
+		User:         *NewReviewUserResponse(&review.User),
+		Rating:       review.Rating,
+		Message:      review.Message,
+		Status:       review.Status,
+		ModeratedAt:  moderatedAt,
+		CreatedAt:    review.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+
+	if review.Reply != nil {
+		response.Reply = NewReviewReplyResponse(review.Reply)
+	}
+
+	for _, picture := range review.Pictures {
+		response.Pictures = append(response.Pictures, *NewReviewPictureResponse(&picture))
+	}
+
+	for _, report := range review.Reports {
+		if !report.ResolvedAt.Valid {
+			response.OpenReports++
+		}
+
+		if withReports {
+			response.Reports = append(response.Reports, *NewReviewReportResponse(&report))
+		}
+	}
+
+	return response
+}
+
+type ReviewsResponse []ReviewResponse
+
+func NewReviewsResponse(reviews *entity.Reviews) *ReviewsResponse {
+	response := new(ReviewsResponse)
+	for _, review := range *reviews {
+		*response = append(*response, *NewReviewResponse(&review, false))
+	}
+	return response
+}
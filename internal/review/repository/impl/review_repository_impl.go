@@ -0,0 +1,316 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"office-booking-backend/internal/review/dto"
+	"office-booking-backend/internal/review/repository"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openReports counts the reports of a review waiting for moderation
+const openReports = "(SELECT COUNT(*) FROM `review_reports` WHERE `review_reports`.`review_id` = `reviews`.`id` AND `review_reports`.`resolved_at` IS NULL)"
+
+type ReviewRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReviewRepositoryImpl(db *gorm.DB) repository.ReviewRepository {
+	return &ReviewRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ReviewRepositoryImpl) GetReviews(ctx context.Context, filter *dto.ReviewQueryParam) (*entity.Reviews, int64, error) {
+	reviews := new(entity.Reviews)
+	var count int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.Review{})
+
+	if filter.BuildingID != "" {
+		query = query.Where("`reviews`.`building_id` = ?", filter.BuildingID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("`reviews`.`status` = ?", filter.Status)
+	}
+
+	if filter.Reported {
+		query = query.Where(openReports + " > 0")
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.
+		Joins("Building").
+		Preload("User.Detail").
+		Preload("Reports", "resolved_at IS NULL").
+		Preload("Reply.User.Detail").
+		Order(openReports + " DESC").
+		Order("`reviews`.`created_at` DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(reviews).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, count, nil
+}
+
+func (r *ReviewRepositoryImpl) GetReviewByID(ctx context.Context, reviewID string) (*entity.Review, error) {
+	review := new(entity.Review)
+	err := r.db.WithContext(ctx).
+		Joins("Building").
+		Preload("User.Detail").
+		Preload("Reports", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at DESC")
+		}).
+		Preload("Reports.User.Detail").
+		Preload("Reply.User.Detail").
+		Preload("Pictures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("`reviews`.`id` = ?", reviewID).
+		First(review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrReviewNotFound
+		}
+
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepositoryImpl) AddReviewReport(ctx context.Context, report *entity.ReviewReport) error {
+	err := r.db.WithContext(ctx).Create(report).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "Duplicate entry"):
+			return err2.ErrReviewAlreadyReported
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_reviews_reports`"):
+			return err2.ErrReviewNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ReviewRepositoryImpl) GetReviewReply(ctx context.Context, reviewID string) (*entity.ReviewReply, error) {
+	reply := new(entity.ReviewReply)
+	err := r.db.WithContext(ctx).
+		Where("review_id = ?", reviewID).
+		First(reply).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrReviewReplyNotFound
+		}
+
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (r *ReviewRepositoryImpl) AddReviewReply(ctx context.Context, reply *entity.ReviewReply) error {
+	err := r.db.WithContext(ctx).Create(reply).Error
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "Duplicate entry"):
+			return err2.ErrReviewReplyAlreadyExist
+		case strings.Contains(err.Error(), "CONSTRAINT `fk_reviews_reply`"):
+			return err2.ErrReviewNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ReviewRepositoryImpl) UpdateReviewReply(ctx context.Context, reply *entity.ReviewReply) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.ReviewReply{}).
+		Where("id = ?", reply.ID).
+		Update("message", reply.Message).Error
+}
+
+func (r *ReviewRepositoryImpl) CountReviewPictures(ctx context.Context, reviewID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.ReviewPicture{}).
+		Where("review_id = ?", reviewID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *ReviewRepositoryImpl) GetReviewPictureByID(ctx context.Context, reviewID string, pictureID string) (*entity.ReviewPicture, error) {
+	picture := new(entity.ReviewPicture)
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND review_id = ?", pictureID, reviewID).
+		First(picture).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrReviewPictureNotFound
+		}
+
+		return nil, err
+	}
+
+	return picture, nil
+}
+
+// AddReviewPicture adds the picture unless the review already has maxPictures, the review is locked
+// so concurrent uploads can't go over the limit
+func (r *ReviewRepositoryImpl) AddReviewPicture(ctx context.Context, picture *entity.ReviewPicture, maxPictures int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		review := new(entity.Review)
+		err := tx.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", picture.ReviewID).
+			First(review).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return err2.ErrReviewNotFound
+			}
+			return err
+		}
+
+		var count int64
+		err = tx.WithContext(ctx).
+			Model(&entity.ReviewPicture{}).
+			Where("review_id = ?", picture.ReviewID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count >= maxPictures {
+			return err2.ErrPicureLimitExceeded
+		}
+
+		err = tx.WithContext(ctx).Create(picture).Error
+		if err != nil {
+			if strings.Contains(err.Error(), "CONSTRAINT `fk_reviews_pictures`") {
+				return err2.ErrReviewNotFound
+			}
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (r *ReviewRepositoryImpl) DeleteReviewPicture(ctx context.Context, pictureID string) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", pictureID).
+		Delete(&entity.ReviewPicture{}).Error
+}
+
+// UpdateReviewStatus moderates a review, resolving its open reports and updating the building rating
+func (r *ReviewRepositoryImpl) UpdateReviewStatus(ctx context.Context, reviewID string, status string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		review := new(entity.Review)
+		err := tx.Select("id, building_id").
+			Where("id = ?", reviewID).
+			First(review).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return err2.ErrReviewNotFound
+			}
+
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&entity.Review{}).
+			Where("id = ?", reviewID).
+			Updates(map[string]interface{}{
+				"status":       status,
+				"moderated_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = resolveReports(tx, reviewID, now)
+		if err != nil {
+			return err
+		}
+
+		return repository.UpdateBuildingRating(tx, review.BuildingID)
+	})
+}
+
+func (r *ReviewRepositoryImpl) DeleteReview(ctx context.Context, reviewID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		review := new(entity.Review)
+		err := tx.Select("id, building_id").
+			Where("id = ?", reviewID).
+			First(review).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return err2.ErrReviewNotFound
+			}
+
+			return err
+		}
+
+		// the status keeps a deleted review out of the rating even if it's restored by hand
+		now := time.Now()
+		err = tx.Model(&entity.Review{}).
+			Where("id = ?", reviewID).
+			Updates(map[string]interface{}{
+				"status":       constant.REVIEW_HIDDEN_STATUS,
+				"moderated_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(review).Error
+		if err != nil {
+			return err
+		}
+
+		// the files are removed from imagekit by the service once the review is gone
+		err = tx.Where("review_id = ?", reviewID).Delete(&entity.ReviewPicture{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = resolveReports(tx, reviewID, now)
+		if err != nil {
+			return err
+		}
+
+		return repository.UpdateBuildingRating(tx, review.BuildingID)
+	})
+}
+
+func resolveReports(tx *gorm.DB, reviewID string, resolvedAt time.Time) error {
+	return tx.Model(&entity.ReviewReport{}).
+		Where("review_id = ? AND resolved_at IS NULL", reviewID).
+		Update("resolved_at", resolvedAt).Error
+}
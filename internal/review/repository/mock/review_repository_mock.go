@@ -0,0 +1,73 @@
+package mock
+
+import (
+	"context"
+	"office-booking-backend/internal/review/dto"
+	"office-booking-backend/pkg/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReviewRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ReviewRepositoryMock) GetReviews(ctx context.Context, filter *dto.ReviewQueryParam) (*entity.Reviews, int64, error) {
+	args := r.Called(ctx, filter)
+	return args.Get(0).(*entity.Reviews), args.Get(1).(int64), args.Error(2)
+}
+
+func (r *ReviewRepositoryMock) GetReviewByID(ctx context.Context, reviewID string) (*entity.Review, error) {
+	args := r.Called(ctx, reviewID)
+	return args.Get(0).(*entity.Review), args.Error(1)
+}
+
+func (r *ReviewRepositoryMock) AddReviewReport(ctx context.Context, report *entity.ReviewReport) error {
+	args := r.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (r *ReviewRepositoryMock) GetReviewReply(ctx context.Context, reviewID string) (*entity.ReviewReply, error) {
+	args := r.Called(ctx, reviewID)
+	return args.Get(0).(*entity.ReviewReply), args.Error(1)
+}
+
+func (r *ReviewRepositoryMock) AddReviewReply(ctx context.Context, reply *entity.ReviewReply) error {
+	args := r.Called(ctx, reply)
+	return args.Error(0)
+}
+
+func (r *ReviewRepositoryMock) UpdateReviewReply(ctx context.Context, reply *entity.ReviewReply) error {
+	args := r.Called(ctx, reply)
+	return args.Error(0)
+}
+
+func (r *ReviewRepositoryMock) CountReviewPictures(ctx context.Context, reviewID string) (int64, error) {
+	args := r.Called(ctx, reviewID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *ReviewRepositoryMock) GetReviewPictureByID(ctx context.Context, reviewID string, pictureID string) (*entity.ReviewPicture, error) {
+	args := r.Called(ctx, reviewID, pictureID)
+	return args.Get(0).(*entity.ReviewPicture), args.Error(1)
+}
+
+func (r *ReviewRepositoryMock) AddReviewPicture(ctx context.Context, picture *entity.ReviewPicture, maxPictures int64) error {
+	args := r.Called(ctx, picture, maxPictures)
+	return args.Error(0)
+}
+
+func (r *ReviewRepositoryMock) DeleteReviewPicture(ctx context.Context, pictureID string) error {
+	args := r.Called(ctx, pictureID)
+	return args.Error(0)
+}
+
+func (r *ReviewRepositoryMock) UpdateReviewStatus(ctx context.Context, reviewID string, status string) error {
+	args := r.Called(ctx, reviewID, status)
+	return args.Error(0)
+}
+
+func (r *ReviewRepositoryMock) DeleteReview(ctx context.Context, reviewID string) error {
+	args := r.Called(ctx, reviewID)
+	return args.Error(0)
+}
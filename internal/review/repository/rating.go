@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"database/sql"
+	"office-booking-backend/pkg/entity"
+
+	"gorm.io/gorm"
+)
+
+// UpdateBuildingRating recalculates the rating and review count of a building from its visible reviews,
+// it must run in the transaction that changes a review so both stay consistent
+func UpdateBuildingRating(tx *gorm.DB, buildingID string) error {
+	stat := struct {
+		Count int
+		Avg   sql.NullFloat64
+	}{}
+	err := tx.Model(&entity.Review{}).
+		Where("building_id = ? AND status IN ?", buildingID, entity.VisibleReviewStatus).
+		Select("COUNT(id) AS count, AVG(rating) AS avg").
+		Scan(&stat).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&entity.Building{}).
+		Where("id = ?", buildingID).
+		Updates(map[string]interface{}{
+			"rating":       stat.Avg.Float64,
+			"review_count": stat.Count,
+		}).Error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"office-booking-backend/internal/review/dto"
+	"office-booking-backend/pkg/entity"
+)
+
+type ReviewRepository interface {
+	GetReviews(ctx context.Context, filter *dto.ReviewQueryParam) (*entity.Reviews, int64, error)
+	GetReviewByID(ctx context.Context, reviewID string) (*entity.Review, error)
+	AddReviewReport(ctx context.Context, report *entity.ReviewReport) error
+	GetReviewReply(ctx context.Context, reviewID string) (*entity.ReviewReply, error)
+	AddReviewReply(ctx context.Context, reply *entity.ReviewReply) error
+	UpdateReviewReply(ctx context.Context, reply *entity.ReviewReply) error
+	CountReviewPictures(ctx context.Context, reviewID string) (int64, error)
+	GetReviewPictureByID(ctx context.Context, reviewID string, pictureID string) (*entity.ReviewPicture, error)
+	AddReviewPicture(ctx context.Context, picture *entity.ReviewPicture, maxPictures int64) error
+	DeleteReviewPicture(ctx context.Context, pictureID string) error
+	UpdateReviewStatus(ctx context.Context, reviewID string, status string) error
+	DeleteReview(ctx context.Context, reviewID string) error
+}
@@ -0,0 +1,280 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	service2 "office-booking-backend/internal/notification/service"
+	"office-booking-backend/internal/review/dto"
+	"office-booking-backend/internal/review/repository"
+	"office-booking-backend/internal/review/service"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/imagekit"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+)
+
+const defaultReviewMaxPictures = 5
+
+type ReviewServiceImpl struct {
+	repo          repository.ReviewRepository
+	notification  service2.NotificationService
+	imgKitService imagekit.ImgKitService
+	config        *viper.Viper
+}
+
+func NewReviewServiceImpl(repo repository.ReviewRepository, notification service2.NotificationService, imgKitService imagekit.ImgKitService, config *viper.Viper) service.ReviewService {
+	return &ReviewServiceImpl{
+		repo:          repo,
+		notification:  notification,
+		imgKitService: imgKitService,
+		config:        config,
+	}
+}
+
+func (r *ReviewServiceImpl) GetReviews(ctx context.Context, filter *dto.ReviewQueryParam) (*dto.ReviewsResponse, int64, error) {
+	filter.SetDefault()
+	reviews, total, err := r.repo.GetReviews(ctx, filter)
+	if err != nil {
+		log.Println("error when getting reviews: ", err)
+		return nil, 0, err
+	}
+
+	return dto.NewReviewsResponse(reviews), total, nil
+}
+
+func (r *ReviewServiceImpl) GetReviewByID(ctx context.Context, reviewID string) (*dto.ReviewResponse, error) {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review by id: ", err)
+		return nil, err
+	}
+
+	return dto.NewReviewResponse(review, true), nil
+}
+
+// ReportReview adds the review to the moderation queue, hidden reviews can't be reported
+// since users don't see them
+func (r *ReviewServiceImpl) ReportReview(ctx context.Context, reviewID string, userID string, report *dto.ReportReviewRequest) error {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review by id: ", err)
+		return err
+	}
+
+	if review.Status == constant.REVIEW_HIDDEN_STATUS {
+		return err2.ErrReviewNotFound
+	}
+
+	if review.UserID == userID {
+		return err2.ErrNoPermission
+	}
+
+	err = r.repo.AddReviewReport(ctx, report.ToEntity(reviewID, userID))
+	if err != nil {
+		log.Println("error when adding review report: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// ReplyReview posts the public reply of an admin or the owner of the building and notifies the reviewer
+func (r *ReviewServiceImpl) ReplyReview(ctx context.Context, reviewID string, userID string, role int, reply *dto.ReviewReplyRequest) error {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review by id: ", err)
+		return err
+	}
+
+	if review.Status == constant.REVIEW_HIDDEN_STATUS {
+		return err2.ErrReviewNotFound
+	}
+
+	if role != constant.ADMIN_ROLE && review.Building.CreatedByID != userID {
+		return err2.ErrNoPermission
+	}
+
+	err = r.repo.AddReviewReply(ctx, reply.ToEntity(reviewID, userID))
+	if err != nil {
+		log.Println("error when adding review reply: ", err)
+		return err
+	}
+
+	if review.UserID == "" || review.UserID == userID {
+		return nil
+	}
+
+	err = r.notification.SendNotifications(ctx, &entity.Notifications{
+		{
+			UserID:  review.UserID,
+			Type:    constant.REVIEW_REPLY_NOTIFICATION,
+			Title:   fmt.Sprintf("Your review of \"%s\" got a reply", review.Building.Name),
+			Message: reply.Message,
+			Link:    "/buildings/" + review.BuildingID,
+		},
+	})
+	if err != nil {
+		log.Println("error when sending review reply notification: ", err)
+	}
+
+	return nil
+}
+
+// UpdateReviewReply lets the author edit the reply until review.replyMaxEditable has passed
+func (r *ReviewServiceImpl) UpdateReviewReply(ctx context.Context, reviewID string, userID string, reply *dto.ReviewReplyRequest) error {
+	savedReply, err := r.repo.GetReviewReply(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review reply: ", err)
+		return err
+	}
+
+	if savedReply.UserID != userID {
+		return err2.ErrNoPermission
+	}
+
+	if time.Now().After(savedReply.CreatedAt.Add(r.config.GetDuration("review.replyMaxEditable"))) {
+		return err2.ErrReviewReplyNotEditable
+	}
+
+	savedReply.Message = reply.Message
+	err = r.repo.UpdateReviewReply(ctx, savedReply)
+	if err != nil {
+		log.Println("error when updating review reply: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// AddReviewPicture attaches a photo to the user's own review, up to review.maxPictures
+func (r *ReviewServiceImpl) AddReviewPicture(ctx context.Context, reviewID string, userID string, picture io.Reader) (*dto.ReviewPictureResponse, error) {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review by id: ", err)
+		return nil, err
+	}
+
+	if review.Status == constant.REVIEW_HIDDEN_STATUS {
+		return nil, err2.ErrReviewNotFound
+	}
+
+	if review.UserID != userID {
+		return nil, err2.ErrNoPermission
+	}
+
+	maxPictures := r.config.GetInt64("review.maxPictures")
+	if maxPictures <= 0 {
+		maxPictures = defaultReviewMaxPictures
+	}
+
+	// checked before uploading, the repository checks again while adding the picture
+	pictureCount, err := r.repo.CountReviewPictures(ctx, reviewID)
+	if err != nil {
+		log.Println("error when counting review pictures: ", err)
+		return nil, err
+	}
+
+	if pictureCount >= maxPictures {
+		return nil, err2.ErrPicureLimitExceeded
+	}
+
+	pictureKey := uuid.New().String()
+	uploadResult, err := r.imgKitService.UploadFile(ctx, picture, pictureKey, "reviews")
+	if err != nil {
+		log.Println("error when uploading file: ", err)
+		return nil, err2.ErrPictureServiceFailed
+	}
+
+	pictureEntity := &entity.ReviewPicture{
+		ID:           uploadResult.FileId,
+		Key:          pictureKey,
+		ReviewID:     reviewID,
+		BuildingID:   review.BuildingID,
+		Url:          uploadResult.Url,
+		ThumbnailUrl: uploadResult.ThumbnailUrl,
+	}
+
+	err = r.repo.AddReviewPicture(ctx, pictureEntity, maxPictures)
+	if err != nil {
+		log.Println("error when adding review picture: ", err)
+		if err := r.imgKitService.DeleteFile(ctx, uploadResult.FileId); err != nil {
+			log.Println("error when deleting file: ", err)
+		}
+		return nil, err
+	}
+
+	return dto.NewReviewPictureResponse(pictureEntity), nil
+}
+
+func (r *ReviewServiceImpl) DeleteReviewPicture(ctx context.Context, reviewID string, pictureID string, userID string) error {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review by id: ", err)
+		return err
+	}
+
+	if review.UserID != userID {
+		return err2.ErrNoPermission
+	}
+
+	picture, err := r.repo.GetReviewPictureByID(ctx, reviewID, pictureID)
+	if err != nil {
+		log.Println("error when getting review picture: ", err)
+		return err
+	}
+
+	err = r.repo.DeleteReviewPicture(ctx, picture.ID)
+	if err != nil {
+		log.Println("error when deleting review picture: ", err)
+		return err
+	}
+
+	r.deletePictureFiles(ctx, &entity.ReviewPictures{*picture})
+
+	return nil
+}
+
+// deletePictureFiles removes the files from imagekit after their rows are gone,
+// a failure only leaves an orphan file so it is logged instead of returned
+func (r *ReviewServiceImpl) deletePictureFiles(ctx context.Context, pictures *entity.ReviewPictures) {
+	for _, picture := range *pictures {
+		err := r.imgKitService.DeleteFile(ctx, picture.ID)
+		if err != nil {
+			log.Println("error when deleting review picture file: ", err)
+		}
+	}
+}
+
+func (r *ReviewServiceImpl) UpdateReviewStatus(ctx context.Context, reviewID string, status *dto.UpdateReviewStatusRequest) error {
+	err := r.repo.UpdateReviewStatus(ctx, reviewID, status.Status)
+	if err != nil {
+		log.Println("error when updating review status: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewServiceImpl) DeleteReview(ctx context.Context, reviewID string) error {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		log.Println("error when getting review by id: ", err)
+		return err
+	}
+
+	err = r.repo.DeleteReview(ctx, reviewID)
+	if err != nil {
+		log.Println("error when deleting review: ", err)
+		return err
+	}
+
+	r.deletePictureFiles(ctx, &review.Pictures)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	mockRepo "office-booking-backend/internal/review/repository/mock"
+	"office-booking-backend/internal/review/service"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/imagekit"
+	"strings"
+	"testing"
+
+	"github.com/imagekit-developer/imagekit-go/api/uploader"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteReviewService struct {
+	suite.Suite
+	mockRepo      *mockRepo.ReviewRepositoryMock
+	mockImgKit    *imagekit.ImgKitServiceMock
+	reviewService service.ReviewService
+}
+
+func (s *TestSuiteReviewService) SetupTest() {
+	s.mockRepo = new(mockRepo.ReviewRepositoryMock)
+	s.mockImgKit = new(imagekit.ImgKitServiceMock)
+	s.reviewService = NewReviewServiceImpl(s.mockRepo, nil, s.mockImgKit, viper.New())
+}
+
+func (s *TestSuiteReviewService) TearDownTest() {
+	s.mockRepo = nil
+	s.mockImgKit = nil
+	s.reviewService = nil
+}
+
+func TestReviewService(t *testing.T) {
+	suite.Run(t, new(TestSuiteReviewService))
+}
+
+func (s *TestSuiteReviewService) mockReview() {
+	s.mockRepo.On("GetReviewByID", mock.Anything, "review").Return(&entity.Review{ID: "review", UserID: "user", BuildingID: "building"}, nil)
+}
+
+func (s *TestSuiteReviewService) TestAddReviewPicture_Success() {
+	s.mockReview()
+	s.mockRepo.On("CountReviewPictures", mock.Anything, "review").Return(int64(0), nil)
+	s.mockImgKit.On("UploadFile", mock.Anything, mock.Anything, mock.Anything, "reviews").Return(&uploader.UploadResult{FileId: "uploaded"}, nil)
+	s.mockRepo.On("AddReviewPicture", mock.Anything, mock.Anything, int64(defaultReviewMaxPictures)).Return(nil)
+
+	picture, err := s.reviewService.AddReviewPicture(context.Background(), "review", "user", strings.NewReader("picture"))
+	s.NoError(err)
+	s.Equal("uploaded", picture.ID)
+	s.mockImgKit.AssertNotCalled(s.T(), "DeleteFile", mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReviewService) TestAddReviewPicture_LimitReached() {
+	s.mockReview()
+	s.mockRepo.On("CountReviewPictures", mock.Anything, "review").Return(int64(defaultReviewMaxPictures), nil)
+
+	_, err := s.reviewService.AddReviewPicture(context.Background(), "review", "user", strings.NewReader("picture"))
+	s.Equal(err2.ErrPicureLimitExceeded, err)
+	s.mockImgKit.AssertNotCalled(s.T(), "UploadFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteReviewService) TestAddReviewPicture_AddFailedDeletesUpload() {
+	for _, tc := range []struct {
+		Name string
+		Err  error
+	}{
+		{Name: "Limit Reached Concurrently", Err: err2.ErrPicureLimitExceeded},
+		{Name: "Database Error", Err: errors.New("deadlock")},
+	} {
+		s.Run(tc.Name, func() {
+			s.SetupTest()
+			s.mockReview()
+			s.mockRepo.On("CountReviewPictures", mock.Anything, "review").Return(int64(0), nil)
+			s.mockImgKit.On("UploadFile", mock.Anything, mock.Anything, mock.Anything, "reviews").Return(&uploader.UploadResult{FileId: "uploaded"}, nil)
+			s.mockImgKit.On("DeleteFile", mock.Anything, "uploaded").Return(nil)
+			s.mockRepo.On("AddReviewPicture", mock.Anything, mock.Anything, mock.Anything).Return(tc.Err)
+
+			_, err := s.reviewService.AddReviewPicture(context.Background(), "review", "user", strings.NewReader("picture"))
+			s.Equal(tc.Err, err)
+			s.mockImgKit.AssertCalled(s.T(), "DeleteFile", mock.Anything, "uploaded")
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"io"
+	"office-booking-backend/internal/review/dto"
+)
+
+type ReviewService interface {
+	GetReviews(ctx context.Context, filter *dto.ReviewQueryParam) (*dto.ReviewsResponse, int64, error)
+	GetReviewByID(ctx context.Context, reviewID string) (*dto.ReviewResponse, error)
+	ReportReview(ctx context.Context, reviewID string, userID string, report *dto.ReportReviewRequest) error
+	ReplyReview(ctx context.Context, reviewID string, userID string, role int, reply *dto.ReviewReplyRequest) error
+	UpdateReviewReply(ctx context.Context, reviewID string, userID string, reply *dto.ReviewReplyRequest) error
+	AddReviewPicture(ctx context.Context, reviewID string, userID string, picture io.Reader) (*dto.ReviewPictureResponse, error)
+	DeleteReviewPicture(ctx context.Context, reviewID string, pictureID string, userID string) error
+	UpdateReviewStatus(ctx context.Context, reviewID string, status *dto.UpdateReviewStatusRequest) error
+	DeleteReview(ctx context.Context, reviewID string) error
+}
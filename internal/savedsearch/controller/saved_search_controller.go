@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"office-booking-backend/internal/savedsearch/dto"
+	"office-booking-backend/internal/savedsearch/service"
+	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/confirm"
+	"office-booking-backend/pkg/utils/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type SavedSearchController struct {
+	service   service.SavedSearchService
+	validator validator.Validator
+}
+
+func NewSavedSearchController(savedSearchService service.SavedSearchService, validator validator.Validator) *SavedSearchController {
+	return &SavedSearchController{
+		service:   savedSearchService,
+		validator: validator,
+	}
+}
+
+func (s *SavedSearchController) GetUserSavedSearches(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	savedSearches, err := s.service.GetUserSavedSearches(c.Context(), userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "saved searches fetched successfully",
+		Data:    savedSearches,
+	})
+}
+
+func (s *SavedSearchController) GetSavedSearchByID(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	savedSearchID := c.Params("savedSearchID")
+	savedSearch, err := s.service.GetSavedSearchByID(c.Context(), userID, savedSearchID)
+	if err != nil {
+		if err == err2.ErrSavedSearchNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "saved search fetched successfully",
+		Data:    savedSearch,
+	})
+}
+
+func (s *SavedSearchController) CreateSavedSearch(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	savedSearch := new(dto.AddSavedSearchRequest)
+	if err := c.BodyParser(savedSearch); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := s.validator.ValidateJSON(*savedSearch); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	savedSearchID, err := s.service.CreateSavedSearch(c.Context(), userID, savedSearch)
+	if err != nil {
+		if err == err2.ErrUserNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.BaseResponse{
+		Message: "saved search created successfully",
+		Data: fiber.Map{
+			"savedSearchId": savedSearchID,
+		},
+	})
+}
+
+func (s *SavedSearchController) UpdateSavedSearch(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	savedSearchID := c.Params("savedSearchID")
+
+	savedSearch := new(dto.UpdateSavedSearchRequest)
+	if err := c.BodyParser(savedSearch); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidRequestBody.Error())
+	}
+
+	if errs := s.validator.ValidateJSON(*savedSearch); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidRequestBody.Error(),
+			Data:    errs,
+		})
+	}
+
+	err := s.service.UpdateSavedSearch(c.Context(), userID, savedSearchID, savedSearch)
+	if err != nil {
+		if err == err2.ErrSavedSearchNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "saved search updated successfully",
+	})
+}
+
+func (s *SavedSearchController) DeleteSavedSearch(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userID := claims["uid"].(string)
+
+	savedSearchID := c.Params("savedSearchID")
+	err := s.service.DeleteSavedSearch(c.Context(), userID, savedSearchID)
+	if err != nil {
+		if err == err2.ErrSavedSearchNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "saved search deleted successfully",
+	})
+}
+
+func (s *SavedSearchController) ConfirmUnsubscribe(c *fiber.Ctx) error {
+	return confirm.Render(c, "Unsubscribe from saved search alerts", "You will stop receiving emails about new buildings matching this search.")
+}
+
+func (s *SavedSearchController) Unsubscribe(c *fiber.Ctx) error {
+	unsubscribeToken := c.Params("token")
+	err := s.service.Unsubscribe(c.Context(), unsubscribeToken)
+	if err != nil {
+		if err == err2.ErrSavedSearchNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Message: "unsubscribed from saved search alerts successfully",
+	})
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"office-booking-backend/internal/building/dto"
+	"office-booking-backend/pkg/custom"
+	"time"
+)
+
+// SavedSearchFilter is the subset of the building search filter that can be saved
+type SavedSearchFilter struct {
+	BuildingName        string  `json:"buildingName" validate:"omitempty,min=3"`
+	CityID              int     `json:"cityId" validate:"omitempty"`
+	DistrictID          int     `json:"districtId" validate:"omitempty"`
+	AnnualPriceMin      int     `json:"annualPriceMin" validate:"omitempty,gte=0"`
+	AnnualPriceMax      int     `json:"annualPriceMax" validate:"omitempty,gte=0"`
+	MonthlyPriceMin     int     `json:"monthlyPriceMin" validate:"omitempty,gte=0"`
+	MonthlyPriceMax     int     `json:"monthlyPriceMax" validate:"omitempty,gte=0"`
+	CapacityMin         int     `json:"capacityMin" validate:"omitempty,gte=0"`
+	CapacityMax         int     `json:"capacityMax" validate:"omitempty,gte=0"`
+	FacilityCategoryIDs []int   `json:"facilityCategoryIds" validate:"omitempty,dive,gte=1"`
+	FacilityMatch       string  `json:"facilityMatch" validate:"omitempty,oneof=all any"`
+	RatingMin           float64 `json:"ratingMin" validate:"omitempty,gte=0,lte=5"`
+	ReviewCountMin      int     `json:"reviewCountMin" validate:"omitempty,gte=0"`
+	StartDate           string  `json:"startDate" validate:"required_with=Duration,omitempty,datetime=2006-01-02"`
+	Duration            int     `json:"duration" validate:"required_with=StartDate,gte=0"`
+}
+
+// ToSearchQuery converts the saved filter into the query used by the building search
+func (f *SavedSearchFilter) ToSearchQuery(limit int) *dto.SearchBuildingQueryParam {
+	query := &dto.SearchBuildingQueryParam{
+		BuildingName:        f.BuildingName,
+		CityID:              f.CityID,
+		DistrictID:          f.DistrictID,
+		AnnualPriceMin:      f.AnnualPriceMin,
+		AnnualPriceMax:      f.AnnualPriceMax,
+		MonthlyPriceMin:     f.MonthlyPriceMin,
+		MonthlyPriceMax:     f.MonthlyPriceMax,
+		CapacityMin:         f.CapacityMin,
+		CapacityMax:         f.CapacityMax,
+		FacilityCategoryIDs: f.FacilityCategoryIDs,
+		FacilityMatch:       f.FacilityMatch,
+		RatingMin:           f.RatingMin,
+		ReviewCountMin:      f.ReviewCountMin,
+		Duration:            f.Duration,
+		Page:                1,
+		Limit:               limit,
+	}
+
+	if startDate, err := time.Parse("2006-01-02", f.StartDate); err == nil {
+		query.StartDate = custom.Date(startDate)
+		query.EndDate = startDate.AddDate(0, f.Duration, 0)
+	}
+
+	return query
+}
+
+type AddSavedSearchRequest struct {
+	Name    string             `json:"name" validate:"required,min=3,max=100"`
+	Channel string             `json:"channel" validate:"required,oneof=email in_app all"`
+	Filter  *SavedSearchFilter `json:"filter" validate:"required"`
+}
+
+type UpdateSavedSearchRequest struct {
+	Name     string             `json:"name" validate:"omitempty,min=3,max=100"`
+	Channel  string             `json:"channel" validate:"omitempty,oneof=email in_app all"`
+	Filter   *SavedSearchFilter `json:"filter" validate:"omitempty"`
+	IsActive *bool              `json:"isActive" validate:"omitempty"`
+}
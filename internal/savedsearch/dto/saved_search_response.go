@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"encoding/json"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+)
+
+type SavedSearchResponse struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Channel   string             `json:"channel"`
+	IsActive  bool               `json:"isActive"`
+	Filter    *SavedSearchFilter `json:"filter"`
+	LastRunAt string             `json:"lastRunAt,omitempty"`
+	CreatedAt string             `json:"createdAt"`
+}
+
+func NewSavedSearchResponse(savedSearch *entity.SavedSearch) *SavedSearchResponse {
+	filter := new(SavedSearchFilter)
+	_ = json.Unmarshal([]byte(savedSearch.Filter), filter)
+
+	lastRunAt := ""
+	if savedSearch.LastRunAt.Valid {
+		lastRunAt = savedSearch.LastRunAt.Time.Format(constant.DATE_RESPONSE_FORMAT)
+	}
+
+	return &SavedSearchResponse{
+		ID:        savedSearch.ID,
+		Name:      savedSearch.Name,
+		Channel:   savedSearch.Channel,
+		IsActive:  savedSearch.IsActive != nil && *savedSearch.IsActive,
+		Filter:    filter,
+		LastRunAt: lastRunAt,
+		CreatedAt: savedSearch.CreatedAt.Format(constant.DATE_RESPONSE_FORMAT),
+	}
+}
+
+type SavedSearchesResponse []SavedSearchResponse
+
+func NewSavedSearchesResponse(savedSearches *entity.SavedSearches) *SavedSearchesResponse {
+	response := new(SavedSearchesResponse)
+	for _, savedSearch := range *savedSearches {
+		*response = append(*response, *NewSavedSearchResponse(&savedSearch))
+	}
+	return response
+}
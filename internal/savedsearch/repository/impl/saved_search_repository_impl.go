@@ -0,0 +1,194 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"office-booking-backend/internal/savedsearch/repository"
+	"office-booking-backend/pkg/entity"
+	err2 "office-booking-backend/pkg/errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SavedSearchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepositoryImpl(db *gorm.DB) repository.SavedSearchRepository {
+	return &SavedSearchRepositoryImpl{
+		db: db,
+	}
+}
+
+func (s *SavedSearchRepositoryImpl) GetUserSavedSearches(ctx context.Context, userID string) (*entity.SavedSearches, error) {
+	savedSearches := new(entity.SavedSearches)
+	err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(savedSearches).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return savedSearches, nil
+}
+
+func (s *SavedSearchRepositoryImpl) GetSavedSearchByID(ctx context.Context, userID string, savedSearchID string) (*entity.SavedSearch, error) {
+	savedSearch := new(entity.SavedSearch)
+	err := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", savedSearchID, userID).
+		First(savedSearch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err2.ErrSavedSearchNotFound
+		}
+
+		return nil, err
+	}
+
+	return savedSearch, nil
+}
+
+func (s *SavedSearchRepositoryImpl) GetActiveSavedSearches(ctx context.Context) (*entity.SavedSearches, error) {
+	savedSearches := new(entity.SavedSearches)
+	err := s.db.WithContext(ctx).
+		Preload("Results").
+		Preload("User.Detail").
+		Where("is_active = ?", true).
+		Find(savedSearches).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return savedSearches, nil
+}
+
+func (s *SavedSearchRepositoryImpl) AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) error {
+	err := s.db.WithContext(ctx).Create(savedSearch).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "CONSTRAINT `fk_saved_searches_user`") {
+			return err2.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *SavedSearchRepositoryImpl) UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) error {
+	res := s.db.WithContext(ctx).
+		Model(&entity.SavedSearch{}).
+		Where("id = ? AND user_id = ?", savedSearch.ID, savedSearch.UserID).
+		Updates(savedSearch)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return err2.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+func (s *SavedSearchRepositoryImpl) DeleteSavedSearch(ctx context.Context, userID string, savedSearchID string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND user_id = ?", savedSearchID, userID).
+			Delete(&entity.SavedSearch{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return err2.ErrSavedSearchNotFound
+		}
+
+		return tx.Where("saved_search_id = ?", savedSearchID).
+			Delete(&entity.SavedSearchResult{}).Error
+	})
+}
+
+func (s *SavedSearchRepositoryImpl) DeactivateSavedSearchByToken(ctx context.Context, token string) error {
+	res := s.db.WithContext(ctx).
+		Model(&entity.SavedSearch{}).
+		Where("unsubscribe_token = ?", token).
+		Update("is_active", false)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		var count int64
+		err := s.db.WithContext(ctx).
+			Model(&entity.SavedSearch{}).
+			Where("unsubscribe_token = ?", token).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return err2.ErrSavedSearchNotFound
+		}
+	}
+
+	return nil
+}
+
+func (s *SavedSearchRepositoryImpl) ReplaceSavedSearchResults(ctx context.Context, savedSearchID string, buildingIDs []string, runAt time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("saved_search_id = ?", savedSearchID).
+			Delete(&entity.SavedSearchResult{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(buildingIDs) > 0 {
+			results := make(entity.SavedSearchResults, 0, len(buildingIDs))
+			for _, buildingID := range buildingIDs {
+				results = append(results, entity.SavedSearchResult{
+					SavedSearchID: savedSearchID,
+					BuildingID:    buildingID,
+				})
+			}
+
+			err = tx.Create(&results).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.SavedSearch{}).
+			Where("id = ?", savedSearchID).
+			Update("last_run_at", runAt).Error
+	})
+}
+
+// AddSavedSearchResults adds the buildings to the ones already seen by the saved search,
+// buildings that were seen before are kept as is
+func (s *SavedSearchRepositoryImpl) AddSavedSearchResults(ctx context.Context, savedSearchID string, buildingIDs []string, runAt time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(buildingIDs) > 0 {
+			results := make(entity.SavedSearchResults, 0, len(buildingIDs))
+			for _, buildingID := range buildingIDs {
+				results = append(results, entity.SavedSearchResult{
+					SavedSearchID: savedSearchID,
+					BuildingID:    buildingID,
+				})
+			}
+
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&results).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.SavedSearch{}).
+			Where("id = ?", savedSearchID).
+			Update("last_run_at", runAt).Error
+	})
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"office-booking-backend/pkg/entity"
+	"time"
+)
+
+type SavedSearchRepository interface {
+	GetUserSavedSearches(ctx context.Context, userID string) (*entity.SavedSearches, error)
+	GetSavedSearchByID(ctx context.Context, userID string, savedSearchID string) (*entity.SavedSearch, error)
+	GetActiveSavedSearches(ctx context.Context) (*entity.SavedSearches, error)
+	AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) error
+	UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) error
+	DeleteSavedSearch(ctx context.Context, userID string, savedSearchID string) error
+	DeactivateSavedSearchByToken(ctx context.Context, token string) error
+	ReplaceSavedSearchResults(ctx context.Context, savedSearchID string, buildingIDs []string, runAt time.Time) error
+	AddSavedSearchResults(ctx context.Context, savedSearchID string, buildingIDs []string, runAt time.Time) error
+}
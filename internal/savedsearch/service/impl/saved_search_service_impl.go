@@ -0,0 +1,282 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	br "office-booking-backend/internal/building/repository"
+	ns "office-booking-backend/internal/notification/service"
+	"office-booking-backend/internal/savedsearch/dto"
+	"office-booking-backend/internal/savedsearch/repository"
+	"office-booking-backend/internal/savedsearch/service"
+	"office-booking-backend/pkg/constant"
+	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const defaultSavedSearchMaxResults = 100
+
+type SavedSearchServiceImpl struct {
+	repo                repository.SavedSearchRepository
+	buildingRepo        br.BuildingRepository
+	notificationService ns.NotificationService
+	mail                mail.Client
+	conf                *viper.Viper
+}
+
+func NewSavedSearchServiceImpl(repo repository.SavedSearchRepository, buildingRepo br.BuildingRepository, notificationService ns.NotificationService, mail mail.Client, conf *viper.Viper) service.SavedSearchService {
+	return &SavedSearchServiceImpl{
+		repo:                repo,
+		buildingRepo:        buildingRepo,
+		notificationService: notificationService,
+		mail:                mail,
+		conf:                conf,
+	}
+}
+
+func (s *SavedSearchServiceImpl) GetUserSavedSearches(ctx context.Context, userID string) (*dto.SavedSearchesResponse, error) {
+	savedSearches, err := s.repo.GetUserSavedSearches(ctx, userID)
+	if err != nil {
+		log.Println("error when getting user saved searches: ", err)
+		return nil, err
+	}
+
+	return dto.NewSavedSearchesResponse(savedSearches), nil
+}
+
+func (s *SavedSearchServiceImpl) GetSavedSearchByID(ctx context.Context, userID string, savedSearchID string) (*dto.SavedSearchResponse, error) {
+	savedSearch, err := s.repo.GetSavedSearchByID(ctx, userID, savedSearchID)
+	if err != nil {
+		log.Println("error when getting saved search by id: ", err)
+		return nil, err
+	}
+
+	return dto.NewSavedSearchResponse(savedSearch), nil
+}
+
+func (s *SavedSearchServiceImpl) CreateSavedSearch(ctx context.Context, userID string, savedSearch *dto.AddSavedSearchRequest) (string, error) {
+	filter, err := json.Marshal(savedSearch.Filter)
+	if err != nil {
+		log.Println("error when encoding saved search filter: ", err)
+		return "", err
+	}
+
+	savedSearchEntity := &entity.SavedSearch{
+		UserID:  userID,
+		Name:    savedSearch.Name,
+		Filter:  string(filter),
+		Channel: savedSearch.Channel,
+	}
+
+	err = s.repo.AddSavedSearch(ctx, savedSearchEntity)
+	if err != nil {
+		log.Println("error when adding saved search: ", err)
+		return "", err
+	}
+
+	// store the current matches as the baseline so only buildings added afterwards are notified,
+	// if it fails the next scheduled run will create the baseline instead
+	s.refreshBaseline(ctx, savedSearchEntity.ID, savedSearch.Filter)
+
+	return savedSearchEntity.ID, nil
+}
+
+func (s *SavedSearchServiceImpl) UpdateSavedSearch(ctx context.Context, userID string, savedSearchID string, savedSearch *dto.UpdateSavedSearchRequest) error {
+	savedSearchEntity := &entity.SavedSearch{
+		ID:       savedSearchID,
+		UserID:   userID,
+		Name:     savedSearch.Name,
+		Channel:  savedSearch.Channel,
+		IsActive: savedSearch.IsActive,
+	}
+
+	if savedSearch.Filter != nil {
+		filter, err := json.Marshal(savedSearch.Filter)
+		if err != nil {
+			log.Println("error when encoding saved search filter: ", err)
+			return err
+		}
+
+		savedSearchEntity.Filter = string(filter)
+	}
+
+	err := s.repo.UpdateSavedSearch(ctx, savedSearchEntity)
+	if err != nil {
+		log.Println("error when updating saved search: ", err)
+		return err
+	}
+
+	if savedSearch.Filter != nil {
+		s.refreshBaseline(ctx, savedSearchID, savedSearch.Filter)
+	}
+
+	return nil
+}
+
+func (s *SavedSearchServiceImpl) DeleteSavedSearch(ctx context.Context, userID string, savedSearchID string) error {
+	err := s.repo.DeleteSavedSearch(ctx, userID, savedSearchID)
+	if err != nil {
+		log.Println("error when deleting saved search: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *SavedSearchServiceImpl) Unsubscribe(ctx context.Context, token string) error {
+	err := s.repo.DeactivateSavedSearchByToken(ctx, token)
+	if err != nil {
+		log.Println("error when unsubscribing saved search: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// RunSavedSearches re-runs every active saved search and notifies the owner about buildings
+// the saved search hasn't seen before
+func (s *SavedSearchServiceImpl) RunSavedSearches(ctx context.Context) error {
+	savedSearches, err := s.repo.GetActiveSavedSearches(ctx)
+	if err != nil {
+		log.Println("error when getting active saved searches: ", err)
+		return err
+	}
+
+	for _, savedSearch := range *savedSearches {
+		err := s.runSavedSearch(ctx, &savedSearch)
+		if err != nil {
+			log.Println("error when running saved search "+savedSearch.ID+": ", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SavedSearchServiceImpl) runSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) error {
+	filter := new(dto.SavedSearchFilter)
+	err := json.Unmarshal([]byte(savedSearch.Filter), filter)
+	if err != nil {
+		return err
+	}
+
+	runAt := time.Now()
+	buildings, err := s.match(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]bool, len(savedSearch.Results))
+	for _, result := range savedSearch.Results {
+		previous[result.BuildingID] = true
+	}
+
+	// the seen buildings are kept across runs, the matches are capped so a building moving
+	// past the cap and back again isn't new
+	ids := make([]string, 0, len(*buildings))
+	newMatches := entity.Buildings{}
+	for _, building := range *buildings {
+		if !previous[building.ID] {
+			ids = append(ids, building.ID)
+			newMatches = append(newMatches, building)
+		}
+	}
+
+	err = s.repo.AddSavedSearchResults(ctx, savedSearch.ID, ids, runAt)
+	if err != nil {
+		return err
+	}
+
+	// the first run only records the baseline
+	if !savedSearch.LastRunAt.Valid || len(newMatches) == 0 {
+		return nil
+	}
+
+	s.notify(ctx, savedSearch, &newMatches)
+	return nil
+}
+
+func (s *SavedSearchServiceImpl) refreshBaseline(ctx context.Context, savedSearchID string, filter *dto.SavedSearchFilter) {
+	buildings, err := s.match(ctx, filter)
+	if err != nil {
+		log.Println("error when running saved search: ", err)
+		return
+	}
+
+	ids := make([]string, 0, len(*buildings))
+	for _, building := range *buildings {
+		ids = append(ids, building.ID)
+	}
+
+	err = s.repo.ReplaceSavedSearchResults(ctx, savedSearchID, ids, time.Now())
+	if err != nil {
+		log.Println("error when storing saved search results: ", err)
+	}
+}
+
+func (s *SavedSearchServiceImpl) match(ctx context.Context, filter *dto.SavedSearchFilter) (*entity.Buildings, error) {
+	limit := s.conf.GetInt("savedSearch.maxResults")
+	if limit <= 0 {
+		limit = defaultSavedSearchMaxResults
+	}
+
+	buildings, _, err := s.buildingRepo.GetAllBuildings(ctx, filter.ToSearchQuery(limit), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildings, nil
+}
+
+func (s *SavedSearchServiceImpl) notify(ctx context.Context, savedSearch *entity.SavedSearch, buildings *entity.Buildings) {
+	names := make([]string, 0, len(*buildings))
+	for _, building := range *buildings {
+		names = append(names, building.Name)
+	}
+
+	title := fmt.Sprintf("New offices match \"%s\"", savedSearch.Name)
+	message := strings.Join(names, ", ")
+
+	if savedSearch.Channel == constant.NOTIFY_IN_APP_CHANNEL || savedSearch.Channel == constant.NOTIFY_ALL_CHANNEL {
+		link := ""
+		if len(*buildings) == 1 {
+			link = "/buildings/" + (*buildings)[0].ID
+		}
+
+		err := s.notificationService.SendNotifications(ctx, &entity.Notifications{
+			{
+				UserID:  savedSearch.UserID,
+				Type:    constant.SAVED_SEARCH_MATCH_NOTIFICATION,
+				Title:   title,
+				Message: message,
+				Link:    link,
+			},
+		})
+		if err != nil {
+			log.Println("error when sending saved search notification: ", err)
+		}
+	}
+
+	if savedSearch.Channel == constant.NOTIFY_EMAIL_CHANNEL || savedSearch.Channel == constant.NOTIFY_ALL_CHANNEL {
+		err := s.mail.SendMail(ctx, &mail.Mail{
+			Subject:  title,
+			Template: "saved-search-match",
+			Variable: map[string]string{
+				"name":        savedSearch.User.Detail.Name,
+				"searchName":  savedSearch.Name,
+				"total":       strconv.Itoa(len(names)),
+				"buildings":   message,
+				"unsubscribe": s.conf.GetString("savedSearch.unsubscribeUrl") + savedSearch.UnsubscribeToken,
+			},
+			Recipient: savedSearch.User.Email,
+		})
+		if err != nil {
+			log.Println("error when sending saved search email: ", err)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"office-booking-backend/internal/savedsearch/dto"
+)
+
+type SavedSearchService interface {
+	GetUserSavedSearches(ctx context.Context, userID string) (*dto.SavedSearchesResponse, error)
+	GetSavedSearchByID(ctx context.Context, userID string, savedSearchID string) (*dto.SavedSearchResponse, error)
+	CreateSavedSearch(ctx context.Context, userID string, savedSearch *dto.AddSavedSearchRequest) (string, error)
+	UpdateSavedSearch(ctx context.Context, userID string, savedSearchID string, savedSearch *dto.UpdateSavedSearchRequest) error
+	DeleteSavedSearch(ctx context.Context, userID string, savedSearchID string) error
+	Unsubscribe(ctx context.Context, token string) error
+	RunSavedSearches(ctx context.Context) error
+}
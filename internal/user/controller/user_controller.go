@@ -6,6 +6,7 @@ import (
 	"office-booking-backend/internal/user/service"
 	err2 "office-booking-backend/pkg/errors"
 	"office-booking-backend/pkg/response"
+	"office-booking-backend/pkg/utils/timeseries"
 	"office-booking-backend/pkg/utils/validator"
 	"reflect"
 
@@ -70,6 +71,10 @@ func (u *UserController) GetAllUsers(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
 	}
 
+	if filter.Detect(c) {
+		filter.Page = 1
+	}
+
 	if errs := u.validator.ValidateQuery(*filter); errs != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
 			Message: err2.ErrInvalidQueryParams.Error(),
@@ -79,23 +84,48 @@ func (u *UserController) GetAllUsers(c *fiber.Ctx) error {
 
 	users, total, err := u.userService.GetAllUsers(c.Context(), filter)
 	if err != nil {
+		if err == err2.ErrInvalidCursor {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	meta := fiber.Map{
+		"limit": filter.Limit,
+		"page":  filter.Page,
+		"total": total,
+	}
+	if filter.Enabled {
+		meta = filter.Meta(filter.Limit)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Message: "user fetched successfully",
 		Data:    users,
-		Meta: fiber.Map{
-			"limit": filter.Limit,
-			"page":  filter.Page,
-			"total": total,
-		},
+		Meta:    meta,
 	})
 }
 
 func (u *UserController) GetRegisteredMemberStat(c *fiber.Ctx) error {
-	stat, err := u.userService.GetRegisteredMemberStat(c.Context())
+	filter := new(timeseries.Query)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	if errs := u.validator.ValidateQuery(filter); errs != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.BaseResponse{
+			Message: err2.ErrInvalidQueryParams.Error(),
+			Data:    errs,
+		})
+	}
+
+	filter.SetDefault(timeseries.Month)
+	stat, err := u.userService.GetRegisteredMemberStat(c.Context(), filter)
 	if err != nil {
+		if err == err2.ErrInvalidDateRange || err == err2.ErrTooManyDataPoints {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -106,8 +136,17 @@ func (u *UserController) GetRegisteredMemberStat(c *fiber.Ctx) error {
 }
 
 func (u *UserController) GetRegisteredMemberCount(c *fiber.Ctx) error {
-	count, err := u.userService.GetRegisteredMemberCount(c.Context())
+	filter := new(dto.RegisteredCountQueryParam)
+	if err := c.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err2.ErrInvalidQueryParams.Error())
+	}
+
+	count, err := u.userService.GetRegisteredMemberCount(c.Context(), filter)
 	if err != nil {
+		if err == err2.ErrInvalidDateRange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -1,9 +1,23 @@
 package dto
 
+import (
+	"office-booking-backend/pkg/custom"
+	"office-booking-backend/pkg/utils/cursor"
+)
+
 type UserFilterRequest struct {
 	Query  string `query:"q" validate:"omitempty,min=3,max=50"`
 	Role   int    `query:"role" validate:"omitempty,oneof=1 2"`
 	Page   int    `query:"page" validate:"omitempty,gte=1"`
 	Limit  int    `query:"limit" validate:"omitempty,gte=1"`
 	Offset int    `query:"-"`
+
+	cursor.Pagination
+}
+
+// RegisteredCountQueryParam optionally adds the total of the members registered between the dates inclusive,
+// a missing date leaves that side open
+type RegisteredCountQueryParam struct {
+	StartDate custom.Date `query:"startDate"`
+	EndDate   custom.Date `query:"endDate"`
 }
@@ -65,34 +65,14 @@ func NewBriefUsersResponse(users *entity.Users) *BriefUsersResponse {
 	return &briefUsers
 }
 
-type RegisteredStatResponse struct {
-	Month string `json:"month"`
-	Total int64  `json:"total"`
-}
-
-func NewRegisteredStatResponse(stat *entity.MonthlyRegisteredStat) *RegisteredStatResponse {
-	return &RegisteredStatResponse{
-		Month: stat.Month,
-		Total: stat.Total,
-	}
-}
-
-type RegisteredStatResponseList []RegisteredStatResponse
-
-func NewRegisteredStatResponseList(stats *entity.MonthlyRegisteredStatList) *RegisteredStatResponseList {
-	var statList RegisteredStatResponseList
-	for _, stat := range *stats {
-		statList = append(statList, *NewRegisteredStatResponse(&stat))
-	}
-	return &statList
-}
-
 type TotalByTimeFrame struct {
 	Today     int64 `json:"today"`
 	ThisWeek  int64 `json:"thisWeek"`
 	ThisMonth int64 `json:"thisMonth"`
 	ThisYear  int64 `json:"thisYear"`
 	AllTime   int64 `json:"allTime"`
+	// InRange is only set when a date range is requested
+	InRange *int64 `json:"inRange,omitempty"`
 }
 
 func NewTimeframeStat(stats *entity.TimeframeStat) *TotalByTimeFrame {
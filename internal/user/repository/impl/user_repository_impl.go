@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"office-booking-backend/internal/user/dto"
 	"office-booking-backend/internal/user/repository"
+	"office-booking-backend/pkg/constant"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/timeseries"
 	"strings"
 	"time"
 
@@ -91,6 +93,27 @@ func (u *UserRepositoryImpl) GetAllUsers(ctx context.Context, filter *dto.UserFi
 		query = query.Where("`Detail`.`name` LIKE ?", "%"+filter.Query+"%")
 	}
 
+	if filter.Enabled {
+		if condition, args := filter.Where("`users`.`created_at`", "`users`.`id`", true); condition != "" {
+			query = query.Where(condition, args...)
+		}
+
+		for _, order := range filter.OrderBy("`users`.`created_at`", "`users`.`id`", true) {
+			query = query.Order(order)
+		}
+
+		// one more row tells whether there's a next page, the total isn't counted in cursor mode
+		err := query.
+			Where("role = ?", filter.Role).
+			Limit(filter.Limit + 1).
+			Find(users).Error
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return users, 0, nil
+	}
+
 	err := query.
 		Where("role = ?", filter.Role).
 		Limit(filter.Limit).
@@ -104,33 +127,20 @@ func (u *UserRepositoryImpl) GetAllUsers(ctx context.Context, filter *dto.UserFi
 	return users, count, nil
 }
 
-func (u *UserRepositoryImpl) GetRegisteredMemberStat(ctx context.Context) (*entity.MonthlyRegisteredStatList, error) {
-
-	var subQuery []*gorm.DB
-	unionStr := ""
-	for i := 0; i <= 11; i++ {
-		unionStr = unionStr + "?"
-		month := time.Now().AddDate(0, i*(-1), 0).Format("2006-01-02")
-		query := u.db.Select("MONTHNAME(?) as month,COUNT(*) as total", month).
-			Table("users").
-			Where("Month(created_at) = Month(?)", month).
-			Where("deleted_at IS NULL")
-		subQuery = append(subQuery, query)
-
-		if i != 11 {
-			unionStr = unionStr + " UNION "
-		}
-	}
-
-	resStructs := new(entity.MonthlyRegisteredStatList)
+func (u *UserRepositoryImpl) GetRegisteredMemberSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error) {
+	stats := new(entity.TimeBucketStats)
 	err := u.db.WithContext(ctx).
-		Raw(unionStr, subQuery[0], subQuery[1], subQuery[2], subQuery[3], subQuery[4], subQuery[5], subQuery[6], subQuery[7], subQuery[8], subQuery[9], subQuery[10], subQuery[11]).
-		Find(&resStructs).Error
+		Model(&entity.User{}).
+		Select(r.Bucket("created_at")+" AS bucket, COUNT(*) AS total").
+		Where("role = ?", constant.USER_ROLE).
+		Where("created_at >= ? AND created_at < ?", r.Start, r.End).
+		Group("bucket").
+		Scan(stats).Error
 	if err != nil {
 		return nil, err
 	}
 
-	return resStructs, nil
+	return stats, nil
 }
 
 func (u *UserRepositoryImpl) GetRegisteredMemberCount(ctx context.Context) (*entity.TimeframeStat, error) {
@@ -160,6 +170,29 @@ func (u *UserRepositoryImpl) GetRegisteredMemberCount(ctx context.Context) (*ent
 	return stat, nil
 }
 
+// CountRegisteredMembers counts the members registered from the start until before the end, a zero time leaves that side open
+func (u *UserRepositoryImpl) CountRegisteredMembers(ctx context.Context, start time.Time, end time.Time) (int64, error) {
+	query := u.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("role = ?", constant.USER_ROLE)
+
+	if !start.IsZero() {
+		query = query.Where("created_at >= ?", start)
+	}
+
+	if !end.IsZero() {
+		query = query.Where("created_at < ?", end)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (u *UserRepositoryImpl) UpdateUserByID(ctx context.Context, user *entity.User) error {
 	res := u.db.WithContext(ctx).Where("id = ?", user.ID).Updates(user)
 	if res.Error != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"office-booking-backend/internal/user/dto"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -27,9 +29,9 @@ func (u *UserRepositoryMock) GetAllUsers(ctx context.Context, filter *dto.UserFi
 	return args.Get(0).(*entity.Users), int64(args.Int(1)), args.Error(2)
 }
 
-func (u *UserRepositoryMock) GetRegisteredMemberStat(ctx context.Context) (*entity.MonthlyRegisteredStatList, error) {
-	args := u.Called(ctx)
-	return args.Get(0).(*entity.MonthlyRegisteredStatList), args.Error(1)
+func (u *UserRepositoryMock) GetRegisteredMemberSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error) {
+	args := u.Called(ctx, r)
+	return args.Get(0).(*entity.TimeBucketStats), args.Error(1)
 }
 
 func (u *UserRepositoryMock) GetRegisteredMemberCount(ctx context.Context) (*entity.TimeframeStat, error) {
@@ -37,6 +39,11 @@ func (u *UserRepositoryMock) GetRegisteredMemberCount(ctx context.Context) (*ent
 	return args.Get(0).(*entity.TimeframeStat), args.Error(1)
 }
 
+func (u *UserRepositoryMock) CountRegisteredMembers(ctx context.Context, start time.Time, end time.Time) (int64, error) {
+	args := u.Called(ctx, start, end)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (u *UserRepositoryMock) UpdateUserByID(ctx context.Context, user *entity.User) error {
 	args := u.Called(ctx, user)
 	return args.Error(0)
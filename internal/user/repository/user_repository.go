@@ -4,6 +4,8 @@ import (
 	"context"
 	"office-booking-backend/internal/user/dto"
 	"office-booking-backend/pkg/entity"
+	"office-booking-backend/pkg/utils/timeseries"
+	"time"
 )
 
 type UserRepository interface {
@@ -11,8 +13,9 @@ type UserRepository interface {
 	GetFullUserByID(ctx context.Context, id string) (*entity.User, error)
 	GetAllUsers(ctx context.Context, filter *dto.UserFilterRequest) (*entity.Users, int64, error)
 	GetUserProfilePictureID(ctx context.Context, id string) (*entity.ProfilePicture, error)
-	GetRegisteredMemberStat(ctx context.Context) (*entity.MonthlyRegisteredStatList, error)
+	GetRegisteredMemberSeries(ctx context.Context, r *timeseries.Range) (*entity.TimeBucketStats, error)
 	GetRegisteredMemberCount(ctx context.Context) (*entity.TimeframeStat, error)
+	CountRegisteredMembers(ctx context.Context, start time.Time, end time.Time) (int64, error)
 	UpdateUserByID(ctx context.Context, user *entity.User) error
 	UpdateUserDetailByID(ctx context.Context, userDetail *entity.UserDetail) error
 	DeleteUserByID(ctx context.Context, id string) (string, error)
@@ -11,7 +11,10 @@ import (
 	"office-booking-backend/pkg/custom"
 	"office-booking-backend/pkg/entity"
 	err2 "office-booking-backend/pkg/errors"
+	"office-booking-backend/pkg/utils/cursor"
 	"office-booking-backend/pkg/utils/imagekit"
+	"office-booking-backend/pkg/utils/timeseries"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
@@ -43,6 +46,10 @@ func (u *UserServiceImpl) GetFullUserByID(ctx context.Context, id string) (*dto.
 }
 
 func (u *UserServiceImpl) GetAllUsers(ctx context.Context, filter *dto.UserFilterRequest) (*dto.BriefUsersResponse, int64, error) {
+	if err := filter.Decode(); err != nil {
+		return nil, 0, err
+	}
+
 	filter.Offset = (filter.Page - 1) * filter.Limit
 	users, total, err := u.userRepository.GetAllUsers(ctx, filter)
 	if err != nil {
@@ -50,28 +57,66 @@ func (u *UserServiceImpl) GetAllUsers(ctx context.Context, filter *dto.UserFilte
 		return nil, 0, err
 	}
 
+	if filter.Enabled {
+		*users = cursor.Paginate(&filter.Pagination, *users, filter.Limit, func(user *entity.User) (string, string) {
+			return cursor.Time(user.CreatedAt), user.ID
+		})
+	}
+
 	briefUsers := dto.NewBriefUsersResponse(users)
 	return briefUsers, total, nil
 }
 
-func (u *UserServiceImpl) GetRegisteredMemberStat(ctx context.Context) (*dto.RegisteredStatResponseList, error) {
-	stat, err := u.userRepository.GetRegisteredMemberStat(ctx)
+func (u *UserServiceImpl) GetRegisteredMemberStat(ctx context.Context, filter *timeseries.Query) (*timeseries.Series, error) {
+	r, err := filter.Range(time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := u.userRepository.GetRegisteredMemberSeries(ctx, r)
 	if err != nil {
 		log.Println("Error while getting registered member stat: ", err)
 		return nil, err
 	}
 
-	return dto.NewRegisteredStatResponseList(stat), nil
+	return r.Fill(stat.Values()), nil
 }
 
-func (u *UserServiceImpl) GetRegisteredMemberCount(ctx context.Context) (*dto.TotalByTimeFrame, error) {
+// GetRegisteredMemberCount returns the totals up to all time, and the total between the dates when one is given
+func (u *UserServiceImpl) GetRegisteredMemberCount(ctx context.Context, filter *dto.RegisteredCountQueryParam) (*dto.TotalByTimeFrame, error) {
+	startDate, endDate := filter.StartDate.ToTime(), filter.EndDate.ToTime()
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		return nil, err2.ErrInvalidDateRange
+	}
+
 	total, err := u.userRepository.GetRegisteredMemberCount(ctx)
 	if err != nil {
 		log.Println("Error while getting registered member count: ", err)
 		return nil, err
 	}
 
-	return dto.NewTimeframeStat(total), nil
+	count := dto.NewTimeframeStat(total)
+	if startDate.IsZero() && endDate.IsZero() {
+		return count, nil
+	}
+
+	// the dates are in the location the DATETIME columns are stored in, the end is exclusive for SQL
+	var start, end time.Time
+	if !startDate.IsZero() {
+		start = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if !endDate.IsZero() {
+		end = time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, time.Local)
+	}
+
+	inRange, err := u.userRepository.CountRegisteredMembers(ctx, start, end)
+	if err != nil {
+		log.Println("Error while counting registered members: ", err)
+		return nil, err
+	}
+
+	count.InRange = &inRange
+	return count, nil
 }
 
 func (u *UserServiceImpl) UpdateUserByID(ctx context.Context, id string, user *dto.UserUpdateRequest) error {
@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"database/sql"
 	mockReservationSrv "office-booking-backend/internal/reservation/service/mock"
 	"office-booking-backend/internal/user/dto"
 	mockRepo "office-booking-backend/internal/user/repository/mock"
@@ -11,6 +12,7 @@ import (
 	err2 "office-booking-backend/pkg/errors"
 	mockImageKitSrv "office-booking-backend/pkg/utils/imagekit"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -151,3 +153,38 @@ func (s *TestSuiteUserService) TestUpdateUserByID() {
 		s.TearDownTest()
 	}
 }
+
+func (s *TestSuiteUserService) TestGetRegisteredMemberCount_AllTime() {
+	s.mockRepo.On("GetRegisteredMemberCount", mock.Anything).Return(&entity.TimeframeStat{All: sql.NullInt64{Int64: 42, Valid: true}}, nil)
+
+	count, err := s.userService.GetRegisteredMemberCount(context.Background(), &dto.RegisteredCountQueryParam{})
+	s.NoError(err)
+	s.Equal(int64(42), count.AllTime)
+	s.Nil(count.InRange)
+	s.mockRepo.AssertNotCalled(s.T(), "CountRegisteredMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuiteUserService) TestGetRegisteredMemberCount_InRange() {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local)
+	s.mockRepo.On("GetRegisteredMemberCount", mock.Anything).Return(&entity.TimeframeStat{All: sql.NullInt64{Int64: 42, Valid: true}}, nil)
+	s.mockRepo.On("CountRegisteredMembers", mock.Anything, start, end).Return(int64(5), nil)
+
+	filter := &dto.RegisteredCountQueryParam{
+		StartDate: custom.Date(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   custom.Date(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)),
+	}
+	count, err := s.userService.GetRegisteredMemberCount(context.Background(), filter)
+	s.NoError(err)
+	s.Equal(int64(42), count.AllTime)
+	s.Equal(int64(5), *count.InRange)
+}
+
+func (s *TestSuiteUserService) TestGetRegisteredMemberCount_InvalidRange() {
+	filter := &dto.RegisteredCountQueryParam{
+		StartDate: custom.Date(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   custom.Date(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	_, err := s.userService.GetRegisteredMemberCount(context.Background(), filter)
+	s.Equal(err2.ErrInvalidDateRange, err)
+}
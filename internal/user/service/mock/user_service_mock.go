@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"office-booking-backend/internal/user/dto"
+	"office-booking-backend/pkg/utils/timeseries"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -22,13 +23,13 @@ func (m *UserServiceMock) GetAllUsers(ctx context.Context, filter *dto.UserFilte
 	return args.Get(0).(*dto.BriefUsersResponse), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *UserServiceMock) GetRegisteredMemberStat(ctx context.Context) (*dto.RegisteredStatResponseList, error) {
-	args := m.Called(ctx)
-	return args.Get(0).(*dto.RegisteredStatResponseList), args.Error(1)
+func (m *UserServiceMock) GetRegisteredMemberStat(ctx context.Context, filter *timeseries.Query) (*timeseries.Series, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*timeseries.Series), args.Error(1)
 }
 
-func (m *UserServiceMock) GetRegisteredMemberCount(ctx context.Context) (*dto.TotalByTimeFrame, error) {
-	args := m.Called(ctx)
+func (m *UserServiceMock) GetRegisteredMemberCount(ctx context.Context, filter *dto.RegisteredCountQueryParam) (*dto.TotalByTimeFrame, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).(*dto.TotalByTimeFrame), args.Error(1)
 }
 
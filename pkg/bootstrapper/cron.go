@@ -1,18 +1,52 @@
 package bootstrapper
 
 import (
+	analyticsRepositoryPkg "office-booking-backend/internal/analytics/repository/impl"
+	analyticsServicePkg "office-booking-backend/internal/analytics/service/impl"
+	buildingRepositoryPkg "office-booking-backend/internal/building/repository/impl"
+	buildingServicePkg "office-booking-backend/internal/building/service/impl"
 	cronServicePkg "office-booking-backend/internal/cron/service/impl"
+	notificationRepositoryPkg "office-booking-backend/internal/notification/repository/impl"
+	notificationServicePkg "office-booking-backend/internal/notification/service/impl"
 	paymentRepositoryPkg "office-booking-backend/internal/payment/repository/impl"
+	reportSubscriptionRepositoryPkg "office-booking-backend/internal/reportsubscription/repository/impl"
+	reportSubscriptionServicePkg "office-booking-backend/internal/reportsubscription/service/impl"
 	reservationRepositoryPkg "office-booking-backend/internal/reservation/repository/impl"
+	savedSearchRepositoryPkg "office-booking-backend/internal/savedsearch/repository/impl"
+	savedSearchServicePkg "office-booking-backend/internal/savedsearch/service/impl"
+	userRepositoryPkg "office-booking-backend/internal/user/repository/impl"
+	redisRepoPkg "office-booking-backend/pkg/database/redis"
+	imagekitServicePkg "office-booking-backend/pkg/utils/imagekit"
+	"office-booking-backend/pkg/utils/mail"
+	"office-booking-backend/pkg/utils/validator"
 
 	"github.com/go-co-op/gocron"
+	"github.com/go-redis/redis/v9"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 )
 
-func InitCron(db *gorm.DB, cron *gocron.Scheduler, conf *viper.Viper) {
+func InitCron(db *gorm.DB, redisClient *redis.Client, cron *gocron.Scheduler, conf *viper.Viper) {
+	validation := validator.NewValidator()
+	redisRepo := redisRepoPkg.NewRedisClient(redisClient)
+
+	imagekitService := imagekitServicePkg.NewImgKitService(conf.GetString("service.imgkit.privateKey"), conf.GetString("service.imgkit.publicKey"), conf.GetString("service.imgkit.endpoint"))
+	mailService := mail.NewClient(conf.GetString("service.mailgun.domain"), conf.GetString("service.mailgun.apiKey"), conf.GetString("service.mailgun.sender"), conf.GetString("service.mailgun.senderName"))
+
 	reservationRepository := reservationRepositoryPkg.NewReservationRepositoryImpl(db)
 	paymentRepository := paymentRepositoryPkg.NewPaymentRepositoryImpl(db)
-	cronService := cronServicePkg.NewCronServiceImpl(reservationRepository, paymentRepository, cron, conf)
+	buildingRepository := buildingRepositoryPkg.NewBuildingRepositoryImpl(db)
+	savedSearchRepository := savedSearchRepositoryPkg.NewSavedSearchRepositoryImpl(db)
+	notificationRepository := notificationRepositoryPkg.NewNotificationRepositoryImpl(db)
+	analyticsRepository := analyticsRepositoryPkg.NewAnalyticsRepositoryImpl(db)
+	userRepository := userRepositoryPkg.NewUserRepositoryImpl(db)
+	reportSubscriptionRepository := reportSubscriptionRepositoryPkg.NewReportSubscriptionRepositoryImpl(db)
+
+	notificationService := notificationServicePkg.NewNotificationServiceImpl(notificationRepository)
+	analyticsService := analyticsServicePkg.NewAnalyticsServiceImpl(analyticsRepository, buildingRepository, redisRepo)
+	buildingService := buildingServicePkg.NewBuildingServiceImpl(buildingRepository, reservationRepository, imagekitService, validation, redisRepo, analyticsService, conf)
+	savedSearchService := savedSearchServicePkg.NewSavedSearchServiceImpl(savedSearchRepository, buildingRepository, notificationService, mailService, conf)
+	reportSubscriptionService := reportSubscriptionServicePkg.NewReportSubscriptionServiceImpl(reportSubscriptionRepository, userRepository, reservationRepository, mailService, conf)
+	cronService := cronServicePkg.NewCronServiceImpl(reservationRepository, paymentRepository, buildingRepository, buildingService, savedSearchService, reportSubscriptionService, notificationService, analyticsService, mailService, cron, conf)
 	cronService.Start()
 }
@@ -1,18 +1,41 @@
 package bootstrapper
 
 import (
+	"context"
+	"log"
+	analyticsControllerPkg "office-booking-backend/internal/analytics/controller"
+	analyticsRepositoryPkg "office-booking-backend/internal/analytics/repository/impl"
+	analyticsServicePkg "office-booking-backend/internal/analytics/service/impl"
 	authControllerPkg "office-booking-backend/internal/auth/controller"
 	authRepositoryPkg "office-booking-backend/internal/auth/repository/impl"
 	authServicePkg "office-booking-backend/internal/auth/service/impl"
 	buildingControllerPkg "office-booking-backend/internal/building/controller"
 	buildingRepositoryPkg "office-booking-backend/internal/building/repository/impl"
 	buildingServicePkg "office-booking-backend/internal/building/service/impl"
+	notificationControllerPkg "office-booking-backend/internal/notification/controller"
+	notificationRepositoryPkg "office-booking-backend/internal/notification/repository/impl"
+	notificationServicePkg "office-booking-backend/internal/notification/service/impl"
+	organizationControllerPkg "office-booking-backend/internal/organization/controller"
+	organizationRepositoryPkg "office-booking-backend/internal/organization/repository/impl"
+	organizationServicePkg "office-booking-backend/internal/organization/service/impl"
 	paymentControllerPkg "office-booking-backend/internal/payment/controller"
 	paymentRepositoryPkg "office-booking-backend/internal/payment/repository/impl"
 	paymentServicePkg "office-booking-backend/internal/payment/service/impl"
+	reportControllerPkg "office-booking-backend/internal/report/controller"
+	reportRepositoryPkg "office-booking-backend/internal/report/repository/impl"
+	reportServicePkg "office-booking-backend/internal/report/service/impl"
+	reportSubscriptionControllerPkg "office-booking-backend/internal/reportsubscription/controller"
+	reportSubscriptionRepositoryPkg "office-booking-backend/internal/reportsubscription/repository/impl"
+	reportSubscriptionServicePkg "office-booking-backend/internal/reportsubscription/service/impl"
 	reservationControllerPkg "office-booking-backend/internal/reservation/controller"
 	reservationRepositoryPkg "office-booking-backend/internal/reservation/repository/impl"
 	reservationServicePkg "office-booking-backend/internal/reservation/service/impl"
+	reviewControllerPkg "office-booking-backend/internal/review/controller"
+	reviewRepositoryPkg "office-booking-backend/internal/review/repository/impl"
+	reviewServicePkg "office-booking-backend/internal/review/service/impl"
+	savedSearchControllerPkg "office-booking-backend/internal/savedsearch/controller"
+	savedSearchRepositoryPkg "office-booking-backend/internal/savedsearch/repository/impl"
+	savedSearchServicePkg "office-booking-backend/internal/savedsearch/service/impl"
 	userControllerPkg "office-booking-backend/internal/user/controller"
 	userRepositoryPkg "office-booking-backend/internal/user/repository/impl"
 	userServicePkg "office-booking-backend/internal/user/service/impl"
@@ -25,6 +48,7 @@ import (
 	passwordServicePkg "office-booking-backend/pkg/utils/password"
 	"office-booking-backend/pkg/utils/random"
 	"office-booking-backend/pkg/utils/validator"
+	"time"
 
 	"github.com/go-redis/redis/v9"
 	"github.com/gofiber/fiber/v2"
@@ -32,7 +56,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func InitAPI(app *fiber.App, db *gorm.DB, redisClient *redis.Client, conf *viper.Viper) {
+func InitAPI(ctx context.Context, app *fiber.App, db *gorm.DB, redisClient *redis.Client, conf *viper.Viper) {
 	passwordService := passwordServicePkg.NewPasswordFuncImpl()
 	validation := validator.NewValidator()
 	generator := random.NewGenerator()
@@ -42,6 +66,7 @@ func InitAPI(app *fiber.App, db *gorm.DB, redisClient *redis.Client, conf *viper
 	redisRepo := redisRepoPkg.NewRedisClient(redisClient)
 	tokenService := authServicePkg.NewTokenServiceImpl(conf.GetString("token.access.secret"), conf.GetString("token.refresh.secret"), conf.GetDuration("token.access.exp"), conf.GetDuration("token.refresh.exp"), redisRepo)
 	accessTokenMiddleware := middlewares.NewJWTMiddleware(conf.GetString("token.access.secret"), middlewares.ValidateAccessToken(tokenService))
+	optionalAccessTokenMiddleware := middlewares.NewOptionalJWTMiddleware(conf.GetString("token.access.secret"), middlewares.ValidateOptionalAccessToken(tokenService))
 	adminAccessTokenMiddleware := middlewares.NewJWTMiddleware(conf.GetString("token.access.secret"), middlewares.ValidateAdminAccessToken(tokenService))
 	limiterMiddeleware := middlewares.NewLimiter(conf.GetDuration("otp.resendLimit"))
 	corsMiddleware := middlewares.NewCORSMiddleware(conf.GetStringSlice("server.allowedOrigins"))
@@ -51,11 +76,25 @@ func InitAPI(app *fiber.App, db *gorm.DB, redisClient *redis.Client, conf *viper
 	authRepository := authRepositoryPkg.NewAuthRepositoryImpl(db)
 	buildingRepository := buildingRepositoryPkg.NewBuildingRepositoryImpl(db)
 	paymentRepository := paymentRepositoryPkg.NewPaymentRepositoryImpl(db)
+	organizationRepository := organizationRepositoryPkg.NewOrganizationRepositoryImpl(db)
+	savedSearchRepository := savedSearchRepositoryPkg.NewSavedSearchRepositoryImpl(db)
+	notificationRepository := notificationRepositoryPkg.NewNotificationRepositoryImpl(db)
+	analyticsRepository := analyticsRepositoryPkg.NewAnalyticsRepositoryImpl(db)
+	reportRepository := reportRepositoryPkg.NewReportRepositoryImpl(db)
+	reportSubscriptionRepository := reportSubscriptionRepositoryPkg.NewReportSubscriptionRepositoryImpl(db)
+	reviewRepository := reviewRepositoryPkg.NewReviewRepositoryImpl(db)
 
+	analyticsService := analyticsServicePkg.NewAnalyticsServiceImpl(analyticsRepository, buildingRepository, redisRepo)
 	paymentService := paymentServicePkg.NewPaymentServiceImpl(paymentRepository, reservationRepository, imagekitService)
-	reservationService := reservationServicePkg.NewReservationServiceImpl(reservationRepository, buildingRepository, conf)
+	reservationService := reservationServicePkg.NewReservationServiceImpl(reservationRepository, buildingRepository, userRepository, mailService, analyticsService, conf)
 	userService := userServicePkg.NewUserServiceImpl(userRepository, reservationService, imagekitService)
-	buildingService := buildingServicePkg.NewBuildingServiceImpl(buildingRepository, reservationRepository, imagekitService, validation)
+	buildingService := buildingServicePkg.NewBuildingServiceImpl(buildingRepository, reservationRepository, imagekitService, validation, redisRepo, analyticsService, conf)
+	organizationService := organizationServicePkg.NewOrganizationServiceImpl(organizationRepository, userRepository, reservationService, mailService)
+	notificationService := notificationServicePkg.NewNotificationServiceImpl(notificationRepository)
+	savedSearchService := savedSearchServicePkg.NewSavedSearchServiceImpl(savedSearchRepository, buildingRepository, notificationService, mailService, conf)
+	reportService := reportServicePkg.NewReportServiceImpl(reportRepository, conf)
+	reportSubscriptionService := reportSubscriptionServicePkg.NewReportSubscriptionServiceImpl(reportSubscriptionRepository, userRepository, reservationRepository, mailService, conf)
+	reviewService := reviewServicePkg.NewReviewServiceImpl(reviewRepository, notificationService, imagekitService, conf)
 	authService := authServicePkg.NewAuthServiceImpl(authRepository, tokenService, redisRepo, mailService, passwordService, generator, conf)
 
 	reservationController := reservationControllerPkg.NewReservationController(reservationService, validation)
@@ -63,8 +102,41 @@ func InitAPI(app *fiber.App, db *gorm.DB, redisClient *redis.Client, conf *viper
 	authController := authControllerPkg.NewAuthController(authService, validation)
 	buildingController := buildingControllerPkg.NewBuildingController(buildingService, validation)
 	paymentController := paymentControllerPkg.NewPaymentController(paymentService, validation)
+	organizationController := organizationControllerPkg.NewOrganizationController(organizationService, validation)
+	savedSearchController := savedSearchControllerPkg.NewSavedSearchController(savedSearchService, validation)
+	notificationController := notificationControllerPkg.NewNotificationController(notificationService, validation)
+	analyticsController := analyticsControllerPkg.NewAnalyticsController(analyticsService, validation)
+	reportController := reportControllerPkg.NewReportController(reportService, validation)
+	reportSubscriptionController := reportSubscriptionControllerPkg.NewReportSubscriptionController(reportSubscriptionService, validation)
+	reviewController := reviewControllerPkg.NewReviewController(reviewService, validation)
+
+	go rebuildSearchIndex(ctx, buildingService.RebuildSearchIndex, conf.GetDuration("search.rebuildInterval"))
 
 	// init routes
-	route := routes.NewRoutes(authController, userController, buildingController, reservationController, paymentController, limiterMiddeleware, accessTokenMiddleware, adminAccessTokenMiddleware, corsMiddleware)
+	route := routes.NewRoutes(authController, userController, buildingController, reservationController, paymentController, organizationController, savedSearchController, notificationController, analyticsController, reportController, reportSubscriptionController, reviewController, limiterMiddeleware, accessTokenMiddleware, optionalAccessTokenMiddleware, adminAccessTokenMiddleware, corsMiddleware)
 	route.Init(app)
 }
+
+// rebuildSearchIndex periodically reloads the in-memory search index, so changes made
+// by other processes (e.g. prefork children) are eventually searchable. It stops when ctx is done
+func rebuildSearchIndex(ctx context.Context, rebuild func(ctx context.Context) error, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := rebuild(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Println("failed to rebuild search index: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
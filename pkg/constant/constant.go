@@ -32,4 +32,76 @@ const (
 	AWAITING_PAYMENT_STATUS = 4
 	ACTIVE_STATUS           = 5
 	COMPLETED_STATUS        = 6
+	PENDING_APPROVAL_STATUS = 7
 )
+
+const (
+	TRANSFER_PENDING_STATUS  = "pending"
+	TRANSFER_ACCEPTED_STATUS = "accepted"
+	TRANSFER_DECLINED_STATUS = "declined"
+	TRANSFER_CANCELED_STATUS = "canceled"
+)
+
+const (
+	REVISION_DRAFT_STATUS     = "draft"
+	REVISION_PUBLISHED_STATUS = "published"
+	REVISION_DISCARDED_STATUS = "discarded"
+)
+
+const (
+	REVIEW_PUBLISHED_STATUS = "published"
+	REVIEW_APPROVED_STATUS  = "approved"
+	REVIEW_HIDDEN_STATUS    = "hidden"
+)
+
+const (
+	ORGANIZATION_OWNER_ROLE    = "owner"
+	ORGANIZATION_BOOKER_ROLE   = "booker"
+	ORGANIZATION_VIEWER_ROLE   = "viewer"
+	ORGANIZATION_APPROVER_ROLE = "approver"
+)
+
+const (
+	APPROVAL_REQUESTED_ACTION = "requested"
+	APPROVAL_APPROVED_ACTION  = "approved"
+	APPROVAL_REJECTED_ACTION  = "rejected"
+)
+
+const (
+	NOTIFY_EMAIL_CHANNEL  = "email"
+	NOTIFY_IN_APP_CHANNEL = "in_app"
+	NOTIFY_ALL_CHANNEL    = "all"
+)
+
+const (
+	SAVED_SEARCH_MATCH_NOTIFICATION       = "saved_search_match"
+	BUILDING_SCHEDULE_FAILED_NOTIFICATION = "building_schedule_failed"
+	REVIEW_REPLY_NOTIFICATION             = "review_reply"
+)
+
+// Building analytics events, named after the columns of the daily stats
+const (
+	BUILDING_IMPRESSION_EVENT    = "impressions"
+	BUILDING_VIEW_EVENT          = "views"
+	BUILDING_FAVORITE_EVENT      = "favorites"
+	RESERVATION_START_EVENT      = "reservation_starts"
+	RESERVATION_COMPLETION_EVENT = "reservation_completions"
+)
+
+// Reports of the scheduled admin report emails
+const (
+	NEW_USERS_REPORT        = "new_users"
+	RESERVATIONS_REPORT     = "reservations"
+	REVENUE_REPORT          = "revenue"
+	PENDING_PAYMENTS_REPORT = "pending_payments"
+	EXPIRING_LEASES_REPORT  = "expiring_leases"
+)
+
+const (
+	DAILY_FREQUENCY   = "daily"
+	WEEKLY_FREQUENCY  = "weekly"
+	MONTHLY_FREQUENCY = "monthly"
+)
+
+// MONTHLY_PRICE_BANDS are the upper limits of the monthly price bands used by the search facets
+var MONTHLY_PRICE_BANDS = []int{5000000, 10000000, 25000000, 50000000}
@@ -40,6 +40,10 @@ type RedisClient interface {
 	Set(ctx context.Context, key string, value string, exp time.Duration) error
 	Del(ctx context.Context, key string) error
 	Get(ctx context.Context, key string) (string, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Rename(ctx context.Context, key string, newKey string) error
+	HIncrBy(ctx context.Context, key string, fields map[string]int64) error
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
 }
 
 type RedisClientImpl struct {
@@ -63,3 +67,27 @@ func (t *RedisClientImpl) Del(ctx context.Context, key string) error {
 func (t *RedisClientImpl) Get(ctx context.Context, key string) (string, error) {
 	return t.redis.Get(ctx, key).Result()
 }
+
+func (t *RedisClientImpl) Exists(ctx context.Context, key string) (bool, error) {
+	count, err := t.redis.Exists(ctx, key).Result()
+	return count > 0, err
+}
+
+func (t *RedisClientImpl) Rename(ctx context.Context, key string, newKey string) error {
+	return t.redis.Rename(ctx, key, newKey).Err()
+}
+
+// HIncrBy increments several fields of a hash in one round trip
+func (t *RedisClientImpl) HIncrBy(ctx context.Context, key string, fields map[string]int64) error {
+	_, err := t.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for field, incr := range fields {
+			pipe.HIncrBy(ctx, key, field, incr)
+		}
+		return nil
+	})
+	return err
+}
+
+func (t *RedisClientImpl) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return t.redis.HGetAll(ctx, key).Result()
+}
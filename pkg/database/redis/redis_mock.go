@@ -24,3 +24,23 @@ func (m *RedisClientMock) Get(ctx context.Context, key string) (string, error) {
 	args := m.Called(ctx, key)
 	return args.String(0), args.Error(1)
 }
+
+func (m *RedisClientMock) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *RedisClientMock) Rename(ctx context.Context, key string, newKey string) error {
+	args := m.Called(ctx, key, newKey)
+	return args.Error(0)
+}
+
+func (m *RedisClientMock) HIncrBy(ctx context.Context, key string, fields map[string]int64) error {
+	args := m.Called(ctx, key, fields)
+	return args.Error(0)
+}
+
+func (m *RedisClientMock) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// BuildingDailyStat is the listing funnel of a building on a date, the counters are buffered in redis
+// and added to the row on every flush
+type BuildingDailyStat struct {
+	BuildingID             string `gorm:"primaryKey; type:varchar(36)"`
+	Building               Building
+	Date                   time.Time `gorm:"primaryKey; type:date"`
+	Impressions            int64     `gorm:"not null; default:0"`
+	Views                  int64     `gorm:"not null; default:0"`
+	Favorites              int64     `gorm:"not null; default:0"`
+	ReservationStarts      int64     `gorm:"not null; default:0"`
+	ReservationCompletions int64     `gorm:"not null; default:0"`
+}
+
+type BuildingDailyStats []BuildingDailyStat
+
+// BuildingEventBatch is a flushed batch of counters, it's recorded with the daily stats so a batch
+// retried after its counters were added isn't added twice
+type BuildingEventBatch struct {
+	ID        string    `gorm:"primaryKey; type:varchar(36)"`
+	FlushedAt time.Time `gorm:"not null; index"`
+}
+
+// BuildingFunnelStat is the funnel of a building summed over a period
+type BuildingFunnelStat struct {
+	BuildingID             string
+	BuildingName           string
+	Impressions            int64
+	Views                  int64
+	Favorites              int64
+	ReservationStarts      int64
+	ReservationCompletions int64
+}
+
+type BuildingFunnelStats []BuildingFunnelStat
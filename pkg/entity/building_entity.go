@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,12 +29,27 @@ type Building struct {
 	Address      string `gorm:"type:text"`
 	Longitude    float64
 	Latitude     float64
-	CreatedByID  string         `gorm:"type:varchar(36); default:null;"`
-	CreatedBy    User           `gorm:"foreignKey:CreatedByID"`
-	IsPublished  *bool          `gorm:"default:false"`
-	CreatedAt    time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	CreatedByID  string `gorm:"type:varchar(36); default:null;"`
+	CreatedBy    User   `gorm:"foreignKey:CreatedByID"`
+	IsPublished  *bool  `gorm:"default:false"`
+	// PublishedAt is when the building was first published, the reports count it as available from then
+	PublishedAt sql.NullTime
+	// PublishAt and UnpublishAt are executed by the cron service, ScheduledBy is told when they fail
+	PublishAt     sql.NullTime `gorm:"index"`
+	UnpublishAt   sql.NullTime `gorm:"index"`
+	ScheduledByID string       `gorm:"type:varchar(36); default:null;"`
+	ScheduledBy   User         `gorm:"foreignKey:ScheduledByID"`
+	// Timezone is an IANA name, the opening hours are in this timezone and empty means the server timezone
+	Timezone          string            `gorm:"type:varchar(64); default:''"`
+	OpeningHours      OpeningHours      `gorm:"foreignKey:BuildingID"`
+	OpeningExceptions OpeningExceptions `gorm:"foreignKey:BuildingID"`
+	// IsAlwaysOpen and IsOpenOnWeekends are derived from the opening hours for the search filters,
+	// a building without hours is not restricted
+	IsAlwaysOpen     *bool          `gorm:"default:true"`
+	IsOpenOnWeekends *bool          `gorm:"default:true"`
+	CreatedAt        time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
 }
 
 type Buildings []Building
@@ -90,6 +106,61 @@ type Facility struct {
 
 type Facilities []Facility
 
+// FloorPlan is a floor-plan image of a building, the ID is the imagekit file id like Picture
+type FloorPlan struct {
+	ID           string `gorm:"primaryKey; type:varchar(36); not null"`
+	Key          string `gorm:"type:varchar(36); not null"`
+	BuildingID   string `gorm:"type:varchar(36); not null; index"`
+	Floor        int    `gorm:"type:int; default:0"`
+	Name         string `gorm:"type:varchar(100); default:''"`
+	Url          string
+	ThumbnailUrl string
+	Width        int
+	Height       int
+	Hotspots     FloorPlanHotspots `gorm:"foreignKey:FloorPlanID"`
+	CreatedAt    time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time         `gorm:"autoUpdateTime"`
+}
+
+type FloorPlans []FloorPlan
+
+// FloorPlanHotspot is a named area of a floor plan, optionally linked to a facility of the building
+type FloorPlanHotspot struct {
+	ID          int      `gorm:"primaryKey; type:int; not null"`
+	FloorPlanID string   `gorm:"type:varchar(36); not null; index"`
+	Name        string   `gorm:"type:varchar(100); not null"`
+	FacilityID  int      `gorm:"default:null"`
+	Facility    Facility `gorm:"constraint:OnDelete:SET NULL;"`
+	// Polygon is a JSON array of [x, y] points relative to the image size, from 0 to 1
+	Polygon string `gorm:"type:text"`
+}
+
+type FloorPlanHotspots []FloorPlanHotspot
+
+// OpeningHour is a weekly opening period, the times are "15:04" and a period until midnight closes at "24:00"
+type OpeningHour struct {
+	ID         int          `gorm:"primaryKey; type:int; not null"`
+	BuildingID string       `gorm:"type:varchar(36); not null; index"`
+	Weekday    time.Weekday `gorm:"type:tinyint; not null"`
+	OpenTime   string       `gorm:"type:varchar(5); not null"`
+	CloseTime  string       `gorm:"type:varchar(5); not null"`
+}
+
+type OpeningHours []OpeningHour
+
+// OpeningException replaces the weekly opening hours on a date, a closed date has no times
+type OpeningException struct {
+	ID         int       `gorm:"primaryKey; type:int; not null"`
+	BuildingID string    `gorm:"type:varchar(36); not null; index"`
+	Date       time.Time `gorm:"type:date; not null"`
+	IsClosed   *bool     `gorm:"default:false"`
+	OpenTime   string    `gorm:"type:varchar(5); default:''"`
+	CloseTime  string    `gorm:"type:varchar(5); default:''"`
+	Note       string    `gorm:"type:varchar(255); default:''"`
+}
+
+type OpeningExceptions []OpeningException
+
 // only used for returning stats
 type CityStat struct {
 	CityID   int64
@@ -98,3 +169,29 @@ type CityStat struct {
 }
 
 type CitiesStat []CityStat
+
+// only used for returning search facets
+type FacetCount struct {
+	ID    int64
+	Name  string
+	Total int64
+}
+
+type FacetCounts []FacetCount
+
+type BuildingFacets struct {
+	Cities             FacetCounts
+	Districts          FacetCounts
+	PriceBands         FacetCounts
+	FacilityCategories FacetCounts
+}
+
+type Favorite struct {
+	UserID     string `gorm:"primaryKey; type:varchar(36)"`
+	User       User
+	BuildingID string `gorm:"primaryKey; type:varchar(36)"`
+	Building   Building
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type Favorites []Favorite
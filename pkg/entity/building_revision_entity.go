@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BuildingRevision struct {
+	ID         string `gorm:"primaryKey; type:varchar(36); not null"`
+	BuildingID string `gorm:"type:varchar(36); not null; uniqueIndex:idx_building_revisions_version"`
+	Building   Building
+	Version    int `gorm:"not null; uniqueIndex:idx_building_revisions_version"`
+	// Changes holds the json encoded building update request of the revision
+	Changes string `gorm:"type:text; not null"`
+	// Snapshot holds the json encoded building fields right after the revision was published
+	Snapshot    string `gorm:"type:text"`
+	Status      string `gorm:"type:varchar(20); default:'draft'"`
+	CreatedByID string `gorm:"type:varchar(36); default:null"`
+	CreatedBy   User   `gorm:"foreignKey:CreatedByID"`
+	PublishedAt sql.NullTime
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (r *BuildingRevision) BeforeCreate(*gorm.DB) (err error) {
+	r.ID = uuid.New().String()
+	return
+}
+
+type BuildingRevisions []BuildingRevision
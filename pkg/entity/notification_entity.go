@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Notification struct {
+	ID        string `gorm:"primaryKey; type:varchar(36); not null"`
+	UserID    string `gorm:"type:varchar(36); not null; index"`
+	User      User
+	Type      string `gorm:"type:varchar(30); not null"`
+	Title     string `gorm:"type:varchar(255); not null"`
+	Message   string `gorm:"type:text"`
+	Link      string `gorm:"type:varchar(255); default:''"`
+	ReadAt    sql.NullTime
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (n *Notification) BeforeCreate(*gorm.DB) (err error) {
+	n.ID = uuid.New().String()
+	return
+}
+
+type Notifications []Notification
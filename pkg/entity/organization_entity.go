@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Organization struct {
+	ID             string `gorm:"primaryKey; type:varchar(36); not null"`
+	Name           string `gorm:"type:varchar(255); not null"`
+	BillingName    string `gorm:"type:varchar(255)"`
+	BillingEmail   string `gorm:"type:varchar(255)"`
+	BillingAddress string `gorm:"type:varchar(255)"`
+	TaxNumber      string `gorm:"type:varchar(50)"`
+	// spending policies, 0 means the policy is disabled
+	MonthlyBudget     int `gorm:"type:int; default:0"`
+	MaxBookingAmount  int `gorm:"type:int; default:0"`
+	ApprovalThreshold int `gorm:"type:int; default:0"`
+	Members           OrganizationMembers
+	CreatedAt         time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+}
+
+func (o *Organization) BeforeCreate(*gorm.DB) (err error) {
+	o.ID = uuid.New().String()
+	return
+}
+
+type Organizations []Organization
+
+type OrganizationMember struct {
+	OrganizationID string `gorm:"primaryKey; type:varchar(36)"`
+	UserID         string `gorm:"primaryKey; type:varchar(36)"`
+	User           User
+	Role           string    `gorm:"type:varchar(20); not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+type OrganizationMembers []OrganizationMember
+
+type OrganizationApprovalLog struct {
+	ID             string `gorm:"primaryKey; type:varchar(36); not null"`
+	OrganizationID string `gorm:"type:varchar(36); not null"`
+	Organization   Organization
+	ReservationID  string `gorm:"type:varchar(36); not null"`
+	Reservation    Reservation
+	ActorID        string    `gorm:"type:varchar(36); not null"`
+	Actor          User      `gorm:"foreignKey:ActorID"`
+	Action         string    `gorm:"type:varchar(20); not null"`
+	Reason         string    `gorm:"type:varchar(255); default:''"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (o *OrganizationApprovalLog) BeforeCreate(*gorm.DB) (err error) {
+	o.ID = uuid.New().String()
+	return
+}
+
+type OrganizationApprovalLogs []OrganizationApprovalLog
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReportSubscription is an admin's scheduled report email, the schedule is in the server timezone
+type ReportSubscription struct {
+	ID     string `gorm:"primaryKey; type:varchar(36); not null"`
+	UserID string `gorm:"type:varchar(36); not null"`
+	User   User
+	// Reports holds the comma separated report names
+	Reports   string `gorm:"type:varchar(255); not null"`
+	Frequency string `gorm:"type:varchar(10); not null"`
+	// Weekday is the day of a weekly report where 0 is sunday, DayOfMonth is the day of a monthly report
+	Weekday          int
+	DayOfMonth       int
+	Hour             int
+	IsActive         *bool  `gorm:"default:true"`
+	UnsubscribeToken string `gorm:"type:varchar(36); uniqueIndex; not null"`
+	LastSentAt       sql.NullTime
+	NextSendAt       time.Time `gorm:"index"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+func (r *ReportSubscription) BeforeCreate(*gorm.DB) (err error) {
+	r.ID = uuid.New().String()
+	r.UnsubscribeToken = uuid.New().String()
+	return
+}
+
+type ReportSubscriptions []ReportSubscription
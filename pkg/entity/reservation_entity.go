@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"database/sql"
+	"office-booking-backend/pkg/constant"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,23 +10,25 @@ import (
 )
 
 type Reservation struct {
-	ID          string `gorm:"primaryKey; type:varchar(36); not null"`
-	CompanyName string
-	BuildingID  string `gorm:"type:varchar(36); not null" `
-	Building    Building
-	StartDate   time.Time `gorm:"type:datetime"`
-	EndDate     time.Time `gorm:"type:datetime"`
-	Amount      int       `gorm:"type:int; not null"`
-	UserID      string    `gorm:"type:varchar(36);"`
-	User        User      `gorm:"constraint:OnUpdate:NO ACTION,OnDelete:SET NULL;"`
-	StatusID    int       `gorm:"type:int; default:1"`
-	Status      Status
-	Message     string         `gorm:"type:varchar(255); default:''"`
-	AcceptedAt  time.Time      `gorm:"type:datetime; default:NULL"`
-	ExpiredAt   time.Time      `gorm:"type:datetime; default:NULL"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID             string `gorm:"primaryKey; type:varchar(36); not null"`
+	CompanyName    string
+	BuildingID     string `gorm:"type:varchar(36); not null" `
+	Building       Building
+	StartDate      time.Time `gorm:"type:datetime"`
+	EndDate        time.Time `gorm:"type:datetime"`
+	Amount         int       `gorm:"type:int; not null"`
+	UserID         string    `gorm:"type:varchar(36);"`
+	User           User      `gorm:"constraint:OnUpdate:NO ACTION,OnDelete:SET NULL;"`
+	StatusID       int       `gorm:"type:int; default:1"`
+	Status         Status
+	OrganizationID string         `gorm:"type:varchar(36); default:null"`
+	Organization   Organization   `gorm:"constraint:OnDelete:SET NULL;"`
+	Message        string         `gorm:"type:varchar(255); default:''"`
+	AcceptedAt     time.Time      `gorm:"type:datetime; default:NULL"`
+	ExpiredAt      time.Time      `gorm:"type:datetime; default:NULL"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 }
 
 func (r *Reservation) BeforeCreate(*gorm.DB) (err error) {
@@ -57,10 +61,22 @@ type Review struct {
 	BuildingID    string `gorm:"type:varchar(36); not null"`
 	Building      Building
 	Rating        int
-	Message       string
-	CreatedAt     time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	// the sub-ratings are optional, nil when the reviewer skipped them
+	LocationRating     *int
+	CleanlinessRating  *int
+	FacilitiesRating   *int
+	ValueRating        *int
+	ConnectivityRating *int
+	Message            string
+	// Status is the moderation status, new reviews are published right away and can be hidden by an admin
+	Status      string `gorm:"type:varchar(10); default:'published'; index"`
+	ModeratedAt sql.NullTime
+	Reports     ReviewReports
+	Reply       *ReviewReply
+	Pictures    ReviewPictures
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
 func (rv *Review) BeforeCreate(*gorm.DB) (err error) {
@@ -69,3 +85,92 @@ func (rv *Review) BeforeCreate(*gorm.DB) (err error) {
 }
 
 type Reviews []Review
+
+// VisibleReviewStatus are the moderation statuses of the reviews shown on the building and counted in its rating
+var VisibleReviewStatus = []string{constant.REVIEW_PUBLISHED_STATUS, constant.REVIEW_APPROVED_STATUS}
+
+// only used for returning the rating breakdown of a building
+type RatingBreakdown struct {
+	Location     sql.NullFloat64
+	Cleanliness  sql.NullFloat64
+	Facilities   sql.NullFloat64
+	Value        sql.NullFloat64
+	Connectivity sql.NullFloat64
+	OneStar      int64
+	TwoStars     int64
+	ThreeStars   int64
+	FourStars    int64
+	FiveStars    int64
+}
+
+// ReviewReport is a report of an abusive review by a user, it is resolved when an admin moderates the review
+type ReviewReport struct {
+	ID         string `gorm:"primaryKey; type:varchar(36); not null"`
+	ReviewID   string `gorm:"type:varchar(36); not null; uniqueIndex:idx_review_report_user"`
+	UserID     string `gorm:"type:varchar(36); not null; uniqueIndex:idx_review_report_user"`
+	User       User
+	Reason     string `gorm:"type:varchar(20); not null"`
+	Message    string `gorm:"type:varchar(255); default:''"`
+	ResolvedAt sql.NullTime
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (rr *ReviewReport) BeforeCreate(*gorm.DB) (err error) {
+	rr.ID = uuid.New().String()
+	return
+}
+
+type ReviewReports []ReviewReport
+
+// ReviewPicture is a photo attached to a review by the reviewer, the ID is the imagekit file id like Picture.
+// BuildingID is kept so the guest photos of a building can be listed without going through the reviews
+type ReviewPicture struct {
+	ID           string `gorm:"primaryKey; type:varchar(36); not null"`
+	Key          string `gorm:"type:varchar(36); not null"`
+	ReviewID     string `gorm:"type:varchar(36); not null; index"`
+	BuildingID   string `gorm:"type:varchar(36); not null; index"`
+	Url          string
+	ThumbnailUrl string
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+type ReviewPictures []ReviewPicture
+
+// ReviewReply is the public response to a review by an admin or the owner of the building, a review has one reply at most
+type ReviewReply struct {
+	ID        string `gorm:"primaryKey; type:varchar(36); not null"`
+	ReviewID  string `gorm:"type:varchar(36); not null; uniqueIndex"`
+	UserID    string `gorm:"type:varchar(36); not null"`
+	User      User
+	Message   string    `gorm:"type:text; not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (rr *ReviewReply) BeforeCreate(*gorm.DB) (err error) {
+	rr.ID = uuid.New().String()
+	return
+}
+
+type ReservationTransfer struct {
+	ID            string `gorm:"primaryKey; type:varchar(36); not null"`
+	ReservationID string `gorm:"type:varchar(36); not null"`
+	Reservation   Reservation
+	FromUserID    string         `gorm:"type:varchar(36); not null"`
+	FromUser      User           `gorm:"foreignKey:FromUserID"`
+	ToUserID      string         `gorm:"type:varchar(36); not null"`
+	ToUser        User           `gorm:"foreignKey:ToUserID"`
+	CompanyName   string         `gorm:"type:varchar(255); default:''"`
+	Status        string         `gorm:"type:varchar(20); default:'pending'"`
+	RespondedAt   time.Time      `gorm:"type:datetime; default:NULL"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+func (rt *ReservationTransfer) BeforeCreate(*gorm.DB) (err error) {
+	rt.ID = uuid.New().String()
+	return
+}
+
+type ReservationTransfers []ReservationTransfer
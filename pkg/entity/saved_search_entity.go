@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedSearch struct {
+	ID     string `gorm:"primaryKey; type:varchar(36); not null"`
+	UserID string `gorm:"type:varchar(36); not null"`
+	User   User
+	Name   string `gorm:"type:varchar(100); not null"`
+	// Filter holds the json encoded search filter
+	Filter           string `gorm:"type:text; not null"`
+	Channel          string `gorm:"type:varchar(10); not null"`
+	IsActive         *bool  `gorm:"default:true"`
+	UnsubscribeToken string `gorm:"type:varchar(36); uniqueIndex; not null"`
+	Results          SavedSearchResults
+	LastRunAt        sql.NullTime
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+func (s *SavedSearch) BeforeCreate(*gorm.DB) (err error) {
+	s.ID = uuid.New().String()
+	s.UnsubscribeToken = uuid.New().String()
+	return
+}
+
+type SavedSearches []SavedSearch
+
+// SavedSearchResult is a building the saved search has already seen, since its filter was last changed
+type SavedSearchResult struct {
+	SavedSearchID string `gorm:"primaryKey; type:varchar(36)"`
+	BuildingID    string `gorm:"primaryKey; type:varchar(36)"`
+	Building      Building
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+type SavedSearchResults []SavedSearchResult
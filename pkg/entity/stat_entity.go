@@ -9,3 +9,39 @@ type TimeframeStat struct {
 	Year  sql.NullInt64
 	All   sql.NullInt64
 }
+
+// TimeBucketStat is the total of a bucket of a time series, see timeseries.Range
+type TimeBucketStat struct {
+	Bucket string
+	Total  int64
+}
+
+type TimeBucketStats []TimeBucketStat
+
+// Values returns the totals by bucket
+func (t *TimeBucketStats) Values() map[string]int64 {
+	values := make(map[string]int64, len(*t))
+	for _, stat := range *t {
+		values[stat.Bucket] = stat.Total
+	}
+	return values
+}
+
+type FavoriteStat struct {
+	BuildingID   string
+	BuildingName string
+	Total        int64
+}
+
+type FavoritesStat []FavoriteStat
+
+// RevenueStat is the paid reservations of a building or a city booked in a month
+type RevenueStat struct {
+	Period    string
+	GroupID   string
+	GroupName string
+	Count     int64
+	Total     int64
+}
+
+type RevenueStats []RevenueStat
@@ -59,10 +59,3 @@ func (n *NullAbleProfilePicture) ConvertToProfilePicture() ProfilePicture {
 		Url: n.Url.String,
 	}
 }
-
-type MonthlyRegisteredStat struct {
-	Month string `gorm:"column:month"`
-	Total int64  `gorm:"column:total"`
-}
-
-type MonthlyRegisteredStatList []MonthlyRegisteredStat
@@ -135,6 +135,117 @@ var (
 	// ErrReviewNotEditable is returned when the review is not editable (e.g. the review is already passed max edit time)
 	ErrReviewNotEditable = errors.New("review is not editable")
 
+	// ErrReviewAlreadyReported is returned when the user has already reported the review
+	ErrReviewAlreadyReported = errors.New("review already reported")
+
+	// ErrReviewReplyAlreadyExist is returned when the review is already replied
+	ErrReviewReplyAlreadyExist = errors.New("review reply already exist")
+
+	// ErrReviewReplyNotFound is returned when the review has no reply
+	ErrReviewReplyNotFound = errors.New("review reply not found")
+
+	// ErrReviewReplyNotEditable is returned when the reply is not editable (e.g. the reply is already passed max edit time)
+	ErrReviewReplyNotEditable = errors.New("review reply is not editable")
+
+	// ErrReviewPictureNotFound is returned when the picture is not attached to the review
+	ErrReviewPictureNotFound = errors.New("review picture not found")
+
 	// ErrPaymentAlreadyExpired is returned when the payment is already expired
 	ErrPaymentAlreadyExpired = errors.New("reservation payment has been expired")
+
+	// ErrReservationNotTransferable is returned when the reservation can't be transferred (e.g. already completed or canceled)
+	ErrReservationNotTransferable = errors.New("reservation is not transferable")
+
+	// ErrTransferToSelf is returned when the user is trying to transfer a reservation to themselves
+	ErrTransferToSelf = errors.New("cannot transfer reservation to yourself")
+
+	// ErrTransferAlreadyPending is returned when the reservation already has a pending transfer
+	ErrTransferAlreadyPending = errors.New("reservation already has a pending transfer")
+
+	// ErrTransferNotFound is returned when the reservation transfer is not found
+	ErrTransferNotFound = errors.New("reservation transfer not found")
+
+	// ErrTransferNotPending is returned when the reservation transfer is already accepted, declined or canceled
+	ErrTransferNotPending = errors.New("reservation transfer is no longer pending")
+
+	// ErrOrganizationNotFound is returned when the organization is not found or the user isn't a member of it
+	ErrOrganizationNotFound = errors.New("organization not found")
+
+	// ErrMemberNotFound is returned when the user is not a member of the organization
+	ErrMemberNotFound = errors.New("organization member not found")
+
+	// ErrMemberAlreadyExist is returned when the user is already a member of the organization
+	ErrMemberAlreadyExist = errors.New("user is already a member of the organization")
+
+	// ErrLastOrganizationOwner is returned when removing or demoting the last owner of the organization
+	ErrLastOrganizationOwner = errors.New("organization must have at least one owner")
+
+	// ErrBookingAmountExceeded is returned when the reservation amount is above the maximum single booking amount of the organization
+	ErrBookingAmountExceeded = errors.New("reservation amount exceeds the organization's maximum booking amount")
+
+	// ErrReservationNotAwaitingApproval is returned when approving or rejecting a reservation that isn't waiting for internal approval
+	ErrReservationNotAwaitingApproval = errors.New("reservation is not awaiting internal approval")
+
+	// ErrReservationAwaitingApproval is returned when an admin changes the status of a reservation still waiting for internal approval
+	ErrReservationAwaitingApproval = errors.New("reservation is awaiting internal approval")
+
+	// ErrFavoriteAlreadyExist is returned when the building is already in the user favorites
+	ErrFavoriteAlreadyExist = errors.New("building is already in favorites")
+
+	// ErrFavoriteNotFound is returned when the building is not in the user favorites
+	ErrFavoriteNotFound = errors.New("building is not in favorites")
+
+	// ErrSavedSearchNotFound is returned when the saved search is not found or belongs to another user
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+
+	// ErrReportSubscriptionNotFound is returned when the report subscription is not found or belongs to another admin
+	ErrReportSubscriptionNotFound = errors.New("report subscription not found")
+
+	// ErrNotificationNotFound is returned when the notification is not found or belongs to another user
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	// ErrInvalidCursor is returned when the pagination cursor can't be decoded
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrCursorNotSupported is returned when cursor pagination is requested with a sort order that doesn't support it
+	ErrCursorNotSupported = errors.New("cursor pagination is not supported with this sort order")
+
+	// ErrRevisionNotFound is returned when the building revision is not found
+	ErrRevisionNotFound = errors.New("building revision not found")
+
+	// ErrRevisionNotDraft is returned when publishing or discarding a revision that is no longer a draft
+	ErrRevisionNotDraft = errors.New("building revision is not a draft")
+
+	// ErrRevisionNotRestorable is returned when restoring a revision that was never published
+	ErrRevisionNotRestorable = errors.New("only published building revisions can be restored")
+
+	// ErrInvalidPublishSchedule is returned when a publish schedule is in the past or unpublishes before it publishes
+	ErrInvalidPublishSchedule = errors.New("publish schedule must be in the future and unpublish after publish")
+
+	// ErrUnsupportedSpreadsheet is returned when the spreadsheet is neither a csv nor a xlsx file
+	ErrUnsupportedSpreadsheet = errors.New("unsupported spreadsheet format, use csv or xlsx")
+
+	// ErrInvalidSpreadsheet is returned when the spreadsheet can't be read or has no data rows
+	ErrInvalidSpreadsheet = errors.New("invalid spreadsheet")
+
+	// ErrImportRowLimitExceeded is returned when the spreadsheet has more rows than a single import allows
+	ErrImportRowLimitExceeded = errors.New("too many rows in a single import")
+
+	// ErrInvalidOpeningHours is returned when an opening time can't be parsed or the periods of a day overlap
+	ErrInvalidOpeningHours = errors.New("opening hours must be HH:MM, close after open and not overlap")
+
+	// ErrInvalidTimezone is returned when the building timezone is not a known IANA timezone
+	ErrInvalidTimezone = errors.New("invalid timezone")
+
+	// ErrBuildingClosed is returned when a reservation starts on a date the building is closed
+	ErrBuildingClosed = errors.New("building is closed on the reservation start date")
+
+	// ErrFloorPlanNotFound is returned when the floor plan is not found in the building
+	ErrFloorPlanNotFound = errors.New("floor plan not found")
+
+	// ErrFloorPlanLimitExceeded is returned when the building has reached the floor plan limit
+	ErrFloorPlanLimitExceeded = errors.New("floor plan limit exceeded")
+
+	// ErrTooManyDataPoints is returned when a statistic date range has more points than a chart can show for the interval
+	ErrTooManyDataPoints = errors.New("date range has too many data points for the interval")
 )
@@ -26,6 +26,23 @@ func NewJWTMiddleware(tokenSecret string, validator fiber.Handler) fiber.Handler
 	})
 }
 
+// NewOptionalJWTMiddleware only validates the token when the Authorization header is present,
+// so public endpoints can personalize the response for logged-in users
+func NewOptionalJWTMiddleware(tokenSecret string, validator fiber.Handler) fiber.Handler {
+	return jwtware.New(jwtware.Config{
+		Filter: func(c *fiber.Ctx) bool {
+			return c.Get(fiber.HeaderAuthorization) == ""
+		},
+		SigningKey:     []byte(tokenSecret),
+		ContextKey:     "user",
+		SuccessHandler: validator,
+		// an expired or invalid token falls back to an anonymous request
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return c.Next()
+		},
+	})
+}
+
 type AccessTokenValidator interface {
 	CheckToken(ctx context.Context, token *jwt.MapClaims) (bool, error)
 }
@@ -48,6 +65,25 @@ func ValidateAccessToken(validator AccessTokenValidator) fiber.Handler {
 	}
 }
 
+// ValidateOptionalAccessToken drops a revoked token instead of rejecting the request
+func ValidateOptionalAccessToken(validator AccessTokenValidator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := c.Locals("user").(*jwt.Token)
+		claims := user.Claims.(jwt.MapClaims)
+
+		valid, err := validator.CheckToken(c.Context(), &claims)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Internal server error")
+		}
+
+		if !valid {
+			c.Locals("user", nil)
+		}
+
+		return c.Next()
+	}
+}
+
 func ValidateAdminAccessToken(validator AccessTokenValidator) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user := c.Locals("user").(*jwt.Token)
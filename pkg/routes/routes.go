@@ -1,10 +1,17 @@
 package routes
 
 import (
+	anc "office-booking-backend/internal/analytics/controller"
 	ac "office-booking-backend/internal/auth/controller"
 	bc "office-booking-backend/internal/building/controller"
+	nc "office-booking-backend/internal/notification/controller"
+	oc "office-booking-backend/internal/organization/controller"
 	pr "office-booking-backend/internal/payment/controller"
+	rpc "office-booking-backend/internal/report/controller"
+	rsc "office-booking-backend/internal/reportsubscription/controller"
 	rc "office-booking-backend/internal/reservation/controller"
+	rvc "office-booking-backend/internal/review/controller"
+	sc "office-booking-backend/internal/savedsearch/controller"
 	uc "office-booking-backend/internal/user/controller"
 	"office-booking-backend/pkg/middlewares"
 
@@ -12,28 +19,44 @@ import (
 )
 
 type Routes struct {
-	auth                       *ac.AuthController
-	user                       *uc.UserController
-	building                   *bc.BuildingController
-	reservation                *rc.ReservationController
-	payment                    *pr.PaymentController
-	limiter                    *middlewares.Limiter
-	cors                       fiber.Handler
-	accessTokenMiddleware      fiber.Handler
-	adminAccessTokenMiddleware fiber.Handler
+	auth                          *ac.AuthController
+	user                          *uc.UserController
+	building                      *bc.BuildingController
+	reservation                   *rc.ReservationController
+	payment                       *pr.PaymentController
+	organization                  *oc.OrganizationController
+	savedSearch                   *sc.SavedSearchController
+	notification                  *nc.NotificationController
+	analytics                     *anc.AnalyticsController
+	report                        *rpc.ReportController
+	reportSubscription            *rsc.ReportSubscriptionController
+	review                        *rvc.ReviewController
+	limiter                       *middlewares.Limiter
+	cors                          fiber.Handler
+	accessTokenMiddleware         fiber.Handler
+	optionalAccessTokenMiddleware fiber.Handler
+	adminAccessTokenMiddleware    fiber.Handler
 }
 
-func NewRoutes(authController *ac.AuthController, userControllerPkg *uc.UserController, buildingController *bc.BuildingController, reservationController *rc.ReservationController, paymentController *pr.PaymentController, limiter *middlewares.Limiter, accessTokenMiddleware fiber.Handler, adminAccessTokenMiddleware fiber.Handler, cors fiber.Handler) *Routes {
+func NewRoutes(authController *ac.AuthController, userControllerPkg *uc.UserController, buildingController *bc.BuildingController, reservationController *rc.ReservationController, paymentController *pr.PaymentController, organizationController *oc.OrganizationController, savedSearchController *sc.SavedSearchController, notificationController *nc.NotificationController, analyticsController *anc.AnalyticsController, reportController *rpc.ReportController, reportSubscriptionController *rsc.ReportSubscriptionController, reviewController *rvc.ReviewController, limiter *middlewares.Limiter, accessTokenMiddleware fiber.Handler, optionalAccessTokenMiddleware fiber.Handler, adminAccessTokenMiddleware fiber.Handler, cors fiber.Handler) *Routes {
 	return &Routes{
-		auth:                       authController,
-		user:                       userControllerPkg,
-		building:                   buildingController,
-		reservation:                reservationController,
-		payment:                    paymentController,
-		limiter:                    limiter,
-		cors:                       cors,
-		accessTokenMiddleware:      accessTokenMiddleware,
-		adminAccessTokenMiddleware: adminAccessTokenMiddleware,
+		auth:                          authController,
+		user:                          userControllerPkg,
+		building:                      buildingController,
+		reservation:                   reservationController,
+		payment:                       paymentController,
+		organization:                  organizationController,
+		savedSearch:                   savedSearchController,
+		notification:                  notificationController,
+		analytics:                     analyticsController,
+		report:                        reportController,
+		reportSubscription:            reportSubscriptionController,
+		review:                        reviewController,
+		limiter:                       limiter,
+		cors:                          cors,
+		accessTokenMiddleware:         accessTokenMiddleware,
+		optionalAccessTokenMiddleware: optionalAccessTokenMiddleware,
+		adminAccessTokenMiddleware:    adminAccessTokenMiddleware,
 	}
 }
 
@@ -53,10 +76,14 @@ func (r *Routes) Init(app *fiber.App) {
 
 	// Buildings routes
 	building := v1.Group("/buildings")
-	building.Get("/", r.building.GetAllPublishedBuildings)
+	building.Get("/", r.optionalAccessTokenMiddleware, r.building.GetAllPublishedBuildings)
 	building.Get("/facilities/category", r.building.GetFacilityCategories)
-	building.Get("/:buildingID", r.building.GetPublishedBuildingDetailByID)
+	building.Get("/compare", r.building.CompareBuildings)
+	building.Get("/:buildingID", r.optionalAccessTokenMiddleware, r.building.GetPublishedBuildingDetailByID)
 	building.Get("/:buildingID/reviews", r.building.GetBuildingReviews)
+	building.Get("/:buildingID/reviews/pictures", r.building.GetBuildingReviewPictures)
+	building.Get("/:buildingID/similar", r.optionalAccessTokenMiddleware, r.building.GetSimilarBuildings)
+	building.Get("/:buildingID/floor-plans", r.building.GetPublishedBuildingFloorPlans)
 
 	// Location routes
 	location := v1.Group("/locations")
@@ -85,21 +112,68 @@ func (r *Routes) Init(app *fiber.App) {
 	user.Put("/", r.accessTokenMiddleware, r.user.UpdateLoggedUser)
 	user.Put("/picture", r.accessTokenMiddleware, r.user.UpdateUserAvatar)
 	user.Put("/change-password", r.accessTokenMiddleware, r.auth.ChangePassword)
+	user.Get("/favorites", r.accessTokenMiddleware, r.building.GetUserFavorites)
+	user.Post("/favorites/:buildingID", r.accessTokenMiddleware, r.building.AddFavorite)
+	user.Delete("/favorites/:buildingID", r.accessTokenMiddleware, r.building.RemoveFavorite)
+	user.Get("/saved-searches", r.accessTokenMiddleware, r.savedSearch.GetUserSavedSearches)
+	user.Post("/saved-searches", r.accessTokenMiddleware, r.savedSearch.CreateSavedSearch)
+	user.Get("/saved-searches/unsubscribe/:token", r.savedSearch.ConfirmUnsubscribe)
+	user.Post("/saved-searches/unsubscribe/:token", r.savedSearch.Unsubscribe)
+	user.Get("/saved-searches/:savedSearchID", r.accessTokenMiddleware, r.savedSearch.GetSavedSearchByID)
+	user.Put("/saved-searches/:savedSearchID", r.accessTokenMiddleware, r.savedSearch.UpdateSavedSearch)
+	user.Delete("/saved-searches/:savedSearchID", r.accessTokenMiddleware, r.savedSearch.DeleteSavedSearch)
+	user.Get("/notifications", r.accessTokenMiddleware, r.notification.GetUserNotifications)
+	user.Put("/notifications/read", r.accessTokenMiddleware, r.notification.MarkAllNotificationsAsRead)
+	user.Put("/notifications/:notificationID/read", r.accessTokenMiddleware, r.notification.MarkNotificationAsRead)
+	user.Delete("/notifications/:notificationID", r.accessTokenMiddleware, r.notification.DeleteNotification)
 
 	// Enduser.Reservation routes
 	uReservation := v1.Group("/reservations")
 	uReservation.Get("/", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.GetUserReservations)
 	uReservation.Post("/", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.CreateReservation)
+	uReservation.Get("/transfers", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.GetUserReservationTransfers)
+	uReservation.Put("/transfers/:transferID/accept", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.AcceptReservationTransfer)
+	uReservation.Put("/transfers/:transferID/decline", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.DeclineReservationTransfer)
 	uReservation.Get("/:reservationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.GetUserReservationDetailByID)
 	uReservation.Delete("/:reservationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.CancelReservation)
 	uReservation.Post("/:reservationID/reviews", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.CreateReservationReview)
 	uReservation.Put("/:reservationID/reviews", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.UpdateReservationReview)
 	uReservation.Get("/:reservationID/reviews", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.GetUserReservationReview)
+	uReservation.Post("/:reservationID/transfers", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.CreateReservationTransfer)
+	uReservation.Delete("/:reservationID/transfers", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.reservation.CancelReservationTransfer)
+
+	// Enduser.Organization routes
+	organization := v1.Group("/organizations")
+	organization.Get("/", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.GetUserOrganizations)
+	organization.Post("/", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.CreateOrganization)
+	organization.Get("/:organizationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.GetOrganizationByID)
+	organization.Put("/:organizationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.UpdateOrganization)
+	organization.Delete("/:organizationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.DeleteOrganization)
+	organization.Put("/:organizationID/policy", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.UpdateOrganizationPolicy)
+	organization.Get("/:organizationID/approvals", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.GetPendingApprovals)
+	organization.Get("/:organizationID/approvals/logs", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.GetApprovalLogs)
+	organization.Put("/:organizationID/approvals/:reservationID/approve", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.ApproveOrganizationReservation)
+	organization.Put("/:organizationID/approvals/:reservationID/reject", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.RejectOrganizationReservation)
+	organization.Post("/:organizationID/members", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.AddOrganizationMember)
+	organization.Put("/:organizationID/members/:userID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.UpdateOrganizationMember)
+	organization.Delete("/:organizationID/members/:userID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.RemoveOrganizationMember)
+	organization.Get("/:organizationID/reservations", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.GetOrganizationReservations)
+	organization.Post("/:organizationID/reservations", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.CreateOrganizationReservation)
+	organization.Get("/:organizationID/reservations/:reservationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.GetOrganizationReservationByID)
+	organization.Delete("/:organizationID/reservations/:reservationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.organization.CancelOrganizationReservation)
 
 	// Enduser.Payment routes
 	payment.Get("/:reservationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.payment.GetUsereservationPaymentByID)
 	payment.Post("/:reservationID", r.accessTokenMiddleware, middlewares.EnforceValidEmail(), r.payment.UploadPaymentProof)
 
+	// Enduser.Review routes
+	uReview := v1.Group("/reviews")
+	uReview.Post("/:reviewID/report", r.accessTokenMiddleware, r.review.ReportReview)
+	uReview.Post("/:reviewID/reply", r.accessTokenMiddleware, r.review.ReplyReview)
+	uReview.Put("/:reviewID/reply", r.accessTokenMiddleware, r.review.UpdateReviewReply)
+	uReview.Post("/:reviewID/pictures", r.accessTokenMiddleware, r.review.AddReviewPicture)
+	uReview.Delete("/:reviewID/pictures/:pictureID", r.accessTokenMiddleware, r.review.DeleteReviewPicture)
+
 	// Admin routes
 	admin := v1.Group("/admin")
 
@@ -120,14 +194,30 @@ func (r *Routes) Init(app *fiber.App) {
 	aBuilding.Get("/", r.adminAccessTokenMiddleware, r.building.GetAllBuildings)
 	aBuilding.Get("/id", r.adminAccessTokenMiddleware, r.building.RequestNewBuildingID)
 	aBuilding.Get("/total", r.adminAccessTokenMiddleware, r.building.GetBuildingTotal)
+	aBuilding.Get("/export", r.adminAccessTokenMiddleware, r.building.ExportBuildings)
+	aBuilding.Post("/import", r.adminAccessTokenMiddleware, r.building.ImportBuildings)
+	aBuilding.Get("/analytics", r.adminAccessTokenMiddleware, r.analytics.GetBuildingLeaderboard)
 	aBuilding.Get("/:buildingID", r.adminAccessTokenMiddleware, r.building.GetBuildingDetailByID)
 	aBuilding.Delete("/:buildingID", r.adminAccessTokenMiddleware, r.building.DeleteBuilding)
 	aBuilding.Put("/:buildingID", r.adminAccessTokenMiddleware, r.building.UpdateBuilding)
 	aBuilding.Put("/:buildingID/publish", r.adminAccessTokenMiddleware, r.building.UpdateBuildingPublishState)
+	aBuilding.Put("/:buildingID/schedule", r.adminAccessTokenMiddleware, r.building.ScheduleBuildingPublishState)
+	aBuilding.Delete("/:buildingID/schedule", r.adminAccessTokenMiddleware, r.building.CancelBuildingPublishSchedule)
+	aBuilding.Put("/:buildingID/hours", r.adminAccessTokenMiddleware, r.building.UpdateBuildingOpeningHours)
+	aBuilding.Get("/:buildingID/analytics", r.adminAccessTokenMiddleware, r.analytics.GetBuildingFunnel)
+	aBuilding.Get("/:buildingID/revisions", r.adminAccessTokenMiddleware, r.building.GetBuildingRevisions)
+	aBuilding.Get("/:buildingID/revisions/:revisionID", r.adminAccessTokenMiddleware, r.building.PreviewBuildingRevision)
+	aBuilding.Post("/:buildingID/revisions/:revisionID/publish", r.adminAccessTokenMiddleware, r.building.PublishBuildingRevision)
+	aBuilding.Post("/:buildingID/revisions/:revisionID/discard", r.adminAccessTokenMiddleware, r.building.DiscardBuildingRevision)
+	aBuilding.Post("/:buildingID/revisions/:revisionID/restore", r.adminAccessTokenMiddleware, r.building.RestoreBuildingRevision)
 	aBuilding.Post("/:buildingID/facilities", r.adminAccessTokenMiddleware, r.building.AddBuildingFacilities)
 	aBuilding.Delete("/:buildingID/facilities/:facilityID", r.adminAccessTokenMiddleware, r.building.DeleteBuildingFacility)
 	aBuilding.Post("/:buildingID/pictures", r.adminAccessTokenMiddleware, r.building.AddBuildingPicture)
 	aBuilding.Delete("/:buildingID/pictures/:pictureID", r.adminAccessTokenMiddleware, r.building.DeleteBuildingPicture)
+	aBuilding.Get("/:buildingID/floor-plans", r.adminAccessTokenMiddleware, r.building.GetBuildingFloorPlans)
+	aBuilding.Post("/:buildingID/floor-plans", r.adminAccessTokenMiddleware, r.building.AddBuildingFloorPlan)
+	aBuilding.Put("/:buildingID/floor-plans/:floorPlanID/hotspots", r.adminAccessTokenMiddleware, r.building.UpdateFloorPlanHotspots)
+	aBuilding.Delete("/:buildingID/floor-plans/:floorPlanID", r.adminAccessTokenMiddleware, r.building.DeleteBuildingFloorPlan)
 
 	// Admin.Reservation routes
 	aReservation := admin.Group("/reservations")
@@ -139,6 +229,31 @@ func (r *Routes) Init(app *fiber.App) {
 	aReservation.Put("/:reservationID", r.adminAccessTokenMiddleware, r.reservation.UpdateReservation)
 	aReservation.Delete("/:reservationID", r.adminAccessTokenMiddleware, r.reservation.DeleteReservation)
 	aReservation.Put("/:reservationID/status", r.adminAccessTokenMiddleware, r.reservation.UpdateReservationStatus)
+	aReservation.Get("/:reservationID/transfers", r.adminAccessTokenMiddleware, r.reservation.GetReservationTransfers)
+
+	// Admin.Review routes
+	aReview := admin.Group("/reviews")
+	aReview.Get("/", r.adminAccessTokenMiddleware, r.review.GetReviews)
+	aReview.Get("/:reviewID", r.adminAccessTokenMiddleware, r.review.GetReviewByID)
+	aReview.Put("/:reviewID/status", r.adminAccessTokenMiddleware, r.review.UpdateReviewStatus)
+	aReview.Delete("/:reviewID", r.adminAccessTokenMiddleware, r.review.DeleteReview)
+
+	// Admin.Report routes
+	aReport := admin.Group("/reports")
+	aReport.Get("/occupancy", r.adminAccessTokenMiddleware, r.report.GetOccupancyReport)
+	aReport.Get("/revenue", r.adminAccessTokenMiddleware, r.report.GetRevenueReport)
+	aReport.Get("/leases", r.adminAccessTokenMiddleware, r.report.GetLeaseReport)
+	aReport.Get("/forecast", r.adminAccessTokenMiddleware, r.report.GetRevenueForecast)
+
+	// Admin.ReportSubscription routes
+	aReportSubscription := admin.Group("/report-subscriptions")
+	aReportSubscription.Get("/", r.adminAccessTokenMiddleware, r.reportSubscription.GetUserReportSubscriptions)
+	aReportSubscription.Post("/", r.adminAccessTokenMiddleware, r.reportSubscription.CreateReportSubscription)
+	aReportSubscription.Get("/unsubscribe/:token", r.reportSubscription.ConfirmUnsubscribe)
+	aReportSubscription.Post("/unsubscribe/:token", r.reportSubscription.Unsubscribe)
+	aReportSubscription.Get("/:subscriptionID", r.adminAccessTokenMiddleware, r.reportSubscription.GetReportSubscriptionByID)
+	aReportSubscription.Put("/:subscriptionID", r.adminAccessTokenMiddleware, r.reportSubscription.UpdateReportSubscription)
+	aReportSubscription.Delete("/:subscriptionID", r.adminAccessTokenMiddleware, r.reportSubscription.DeleteReportSubscription)
 
 	// Admin.Payment routes
 	aPayment := admin.Group("/payments")
@@ -0,0 +1,161 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	err2 "office-booking-backend/pkg/errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// timeFormat matches the way DATETIME values are stored, so a time cursor can be compared in SQL directly
+const timeFormat = "2006-01-02 15:04:05.999999"
+
+// Cursor points at an item of a list: the value of the sort column and the id as tie breaker
+type Cursor struct {
+	Value string `json:"v"`
+	ID    string `json:"i"`
+	// Backward is set on previous page cursors
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode returns the opaque representation of the cursor
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a cursor returned by Encode
+func Decode(token string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err2.ErrInvalidCursor
+	}
+
+	c := new(Cursor)
+	err = json.Unmarshal(b, c)
+	if err != nil || c.ID == "" {
+		return nil, err2.ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// Time formats a time sort value
+func Time(t time.Time) string {
+	return t.In(time.Local).Format(timeFormat)
+}
+
+// Int formats an integer sort value
+func Int(i int) string {
+	return strconv.Itoa(i)
+}
+
+// Pagination is embedded in list query params to support cursor pagination next to page pagination.
+// Cursor pagination is enabled when the cursor param is present, an empty cursor requests the first page
+type Pagination struct {
+	Cursor     string  `query:"cursor"`
+	Enabled    bool    `query:"-"`
+	Position   *Cursor `query:"-"`
+	NextCursor string  `query:"-"`
+	PrevCursor string  `query:"-"`
+}
+
+// Detect enables cursor pagination when the request has a cursor query param
+func (p *Pagination) Detect(c *fiber.Ctx) bool {
+	p.Enabled = c.Context().QueryArgs().Has("cursor")
+	p.Cursor = c.Query("cursor")
+	return p.Enabled
+}
+
+// Meta returns the meta of a cursor paginated response
+func (p *Pagination) Meta(limit int) fiber.Map {
+	return fiber.Map{
+		"limit":      limit,
+		"nextCursor": p.NextCursor,
+		"prevCursor": p.PrevCursor,
+	}
+}
+
+// Decode decodes the cursor param when cursor pagination is enabled
+func (p *Pagination) Decode() error {
+	if !p.Enabled || p.Cursor == "" {
+		return nil
+	}
+
+	position, err := Decode(p.Cursor)
+	if err != nil {
+		return err
+	}
+
+	p.Position = position
+	return nil
+}
+
+// Where returns the keyset condition selecting the items after the cursor position in the given sort order,
+// it returns an empty condition for the first page
+func (p *Pagination) Where(column string, idColumn string, desc bool) (string, []interface{}) {
+	if p.Position == nil {
+		return "", nil
+	}
+
+	operator := ">"
+	if desc != p.Position.Backward {
+		operator = "<"
+	}
+
+	return fmt.Sprintf("(%[1]s %[3]s ? OR (%[1]s = ? AND %[2]s %[3]s ?))", column, idColumn, operator),
+		[]interface{}{p.Position.Value, p.Position.Value, p.Position.ID}
+}
+
+// OrderBy returns the order clauses for the sort column and the id tie breaker,
+// a backward page is fetched in the reverse order
+func (p *Pagination) OrderBy(column string, idColumn string, desc bool) []string {
+	direction := "ASC"
+	if desc != (p.Position != nil && p.Position.Backward) {
+		direction = "DESC"
+	}
+
+	return []string{column + " " + direction, idColumn + " " + direction}
+}
+
+// Paginate trims the extra item fetched to detect another page, restores the order of a backward page
+// and sets the next and previous cursors. The query must fetch limit + 1 items
+func Paginate[T any](p *Pagination, items []T, limit int, key func(item *T) (value string, id string)) []T {
+	backward := p.Position != nil && p.Position.Backward
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	p.NextCursor, p.PrevCursor = "", ""
+	if len(items) == 0 {
+		return items
+	}
+
+	// moving forward there's a previous page whenever we didn't start from the beginning,
+	// moving backward there's always a next page
+	hasNext := hasMore || backward
+	hasPrev := (hasMore && backward) || (!backward && p.Position != nil)
+
+	if hasNext {
+		value, id := key(&items[len(items)-1])
+		p.NextCursor = (&Cursor{Value: value, ID: id}).Encode()
+	}
+
+	if hasPrev {
+		value, id := key(&items[0])
+		p.PrevCursor = (&Cursor{Value: value, ID: id, Backward: true}).Encode()
+	}
+
+	return items
+}
@@ -0,0 +1,85 @@
+package cursor
+
+import (
+	"strconv"
+	"testing"
+
+	err2 "office-booking-backend/pkg/errors"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type item struct {
+	ID    string
+	Value int
+}
+
+func key(i *item) (string, string) {
+	return strconv.Itoa(i.Value), i.ID
+}
+
+type TestSuiteCursor struct {
+	suite.Suite
+}
+
+func TestCursor(t *testing.T) {
+	suite.Run(t, new(TestSuiteCursor))
+}
+
+func (s *TestSuiteCursor) TestEncodeDecode() {
+	c := &Cursor{Value: "2022-12-01 10:00:00", ID: "abc", Backward: true}
+	decoded, err := Decode(c.Encode())
+	s.NoError(err)
+	s.Equal(c, decoded)
+}
+
+func (s *TestSuiteCursor) TestDecode_Invalid() {
+	_, err := Decode("not a cursor")
+	s.Equal(err2.ErrInvalidCursor, err)
+}
+
+func (s *TestSuiteCursor) TestWhere_FirstPage() {
+	p := &Pagination{Enabled: true}
+	condition, args := p.Where("price", "id", true)
+	s.Empty(condition)
+	s.Nil(args)
+	s.Equal([]string{"price DESC", "id DESC"}, p.OrderBy("price", "id", true))
+}
+
+func (s *TestSuiteCursor) TestWhere_Backward() {
+	p := &Pagination{Enabled: true, Position: &Cursor{Value: "5", ID: "x", Backward: true}}
+	condition, args := p.Where("price", "id", true)
+	s.Equal("(price > ? OR (price = ? AND id > ?))", condition)
+	s.Equal([]interface{}{"5", "5", "x"}, args)
+	s.Equal([]string{"price ASC", "id ASC"}, p.OrderBy("price", "id", true))
+}
+
+func (s *TestSuiteCursor) TestPaginate_FirstPage() {
+	p := &Pagination{Enabled: true}
+	items := Paginate(p, []item{{"a", 1}, {"b", 2}, {"c", 3}}, 2, key)
+	s.Len(items, 2)
+	s.NotEmpty(p.NextCursor)
+	s.Empty(p.PrevCursor)
+
+	next, _ := Decode(p.NextCursor)
+	s.Equal(&Cursor{Value: "2", ID: "b"}, next)
+}
+
+func (s *TestSuiteCursor) TestPaginate_LastPage() {
+	p := &Pagination{Enabled: true, Position: &Cursor{Value: "2", ID: "b"}}
+	items := Paginate(p, []item{{"c", 3}}, 2, key)
+	s.Len(items, 1)
+	s.Empty(p.NextCursor)
+
+	prev, _ := Decode(p.PrevCursor)
+	s.Equal(&Cursor{Value: "3", ID: "c", Backward: true}, prev)
+}
+
+func (s *TestSuiteCursor) TestPaginate_Backward() {
+	p := &Pagination{Enabled: true, Position: &Cursor{Value: "3", ID: "c", Backward: true}}
+	// fetched in reverse order
+	items := Paginate(p, []item{{"b", 2}, {"a", 1}}, 2, key)
+	s.Equal([]item{{"a", 1}, {"b", 2}}, items)
+	s.NotEmpty(p.NextCursor)
+	s.Empty(p.PrevCursor)
+}
@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// EarthRadiusKm is the mean radius of the earth
+	EarthRadiusKm = 6371.0088
+	// kmPerDegree is the length of one degree of latitude
+	kmPerDegree = math.Pi * EarthRadiusKm / 180
+)
+
+// Haversine returns the great-circle distance in kilometers between two points
+func Haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Pow(math.Sin(dLng/2), 2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox is a rectangle of coordinates, used to narrow down the candidates before computing exact distances
+type BoundingBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+// NewBoundingBox returns the smallest box containing every point within radiusKm of the center
+func NewBoundingBox(lat, lng, radiusKm float64) *BoundingBox {
+	dLat := radiusKm / kmPerDegree
+	box := &BoundingBox{
+		MinLat: math.Max(lat-dLat, -90),
+		MaxLat: math.Min(lat+dLat, 90),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+
+	// near the poles the box covers every longitude
+	cos := math.Cos(toRadians(lat))
+	if box.MinLat > -90 && box.MaxLat < 90 && cos > 0 {
+		dLng := radiusKm / (kmPerDegree * cos)
+		if dLng < 180 {
+			box.MinLng = math.Max(lng-dLng, -180)
+			box.MaxLng = math.Min(lng+dLng, 180)
+		}
+	}
+
+	return box
+}
+
+// Contains reports whether the point is inside the box
+func (b *BoundingBox) Contains(lat, lng float64) bool {
+	return lat >= b.MinLat && lat <= b.MaxLat && lng >= b.MinLng && lng <= b.MaxLng
+}
+
+// Split returns the box as boxes that don't cross the antimeridian, a box crossing it has
+// a MinLng greater than its MaxLng
+func (b *BoundingBox) Split() []BoundingBox {
+	if b.MinLng <= b.MaxLng {
+		return []BoundingBox{*b}
+	}
+
+	return []BoundingBox{
+		{MinLat: b.MinLat, MinLng: b.MinLng, MaxLat: b.MaxLat, MaxLng: 180},
+		{MinLat: b.MinLat, MinLng: -180, MaxLat: b.MaxLat, MaxLng: b.MaxLng},
+	}
+}
+
+// WKT returns the box as a well-known text polygon with x as longitude and y as latitude
+func (b *BoundingBox) WKT() string {
+	return fmt.Sprintf("POLYGON((%[1]f %[2]f, %[3]f %[2]f, %[3]f %[4]f, %[1]f %[4]f, %[1]f %[2]f))",
+		b.MinLng, b.MinLat, b.MaxLng, b.MaxLat)
+}
+
+func toRadians(degree float64) float64 {
+	return degree * math.Pi / 180
+}
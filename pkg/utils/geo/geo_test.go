@@ -0,0 +1,68 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteGeo struct {
+	suite.Suite
+}
+
+func TestGeo(t *testing.T) {
+	suite.Run(t, new(TestSuiteGeo))
+}
+
+func (s *TestSuiteGeo) TestHaversine_SamePoint() {
+	s.Equal(0.0, Haversine(-8.65, 115.21, -8.65, 115.21))
+}
+
+func (s *TestSuiteGeo) TestHaversine_Distance() {
+	// one degree along the equator
+	s.InDelta(111.195, Haversine(0, 0, 0, 1), 0.01)
+	// one degree along a meridian
+	s.InDelta(111.195, Haversine(-8, 115, -9, 115), 0.01)
+}
+
+func (s *TestSuiteGeo) TestHaversine_Symmetric() {
+	s.InDelta(Haversine(-8.6705, 115.2126, -6.2088, 106.8456), Haversine(-6.2088, 106.8456, -8.6705, 115.2126), 1e-9)
+}
+
+func (s *TestSuiteGeo) TestNewBoundingBox_ContainsRadius() {
+	lat, lng, radius := -8.65, 115.21, 10.0
+	box := NewBoundingBox(lat, lng, radius)
+
+	s.True(box.Contains(lat, lng))
+	// points exactly radius away in every direction must be inside the box
+	s.True(box.Contains(lat+radius/kmPerDegree*0.999, lng))
+	s.True(box.Contains(lat-radius/kmPerDegree*0.999, lng))
+	s.InDelta(radius, Haversine(lat, lng, lat, box.MaxLng), 0.1)
+	s.False(box.Contains(lat+1, lng))
+}
+
+func (s *TestSuiteGeo) TestNewBoundingBox_Pole() {
+	box := NewBoundingBox(89.99, 0, 50)
+	s.Equal(90.0, box.MaxLat)
+	s.Equal(-180.0, box.MinLng)
+	s.Equal(180.0, box.MaxLng)
+}
+
+func (s *TestSuiteGeo) TestBoundingBox_WKT() {
+	box := &BoundingBox{MinLat: -9, MinLng: 115, MaxLat: -8, MaxLng: 116}
+	s.Equal("POLYGON((115.000000 -9.000000, 116.000000 -9.000000, 116.000000 -8.000000, 115.000000 -8.000000, 115.000000 -9.000000))", box.WKT())
+}
+
+func (s *TestSuiteGeo) TestBoundingBox_Split() {
+	box := &BoundingBox{MinLat: -9, MinLng: 115, MaxLat: -8, MaxLng: 116}
+	s.Equal([]BoundingBox{*box}, box.Split())
+
+	// a box crossing the antimeridian is split at 180°
+	crossing := &BoundingBox{MinLat: -20, MinLng: 170, MaxLat: -10, MaxLng: -170}
+	halves := crossing.Split()
+	s.Len(halves, 2)
+	s.True(halves[0].Contains(-15, 175))
+	s.True(halves[1].Contains(-15, -175))
+	s.False(halves[0].Contains(-15, 0))
+	s.False(halves[1].Contains(-15, 0))
+}
@@ -9,6 +9,7 @@ import (
 
 type ImgKitService interface {
 	UploadFile(ctx context.Context, file io.Reader, fileName string, folder string) (*uploader.UploadResult, error)
+	UploadRemoteFile(ctx context.Context, url string, fileName string, folder string) (*uploader.UploadResult, error)
 	DeleteFile(ctx context.Context, fileId string) error
 }
 
@@ -40,6 +41,21 @@ func (t *ImgKitServiceImpl) UploadFile(ctx context.Context, file io.Reader, file
 	return &uploadResp.Data, nil
 }
 
+// UploadRemoteFile lets imagekit fetch the file from a public url
+func (t *ImgKitServiceImpl) UploadRemoteFile(ctx context.Context, url string, fileName string, folder string) (*uploader.UploadResult, error) {
+	falsePtr := false
+	uploadResp, err := t.ImageKitClient.Uploader.Upload(ctx, url, uploader.UploadParam{
+		FileName:          fileName,
+		Folder:            folder,
+		UseUniqueFileName: &falsePtr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &uploadResp.Data, nil
+}
+
 func (t *ImgKitServiceImpl) DeleteFile(ctx context.Context, fileId string) error {
 	_, err := t.ImageKitClient.Media.DeleteFile(ctx, fileId)
 	return err
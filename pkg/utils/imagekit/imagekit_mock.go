@@ -16,6 +16,11 @@ func (i *ImgKitServiceMock) UploadFile(ctx context.Context, file io.Reader, file
 	return args.Get(0).(*uploader.UploadResult), args.Error(1)
 }
 
+func (i *ImgKitServiceMock) UploadRemoteFile(ctx context.Context, url string, fileName string, folder string) (*uploader.UploadResult, error) {
+	args := i.Called(ctx, url, fileName, folder)
+	return args.Get(0).(*uploader.UploadResult), args.Error(1)
+}
+
 func (i *ImgKitServiceMock) DeleteFile(ctx context.Context, fileId string) error {
 	args := i.Called(ctx, fileId)
 	return args.Error(0)
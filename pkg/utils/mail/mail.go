@@ -7,11 +7,17 @@ import (
 	"time"
 )
 
+type Attachment struct {
+	Filename string
+	Content  []byte
+}
+
 type Mail struct {
-	Subject   string
-	Template  string
-	Variable  map[string]string
-	Recipient string
+	Subject     string
+	Template    string
+	Variable    map[string]string
+	Attachments []Attachment
+	Recipient   string
 }
 
 type Client interface {
@@ -44,6 +50,10 @@ func (c *clientImpl) SendMail(ctx context.Context, mail *Mail) error {
 		}
 	}
 
+	for _, attachment := range mail.Attachments {
+		m.AddBufferAttachment(attachment.Filename, attachment.Content)
+	}
+
 	ct, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
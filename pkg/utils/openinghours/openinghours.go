@@ -0,0 +1,204 @@
+package openinghours
+
+import (
+	"fmt"
+	err2 "office-booking-backend/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MinutesPerDay is the closing time of a period that lasts until midnight
+	MinutesPerDay = 24 * 60
+	// DateFormat is the format of the exception dates
+	DateFormat = "2006-01-02"
+)
+
+// Period is an opening period in minutes since midnight
+type Period struct {
+	Open  int
+	Close int
+}
+
+// Schedule is the opening hours of a building in its own timezone,
+// a date with an exception uses the exception periods instead of the weekly ones and no periods means closed
+type Schedule struct {
+	Location   *time.Location
+	Weekly     map[time.Weekday][]Period
+	Exceptions map[string][]Period
+}
+
+// NewSchedule returns an empty schedule, an empty timezone uses the server timezone
+func NewSchedule(timezone string) (*Schedule, error) {
+	location := time.Local
+	if timezone != "" {
+		var err error
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, err2.ErrInvalidTimezone
+		}
+	}
+
+	return &Schedule{
+		Location:   location,
+		Weekly:     map[time.Weekday][]Period{},
+		Exceptions: map[string][]Period{},
+	}, nil
+}
+
+// ParseClock returns the minutes since midnight of a "15:04" time, "24:00" is the end of the day
+func ParseClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, err2.ErrInvalidOpeningHours
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err2.ErrInvalidOpeningHours
+	}
+
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err2.ErrInvalidOpeningHours
+	}
+
+	minutes := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || minutes > MinutesPerDay {
+		return 0, err2.ErrInvalidOpeningHours
+	}
+
+	return minutes, nil
+}
+
+// FormatClock is the reverse of ParseClock
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NewPeriod parses the opening and closing time of a period
+func NewPeriod(open string, close string) (Period, error) {
+	openMinutes, err := ParseClock(open)
+	if err != nil {
+		return Period{}, err
+	}
+
+	closeMinutes, err := ParseClock(close)
+	if err != nil {
+		return Period{}, err
+	}
+
+	if closeMinutes <= openMinutes {
+		return Period{}, err2.ErrInvalidOpeningHours
+	}
+
+	return Period{Open: openMinutes, Close: closeMinutes}, nil
+}
+
+// AddWeekly adds an opening period to a weekday
+func (s *Schedule) AddWeekly(weekday time.Weekday, period Period) error {
+	periods, err := addPeriod(s.Weekly[weekday], period)
+	if err != nil {
+		return err
+	}
+
+	s.Weekly[weekday] = periods
+	return nil
+}
+
+// AddException adds an opening period on a date, a nil period closes the building for the whole date
+func (s *Schedule) AddException(date time.Time, period *Period) error {
+	key := date.Format(DateFormat)
+	if period == nil {
+		if _, ok := s.Exceptions[key]; !ok {
+			s.Exceptions[key] = []Period{}
+		}
+		return nil
+	}
+
+	periods, err := addPeriod(s.Exceptions[key], *period)
+	if err != nil {
+		return err
+	}
+
+	s.Exceptions[key] = periods
+	return nil
+}
+
+// addPeriod keeps the periods of a day sorted and rejects overlapping ones
+func addPeriod(periods []Period, period Period) ([]Period, error) {
+	for _, p := range periods {
+		if period.Open < p.Close && p.Open < period.Close {
+			return nil, err2.ErrInvalidOpeningHours
+		}
+	}
+
+	periods = append(periods, period)
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Open < periods[j].Open
+	})
+	return periods, nil
+}
+
+// IsEmpty reports whether no weekly hours are set, a building without hours is not restricted
+func (s *Schedule) IsEmpty() bool {
+	return len(s.Weekly) == 0
+}
+
+// PeriodsOn returns the opening periods of a calendar date
+func (s *Schedule) PeriodsOn(date time.Time) []Period {
+	if periods, ok := s.Exceptions[date.Format(DateFormat)]; ok {
+		return periods
+	}
+	return s.Weekly[date.Weekday()]
+}
+
+// IsOpenOn reports whether the building opens at any time on a calendar date
+func (s *Schedule) IsOpenOn(date time.Time) bool {
+	return s.IsEmpty() || len(s.PeriodsOn(date)) > 0
+}
+
+// IsOpenAt reports whether the building is open at an instant
+func (s *Schedule) IsOpenAt(t time.Time) bool {
+	if s.IsEmpty() {
+		return true
+	}
+
+	local := t.In(s.Location)
+	minute := local.Hour()*60 + local.Minute()
+	for _, period := range s.PeriodsOn(local) {
+		if period.Open <= minute && minute < period.Close {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAlwaysOpen reports whether the weekly hours cover every day around the clock
+func (s *Schedule) IsAlwaysOpen() bool {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if !coversDay(s.Weekly[weekday]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsOpenOnWeekends reports whether the building opens on both saturday and sunday
+func (s *Schedule) IsOpenOnWeekends() bool {
+	return len(s.Weekly[time.Saturday]) > 0 && len(s.Weekly[time.Sunday]) > 0
+}
+
+// coversDay reports whether sorted periods leave no gap between midnight and midnight
+func coversDay(periods []Period) bool {
+	end := 0
+	for _, period := range periods {
+		if period.Open > end {
+			return false
+		}
+		end = period.Close
+	}
+	return end == MinutesPerDay
+}
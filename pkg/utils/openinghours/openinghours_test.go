@@ -0,0 +1,129 @@
+package openinghours
+
+import (
+	err2 "office-booking-backend/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteOpeningHours struct {
+	suite.Suite
+	schedule *Schedule
+}
+
+func TestOpeningHours(t *testing.T) {
+	suite.Run(t, new(TestSuiteOpeningHours))
+}
+
+func (s *TestSuiteOpeningHours) SetupTest() {
+	schedule, err := NewSchedule("Asia/Makassar")
+	s.Require().NoError(err)
+
+	// monday to friday 08:00-12:00 and 13:00-17:00
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		s.Require().NoError(schedule.AddWeekly(weekday, Period{Open: 8 * 60, Close: 12 * 60}))
+		s.Require().NoError(schedule.AddWeekly(weekday, Period{Open: 13 * 60, Close: 17 * 60}))
+	}
+	s.schedule = schedule
+}
+
+func (s *TestSuiteOpeningHours) date(value string) time.Time {
+	date, err := time.Parse(DateFormat, value)
+	s.Require().NoError(err)
+	return date
+}
+
+func (s *TestSuiteOpeningHours) TestParseClock() {
+	for value, expected := range map[string]int{"00:00": 0, "08:30": 510, "23:59": 1439, "24:00": MinutesPerDay} {
+		minutes, err := ParseClock(value)
+		s.NoError(err)
+		s.Equal(expected, minutes)
+		s.Equal(value, FormatClock(minutes))
+	}
+
+	for _, value := range []string{"", "8:00", "08:60", "24:01", "25:00", "aa:bb", "08-00"} {
+		_, err := ParseClock(value)
+		s.Equal(err2.ErrInvalidOpeningHours, err, value)
+	}
+}
+
+func (s *TestSuiteOpeningHours) TestNewPeriod_CloseBeforeOpen() {
+	_, err := NewPeriod("17:00", "08:00")
+	s.Equal(err2.ErrInvalidOpeningHours, err)
+
+	_, err = NewPeriod("08:00", "08:00")
+	s.Equal(err2.ErrInvalidOpeningHours, err)
+}
+
+func (s *TestSuiteOpeningHours) TestNewSchedule_InvalidTimezone() {
+	_, err := NewSchedule("Mars/Olympus")
+	s.Equal(err2.ErrInvalidTimezone, err)
+}
+
+func (s *TestSuiteOpeningHours) TestAddWeekly_Overlap() {
+	err := s.schedule.AddWeekly(time.Monday, Period{Open: 11 * 60, Close: 14 * 60})
+	s.Equal(err2.ErrInvalidOpeningHours, err)
+
+	// touching periods don't overlap
+	s.NoError(s.schedule.AddWeekly(time.Monday, Period{Open: 12 * 60, Close: 13 * 60}))
+}
+
+func (s *TestSuiteOpeningHours) TestIsOpenOn() {
+	// 2022-12-19 is a monday
+	s.True(s.schedule.IsOpenOn(s.date("2022-12-19")))
+	s.False(s.schedule.IsOpenOn(s.date("2022-12-24")))
+	s.False(s.schedule.IsOpenOn(s.date("2022-12-25")))
+}
+
+func (s *TestSuiteOpeningHours) TestIsOpenOn_Exceptions() {
+	s.Require().NoError(s.schedule.AddException(s.date("2022-12-26"), nil))
+	s.Require().NoError(s.schedule.AddException(s.date("2022-12-24"), &Period{Open: 9 * 60, Close: 12 * 60}))
+
+	s.False(s.schedule.IsOpenOn(s.date("2022-12-26")))
+	s.True(s.schedule.IsOpenOn(s.date("2022-12-24")))
+}
+
+func (s *TestSuiteOpeningHours) TestIsOpenAt_Timezone() {
+	// 08:30 in Makassar (UTC+8) is 00:30 UTC
+	s.True(s.schedule.IsOpenAt(time.Date(2022, 12, 19, 0, 30, 0, 0, time.UTC)))
+	// 07:59 in Makassar
+	s.False(s.schedule.IsOpenAt(time.Date(2022, 12, 18, 23, 59, 0, 0, time.UTC)))
+	// lunch break
+	s.False(s.schedule.IsOpenAt(time.Date(2022, 12, 19, 4, 30, 0, 0, time.UTC)))
+	// closing time is exclusive
+	s.False(s.schedule.IsOpenAt(time.Date(2022, 12, 19, 9, 0, 0, 0, time.UTC)))
+}
+
+func (s *TestSuiteOpeningHours) TestIsEmpty_NotRestricted() {
+	schedule, err := NewSchedule("")
+	s.Require().NoError(err)
+
+	s.True(schedule.IsEmpty())
+	s.True(schedule.IsOpenOn(s.date("2022-12-25")))
+	s.True(schedule.IsOpenAt(time.Now()))
+}
+
+func (s *TestSuiteOpeningHours) TestIsAlwaysOpen() {
+	s.False(s.schedule.IsAlwaysOpen())
+
+	schedule, err := NewSchedule("")
+	s.Require().NoError(err)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		s.Require().NoError(schedule.AddWeekly(weekday, Period{Open: 12 * 60, Close: MinutesPerDay}))
+		s.Require().NoError(schedule.AddWeekly(weekday, Period{Open: 0, Close: 12 * 60}))
+	}
+	s.True(schedule.IsAlwaysOpen())
+	s.True(schedule.IsOpenOnWeekends())
+}
+
+func (s *TestSuiteOpeningHours) TestIsOpenOnWeekends() {
+	s.False(s.schedule.IsOpenOnWeekends())
+
+	s.Require().NoError(s.schedule.AddWeekly(time.Saturday, Period{Open: 9 * 60, Close: 12 * 60}))
+	s.False(s.schedule.IsOpenOnWeekends())
+
+	s.Require().NoError(s.schedule.AddWeekly(time.Sunday, Period{Open: 9 * 60, Close: 12 * 60}))
+	s.True(s.schedule.IsOpenOnWeekends())
+}
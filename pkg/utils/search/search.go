@@ -0,0 +1,418 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	exactSimilarity  = 1.0
+	prefixSimilarity = 0.8
+	// minimum token length before prefix and typo matching kicks in
+	minFuzzyLength = 4
+	// maximum length of a highlighted field before it gets cut into a snippet
+	snippetLength = 160
+)
+
+// Document is a single searchable item, the key of Fields is the field name
+type Document struct {
+	ID     string
+	Fields map[string]string
+}
+
+// Hit is a document matching a query
+type Hit struct {
+	ID    string
+	Score float64
+	// Highlights contains the matched fields with the matched words wrapped in <em> tags
+	Highlights map[string]string
+}
+
+// Index is a thread safe in-memory inverted index with typo tolerance
+type Index struct {
+	mu       sync.RWMutex
+	weights  map[string]float64
+	docs     map[string]*Document
+	postings map[string]map[string]map[string]int // term -> document -> field -> term frequency
+}
+
+// NewIndex creates an empty index, fields without a weight are weighted 1
+func NewIndex(weights map[string]float64) *Index {
+	return &Index{
+		weights:  weights,
+		docs:     map[string]*Document{},
+		postings: map[string]map[string]map[string]int{},
+	}
+}
+
+// Add adds the document to the index, replacing the previous version if any
+func (i *Index) Add(doc *Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(doc.ID)
+	i.add(doc)
+}
+
+// Remove removes the document from the index
+func (i *Index) Remove(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+}
+
+// Replace swaps the whole content of the index with the given documents
+func (i *Index) Replace(docs []Document) {
+	fresh := NewIndex(i.weights)
+	for idx := range docs {
+		fresh.add(&docs[idx])
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.docs = fresh.docs
+	i.postings = fresh.postings
+}
+
+// Len returns the number of indexed documents
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.docs)
+}
+
+func (i *Index) add(doc *Document) {
+	i.docs[doc.ID] = doc
+	for field, text := range doc.Fields {
+		for _, term := range Tokenize(text) {
+			docs, ok := i.postings[term]
+			if !ok {
+				docs = map[string]map[string]int{}
+				i.postings[term] = docs
+			}
+
+			fields, ok := docs[doc.ID]
+			if !ok {
+				fields = map[string]int{}
+				docs[doc.ID] = fields
+			}
+
+			fields[field]++
+		}
+	}
+}
+
+func (i *Index) remove(id string) {
+	doc, ok := i.docs[id]
+	if !ok {
+		return
+	}
+
+	for _, text := range doc.Fields {
+		for _, term := range Tokenize(text) {
+			docs, ok := i.postings[term]
+			if !ok {
+				continue
+			}
+
+			delete(docs, id)
+			if len(docs) == 0 {
+				delete(i.postings, term)
+			}
+		}
+	}
+
+	delete(i.docs, id)
+}
+
+// Search returns the documents matching the query sorted by relevance.
+// Every query term is matched exactly, as a prefix, or with a few typos depending on its length,
+// and a document has to match most of the query terms to be returned
+func (i *Index) Search(query string) []Hit {
+	terms := unique(Tokenize(query))
+	if len(terms) == 0 {
+		return []Hit{}
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	scores := map[string]float64{}
+	matchedTerms := map[string]int{}
+	// document -> field -> index terms that matched, used for highlighting
+	matchedWords := map[string]map[string]map[string]bool{}
+
+	total := float64(len(i.docs))
+	for _, term := range terms {
+		best := map[string]float64{}
+		for indexTerm, similarity := range i.expand(term) {
+			docs := i.postings[indexTerm]
+			idf := math.Log(1 + total/float64(len(docs)))
+
+			for docID, fields := range docs {
+				score := 0.0
+				for field, frequency := range fields {
+					score += i.weight(field) * (1 + math.Log(float64(frequency)))
+
+					if _, ok := matchedWords[docID]; !ok {
+						matchedWords[docID] = map[string]map[string]bool{}
+					}
+					if _, ok := matchedWords[docID][field]; !ok {
+						matchedWords[docID][field] = map[string]bool{}
+					}
+					matchedWords[docID][field][indexTerm] = true
+				}
+
+				score *= similarity * idf
+				if score > best[docID] {
+					best[docID] = score
+				}
+			}
+		}
+
+		for docID, score := range best {
+			scores[docID] += score
+			matchedTerms[docID]++
+		}
+	}
+
+	minimum := minimumMatch(len(terms))
+	hits := make([]Hit, 0, len(scores))
+	for docID, score := range scores {
+		if matchedTerms[docID] < minimum {
+			continue
+		}
+
+		coverage := float64(matchedTerms[docID]) / float64(len(terms))
+		hits = append(hits, Hit{
+			ID:         docID,
+			Score:      score * coverage,
+			Highlights: i.highlight(docID, matchedWords[docID]),
+		})
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].Score == hits[b].Score {
+			return hits[a].ID < hits[b].ID
+		}
+		return hits[a].Score > hits[b].Score
+	})
+
+	return hits
+}
+
+// expand returns the index terms matching the query term along with their similarity
+func (i *Index) expand(term string) map[string]float64 {
+	matches := map[string]float64{}
+	if _, ok := i.postings[term]; ok {
+		matches[term] = exactSimilarity
+	}
+
+	termLength := len([]rune(term))
+	if termLength < minFuzzyLength {
+		return matches
+	}
+
+	maxDistance := MaxTypos(term)
+	for indexTerm := range i.postings {
+		if indexTerm == term {
+			continue
+		}
+
+		if strings.HasPrefix(indexTerm, term) {
+			matches[indexTerm] = prefixSimilarity
+			continue
+		}
+
+		indexTermLength := len([]rune(indexTerm))
+		if abs(indexTermLength-termLength) > maxDistance {
+			continue
+		}
+
+		distance := Levenshtein(term, indexTerm)
+		if distance <= maxDistance {
+			matches[indexTerm] = 1 - 0.2*float64(distance) - 0.1
+		}
+	}
+
+	return matches
+}
+
+func (i *Index) weight(field string) float64 {
+	if weight, ok := i.weights[field]; ok {
+		return weight
+	}
+	return 1
+}
+
+func (i *Index) highlight(docID string, fields map[string]map[string]bool) map[string]string {
+	doc := i.docs[docID]
+	highlights := map[string]string{}
+	for field, words := range fields {
+		highlights[field] = Highlight(doc.Fields[field], words)
+	}
+	return highlights
+}
+
+// Tokenize splits the text into lower cased words
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+// MaxTypos returns how many typos are tolerated for the given term
+func MaxTypos(term string) int {
+	length := len([]rune(term))
+	switch {
+	case length < minFuzzyLength:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Levenshtein returns the edit distance between a and b
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// Highlight wraps the given words of the text in <em> tags, long texts are cut into a snippet around the first match.
+// The text is html escaped so the <em> tags are the only markup of the result
+func Highlight(text string, words map[string]bool) string {
+	runes := []rune(text)
+
+	type span struct{ start, end int }
+	spans := []span{}
+	for start := 0; start < len(runes); {
+		if !isWordRune(runes[start]) {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		if words[strings.ToLower(string(runes[start:end]))] {
+			spans = append(spans, span{start, end})
+		}
+		start = end
+	}
+
+	from, to := 0, len(runes)
+	if len(runes) > snippetLength {
+		if len(spans) > 0 {
+			from = spans[0].start - snippetLength/4
+		}
+		if from < 0 {
+			from = 0
+		}
+
+		// don't cut a word in half
+		for from > 0 && isWordRune(runes[from-1]) {
+			from--
+		}
+
+		to = from + snippetLength
+		if to > len(runes) {
+			to = len(runes)
+		}
+		for to < len(runes) && isWordRune(runes[to]) {
+			to++
+		}
+	}
+
+	builder := strings.Builder{}
+	if from > 0 {
+		builder.WriteString("...")
+	}
+
+	cursor := from
+	for _, s := range spans {
+		if s.start < from || s.end > to {
+			continue
+		}
+
+		builder.WriteString(html.EscapeString(string(runes[cursor:s.start])))
+		builder.WriteString("<em>")
+		builder.WriteString(html.EscapeString(string(runes[s.start:s.end])))
+		builder.WriteString("</em>")
+		cursor = s.end
+	}
+	builder.WriteString(html.EscapeString(string(runes[cursor:to])))
+
+	if to < len(runes) {
+		builder.WriteString("...")
+	}
+
+	return builder.String()
+}
+
+// minimumMatch returns how many of the query terms a document has to match
+func minimumMatch(terms int) int {
+	return terms - terms/3
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func unique(terms []string) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		result = append(result, term)
+	}
+	return result
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteSearchIndex struct {
+	suite.Suite
+	index *Index
+}
+
+func (s *TestSuiteSearchIndex) SetupTest() {
+	s.index = NewIndex(map[string]float64{"name": 3, "city": 2})
+	s.index.Replace([]Document{
+		{ID: "1", Fields: map[string]string{"name": "Kuta Coworking Space", "city": "Badung", "facilities": "Wifi, Parking"}},
+		{ID: "2", Fields: map[string]string{"name": "Sanur Private Office", "city": "Denpasar", "facilities": "Wifi"}},
+		{ID: "3", Fields: map[string]string{"name": "Canggu Hub", "city": "Badung", "description": "Coworking near Kuta beach"}},
+	})
+}
+
+func (s *TestSuiteSearchIndex) TearDownTest() {
+	s.index = nil
+}
+
+func TestSearchIndex(t *testing.T) {
+	suite.Run(t, new(TestSuiteSearchIndex))
+}
+
+func (s *TestSuiteSearchIndex) TestSearch_MultipleFields() {
+	hits := s.index.Search("coworking kuta wifi")
+	s.Len(hits, 2)
+	s.Equal("1", hits[0].ID)
+	s.Equal("3", hits[1].ID)
+}
+
+func (s *TestSuiteSearchIndex) TestSearch_Typo() {
+	hits := s.index.Search("cowroking")
+	s.Len(hits, 2)
+}
+
+func (s *TestSuiteSearchIndex) TestSearch_Prefix() {
+	hits := s.index.Search("denpa")
+	s.Len(hits, 1)
+	s.Equal("2", hits[0].ID)
+}
+
+func (s *TestSuiteSearchIndex) TestSearch_NoMatch() {
+	hits := s.index.Search("jakarta")
+	s.Empty(hits)
+}
+
+func (s *TestSuiteSearchIndex) TestSearch_Highlight() {
+	hits := s.index.Search("sanur")
+	s.Len(hits, 1)
+	s.Equal("<em>Sanur</em> Private Office", hits[0].Highlights["name"])
+}
+
+func (s *TestSuiteSearchIndex) TestAdd_ReplacesDocument() {
+	s.index.Add(&Document{ID: "2", Fields: map[string]string{"name": "Ubud Studio"}})
+	s.Empty(s.index.Search("sanur"))
+	s.Len(s.index.Search("ubud"), 1)
+	s.Equal(3, s.index.Len())
+}
+
+func (s *TestSuiteSearchIndex) TestRemove() {
+	s.index.Remove("1")
+	s.Len(s.index.Search("kuta"), 1)
+	s.Equal(2, s.index.Len())
+}
+
+func (s *TestSuiteSearchIndex) TestLevenshtein() {
+	s.Equal(0, Levenshtein("kuta", "kuta"))
+	s.Equal(1, Levenshtein("kuta", "kota"))
+	s.Equal(2, Levenshtein("coworking", "cowroking"))
+}
+
+func (s *TestSuiteSearchIndex) TestHighlight_Snippet() {
+	text := "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. " +
+		"Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat near kuta beach."
+	highlighted := Highlight(text, map[string]bool{"kuta": true})
+	s.Contains(highlighted, "<em>kuta</em>")
+	s.True(len(highlighted) < len(text))
+}
+
+func (s *TestSuiteSearchIndex) TestHighlight_EscapesHTML() {
+	text := `Kuta <script>alert("kuta")</script> & beach`
+	highlighted := Highlight(text, map[string]bool{"kuta": true})
+	s.Equal(`<em>Kuta</em> &lt;script&gt;alert(&#34;<em>kuta</em>&#34;)&lt;/script&gt; &amp; beach`, highlighted)
+	s.NotContains(highlighted, "<script>")
+}
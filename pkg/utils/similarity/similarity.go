@@ -0,0 +1,157 @@
+package similarity
+
+import (
+	"math"
+	"office-booking-backend/pkg/utils/geo"
+	"sort"
+)
+
+const (
+	// distanceScaleKm is the distance at which the distance score drops to about a third
+	distanceScaleKm = 10.0
+	// priceRatioLimit is the price ratio at which the price score reaches zero
+	priceRatioLimit = 4.0
+	// ratingPrior and ratingPriorWeight pull the rating of buildings with few reviews towards an average building
+	ratingPrior       = 3.0
+	ratingPriorWeight = 5.0
+)
+
+// Candidate is the part of a building the similarity is computed from
+type Candidate struct {
+	ID           string
+	Latitude     float64
+	Longitude    float64
+	MonthlyPrice int
+	AnnualPrice  int
+	Capacity     int
+	CategoryIDs  []int
+	Rating       float64
+	ReviewCount  int
+}
+
+// Weights sets how much each criteria counts towards the score, they don't need to add up to 1
+type Weights struct {
+	Distance   float64
+	Price      float64
+	Capacity   float64
+	Facilities float64
+	Rating     float64
+}
+
+var DefaultWeights = Weights{
+	Distance:   0.3,
+	Price:      0.25,
+	Capacity:   0.15,
+	Facilities: 0.2,
+	Rating:     0.1,
+}
+
+// Result is the similarity of a candidate to the target, Score is from 0 to 1
+type Result struct {
+	ID         string  `json:"id"`
+	Score      float64 `json:"score"`
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// Score compares a candidate to the target building
+func Score(target *Candidate, candidate *Candidate, weights Weights) Result {
+	distance := geo.Haversine(target.Latitude, target.Longitude, candidate.Latitude, candidate.Longitude)
+
+	total := weights.Distance + weights.Price + weights.Capacity + weights.Facilities + weights.Rating
+	if total == 0 {
+		return Result{ID: candidate.ID, DistanceKm: distance}
+	}
+
+	score := weights.Distance*math.Exp(-distance/distanceScaleKm) +
+		weights.Price*priceScore(target, candidate) +
+		weights.Capacity*ratio(float64(target.Capacity), float64(candidate.Capacity)) +
+		weights.Facilities*jaccard(target.CategoryIDs, candidate.CategoryIDs) +
+		weights.Rating*ratingScore(candidate)
+
+	return Result{
+		ID:         candidate.ID,
+		Score:      score / total,
+		DistanceKm: distance,
+	}
+}
+
+// Rank returns the limit most similar candidates, the target itself is skipped
+func Rank(target *Candidate, candidates []Candidate, weights Weights, limit int) []Result {
+	results := make([]Result, 0, len(candidates))
+	for i := range candidates {
+		if candidates[i].ID == target.ID {
+			continue
+		}
+		results = append(results, Score(target, &candidates[i], weights))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// priceScore compares the monthly prices, or the annual prices when a building has no monthly price
+func priceScore(target *Candidate, candidate *Candidate) float64 {
+	a, b := float64(target.MonthlyPrice), float64(candidate.MonthlyPrice)
+	if a == 0 || b == 0 {
+		a, b = float64(target.AnnualPrice), float64(candidate.AnnualPrice)
+	}
+
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+
+	// prices are compared by ratio, so the same gap matters less for expensive buildings
+	return math.Max(0, 1-math.Abs(math.Log(a/b))/math.Log(priceRatioLimit))
+}
+
+// ratio returns the smaller value divided by the larger one
+func ratio(a float64, b float64) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	return math.Min(a, b) / math.Max(a, b)
+}
+
+// jaccard returns the share of facility categories the buildings have in common
+func jaccard(a []int, b []int) float64 {
+	set := map[int]bool{}
+	for _, id := range a {
+		set[id] = true
+	}
+
+	union := len(set)
+	intersection := 0
+	seen := map[int]bool{}
+	for _, id := range b {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if set[id] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+func ratingScore(candidate *Candidate) float64 {
+	reviews := float64(candidate.ReviewCount)
+	rating := (candidate.Rating*reviews + ratingPrior*ratingPriorWeight) / (reviews + ratingPriorWeight)
+	return rating / 5
+}
@@ -0,0 +1,100 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteSimilarity struct {
+	suite.Suite
+	target *Candidate
+}
+
+func TestSimilarity(t *testing.T) {
+	suite.Run(t, new(TestSuiteSimilarity))
+}
+
+func (s *TestSuiteSimilarity) SetupTest() {
+	s.target = &Candidate{
+		ID:           "target",
+		Latitude:     -8.65,
+		Longitude:    115.21,
+		MonthlyPrice: 10000000,
+		AnnualPrice:  100000000,
+		Capacity:     20,
+		CategoryIDs:  []int{1, 2, 3},
+		Rating:       4,
+		ReviewCount:  10,
+	}
+}
+
+func (s *TestSuiteSimilarity) TestScore_Identical() {
+	candidate := *s.target
+	candidate.ID = "copy"
+	candidate.Rating = 5
+	candidate.ReviewCount = 1000
+
+	result := Score(s.target, &candidate, DefaultWeights)
+	s.Equal("copy", result.ID)
+	s.InDelta(1, result.Score, 0.01)
+	s.Equal(0.0, result.DistanceKm)
+}
+
+func (s *TestSuiteSimilarity) TestScore_Bounds() {
+	candidate := Candidate{ID: "empty"}
+	result := Score(s.target, &candidate, DefaultWeights)
+	s.GreaterOrEqual(result.Score, 0.0)
+	s.LessOrEqual(result.Score, 1.0)
+
+	s.Equal(0.0, Score(s.target, &candidate, Weights{}).Score)
+}
+
+func (s *TestSuiteSimilarity) TestPriceScore() {
+	same := &Candidate{MonthlyPrice: 10000000}
+	double := &Candidate{MonthlyPrice: 20000000}
+	half := &Candidate{MonthlyPrice: 5000000}
+	far := &Candidate{MonthlyPrice: 50000000}
+
+	s.Equal(1.0, priceScore(s.target, same))
+	s.InDelta(0.5, priceScore(s.target, double), 1e-9)
+	// the ratio counts the same both ways
+	s.InDelta(priceScore(s.target, double), priceScore(s.target, half), 1e-9)
+	s.Equal(0.0, priceScore(s.target, far))
+
+	// the annual price is used when there is no monthly price
+	annualOnly := &Candidate{AnnualPrice: 100000000}
+	s.Equal(1.0, priceScore(s.target, annualOnly))
+}
+
+func (s *TestSuiteSimilarity) TestJaccard() {
+	s.Equal(1.0, jaccard([]int{1, 2}, []int{2, 1, 1}))
+	s.InDelta(0.5, jaccard([]int{1, 2, 3}, []int{2, 3, 4}), 1e-9)
+	s.Equal(0.0, jaccard(nil, nil))
+}
+
+func (s *TestSuiteSimilarity) TestRatingScore_FewReviews() {
+	// a perfect rating from one review counts less than a good rating from many
+	few := &Candidate{Rating: 5, ReviewCount: 1}
+	many := &Candidate{Rating: 4.5, ReviewCount: 100}
+	s.Less(ratingScore(few), ratingScore(many))
+}
+
+func (s *TestSuiteSimilarity) TestRank() {
+	candidates := []Candidate{
+		*s.target,
+		{ID: "far", Latitude: -6.2, Longitude: 106.8, MonthlyPrice: 10000000, Capacity: 20, CategoryIDs: []int{1, 2, 3}},
+		{ID: "near", Latitude: -8.651, Longitude: 115.211, MonthlyPrice: 10000000, Capacity: 20, CategoryIDs: []int{1, 2, 3}},
+		{ID: "cheap", Latitude: -8.651, Longitude: 115.211, MonthlyPrice: 1000000, Capacity: 2},
+	}
+
+	results := Rank(s.target, candidates, DefaultWeights, 2)
+	s.Len(results, 2)
+	s.Equal("near", results[0].ID)
+	s.Equal("far", results[1].ID)
+
+	// the target is never recommended
+	for _, result := range Rank(s.target, candidates, DefaultWeights, 0) {
+		s.NotEqual("target", result.ID)
+	}
+}
@@ -0,0 +1,170 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	err2 "office-booking-backend/pkg/errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// utf8BOM is prepended by spreadsheet apps when saving as csv
+const utf8BOM = "\ufeff"
+
+// formulaPrefixes are the first characters that make a spreadsheet app evaluate a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeCell prefixes a text that would be evaluated as a formula with a quote, numbers are kept as is
+func escapeCell(value string) string {
+	if value == "" || !strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return value
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+
+	return "'" + value
+}
+
+// unescapeCell removes the quote added by escapeCell, so an exported file can be imported back
+func unescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func unescapeRows(rows [][]string) [][]string {
+	for _, row := range rows {
+		for j, value := range row {
+			row[j] = unescapeCell(value)
+		}
+	}
+	return rows
+}
+
+// Format returns the spreadsheet format of a file from its extension
+func Format(fileName string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), ".")) {
+	case CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	default:
+		return "", err2.ErrUnsupportedSpreadsheet
+	}
+}
+
+// ContentType returns the mime type of the format
+func ContentType(format string) string {
+	if format == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// Read returns the rows of a csv file or of the first sheet of a xlsx file
+func Read(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case CSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err2.ErrInvalidSpreadsheet
+		}
+
+		if len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], utf8BOM)
+		}
+		return unescapeRows(rows), nil
+	case XLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err2.ErrInvalidSpreadsheet
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, err2.ErrInvalidSpreadsheet
+		}
+
+		rows, err := file.GetRows(sheets[0])
+		if err != nil {
+			return nil, err2.ErrInvalidSpreadsheet
+		}
+		return unescapeRows(rows), nil
+	default:
+		return nil, err2.ErrUnsupportedSpreadsheet
+	}
+}
+
+// Write writes the rows as a csv file or as a single sheet xlsx file, the cells are user input
+// so the ones starting like a formula are escaped
+func Write(w io.Writer, format string, sheet string, rows [][]string) error {
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = make([]string, len(row))
+		for j, value := range row {
+			escaped[i][j] = escapeCell(value)
+		}
+	}
+	rows = escaped
+
+	switch format {
+	case CSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case XLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+
+		if err := file.SetSheetName(file.GetSheetList()[0], sheet); err != nil {
+			return err
+		}
+
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+
+			values := make([]interface{}, len(row))
+			for j, value := range row {
+				values[j] = value
+			}
+
+			if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+				return err
+			}
+		}
+
+		return file.Write(w)
+	default:
+		return err2.ErrUnsupportedSpreadsheet
+	}
+}
+
+// Bytes writes the rows into a buffer, see Write
+func Bytes(format string, sheet string, rows [][]string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, format, sheet, rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
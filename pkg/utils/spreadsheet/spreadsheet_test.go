@@ -0,0 +1,94 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	err2 "office-booking-backend/pkg/errors"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var rows = [][]string{
+	{"id", "name", "facilities"},
+	{"", "Sudirman Tower", "1 | Wifi | 100 Mbps\n2 | Parking"},
+	{"abc", "Kuta Hub, Bali", ""},
+}
+
+type TestSuiteSpreadsheet struct {
+	suite.Suite
+}
+
+func TestSpreadsheet(t *testing.T) {
+	suite.Run(t, new(TestSuiteSpreadsheet))
+}
+
+func (s *TestSuiteSpreadsheet) TestFormat() {
+	format, err := Format("buildings.XLSX")
+	s.Nil(err)
+	s.Equal(XLSX, format)
+
+	format, err = Format("export/buildings.csv")
+	s.Nil(err)
+	s.Equal(CSV, format)
+
+	_, err = Format("buildings.xls")
+	s.Equal(err2.ErrUnsupportedSpreadsheet, err)
+}
+
+func (s *TestSuiteSpreadsheet) TestRoundTrip() {
+	for _, format := range []string{CSV, XLSX} {
+		s.Run(format, func() {
+			content, err := Bytes(format, "Buildings", rows)
+			s.Nil(err)
+
+			read, err := Read(bytes.NewReader(content), format)
+			s.Nil(err)
+
+			// xlsx drops trailing empty cells
+			s.Equal(rows[0], read[0])
+			s.Equal(rows[1], read[1])
+			s.Equal(rows[2][:2], read[2][:2])
+		})
+	}
+}
+
+func (s *TestSuiteSpreadsheet) TestReadCSVWithBOM() {
+	read, err := Read(strings.NewReader("\ufeffid,name\n1,Tower\n"), CSV)
+	s.Nil(err)
+	s.Equal([]string{"id", "name"}, read[0])
+}
+
+func (s *TestSuiteSpreadsheet) TestReadInvalid() {
+	_, err := Read(strings.NewReader("not a zip"), XLSX)
+	s.Equal(err2.ErrInvalidSpreadsheet, err)
+
+	_, err = Read(strings.NewReader(""), "ods")
+	s.Equal(err2.ErrUnsupportedSpreadsheet, err)
+}
+
+func (s *TestSuiteSpreadsheet) TestWriteEscapesFormulas() {
+	formulas := [][]string{
+		{"name", "owner", "longitude"},
+		{"=HYPERLINK(\"https://example.com\")", "@SUM(A1)", "-8.65"},
+		{"+62 812", "-Kuta", "Sanur"},
+	}
+
+	content, err := Bytes(CSV, "Buildings", formulas)
+	s.Nil(err)
+	s.Contains(string(content), "\"'=HYPERLINK(\"\"https://example.com\"\")\",'@SUM(A1),-8.65\n")
+	s.Contains(string(content), "'+62 812,'-Kuta,Sanur\n")
+
+	for _, format := range []string{CSV, XLSX} {
+		s.Run(format, func() {
+			content, err := Bytes(format, "Buildings", formulas)
+			s.Nil(err)
+
+			// the escape is removed on import
+			read, err := Read(bytes.NewReader(content), format)
+			s.Nil(err)
+			s.Equal(formulas, read)
+		})
+	}
+}
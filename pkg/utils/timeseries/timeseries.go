@@ -0,0 +1,182 @@
+package timeseries
+
+import (
+	"office-booking-backend/pkg/custom"
+	err2 "office-booking-backend/pkg/errors"
+	"time"
+)
+
+const (
+	Day   = "day"
+	Week  = "week"
+	Month = "month"
+	Year  = "year"
+)
+
+const (
+	// MaxPoints keeps a series at a size a chart can show, a daily series covers a bit over a year
+	MaxPoints = 400
+	// BucketFormat is the format of the bucket keys returned by the Bucket SQL expression
+	BucketFormat = "2006-01-02"
+)
+
+// defaultPoints is how many buckets are returned when no start date is given
+var defaultPoints = map[string]int{
+	Day:   30,
+	Week:  12,
+	Month: 12,
+	Year:  5,
+}
+
+// Query is embedded in statistic query params, the dates are inclusive
+type Query struct {
+	StartDate custom.Date `query:"startDate"`
+	EndDate   custom.Date `query:"endDate"`
+	Interval  string      `query:"interval" validate:"omitempty,oneof=day week month year"`
+}
+
+// Range is the period of a series split into buckets, the buckets start at midnight in the location
+// and weeks start on monday
+type Range struct {
+	// Start is the start date and End is the day after the end date, for SQL
+	Start    time.Time
+	End      time.Time
+	Interval string
+	Location *time.Location
+}
+
+// SetDefault sets the interval used when the query doesn't specify one
+func (q *Query) SetDefault(interval string) {
+	if q.Interval == "" {
+		q.Interval = interval
+	}
+}
+
+// Range returns the range of the query, it ends today and shows the default number of points when the dates are not given.
+// The interval is daily by default, the location must be the one the DATETIME columns are stored in so the buckets of the SQL match
+func (q *Query) Range(loc *time.Location) (*Range, error) {
+	q.SetDefault(Day)
+	interval := q.Interval
+
+	if _, ok := defaultPoints[interval]; !ok {
+		return nil, err2.ErrInvalidQueryParams
+	}
+
+	endDate := inLocation(q.EndDate.ToTime(), loc)
+	if q.EndDate.ToTime().IsZero() {
+		endDate = Truncate(time.Now(), Day, loc)
+	}
+
+	startDate := inLocation(q.StartDate.ToTime(), loc)
+	if q.StartDate.ToTime().IsZero() {
+		startDate = add(Truncate(endDate, interval, loc), interval, -(defaultPoints[interval] - 1))
+	}
+
+	if endDate.Before(startDate) {
+		return nil, err2.ErrInvalidDateRange
+	}
+
+	r := &Range{
+		Start:    startDate,
+		End:      endDate.AddDate(0, 0, 1),
+		Interval: interval,
+		Location: loc,
+	}
+
+	if len(r.Buckets()) > MaxPoints {
+		return nil, err2.ErrTooManyDataPoints
+	}
+
+	return r, nil
+}
+
+// inLocation moves a date parsed in UTC to midnight of the same date in the location
+func inLocation(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// Truncate returns the start of the bucket holding the time
+func Truncate(t time.Time, interval string, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch interval {
+	case Week:
+		return time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, loc)
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	case Year:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// add moves a bucket start by n buckets, on the calendar so a day always starts at midnight
+func add(t time.Time, interval string, n int) time.Time {
+	switch interval {
+	case Week:
+		return t.AddDate(0, 0, 7*n)
+	case Month:
+		return t.AddDate(0, n, 0)
+	case Year:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// Buckets returns the start of every bucket, the first one may start before the start date
+func (r *Range) Buckets() []time.Time {
+	buckets := make([]time.Time, 0)
+	for bucket := Truncate(r.Start, r.Interval, r.Location); bucket.Before(r.End); bucket = add(bucket, r.Interval, 1) {
+		buckets = append(buckets, bucket)
+		if len(buckets) > MaxPoints {
+			break
+		}
+	}
+	return buckets
+}
+
+// Bucket returns the SQL expression of the bucket key of a DATETIME column, formatted with BucketFormat
+func (r *Range) Bucket(column string) string {
+	switch r.Interval {
+	case Week:
+		return "DATE_FORMAT(DATE_SUB(" + column + ", INTERVAL WEEKDAY(" + column + ") DAY), '%Y-%m-%d')"
+	case Month:
+		return "DATE_FORMAT(" + column + ", '%Y-%m-01')"
+	case Year:
+		return "DATE_FORMAT(" + column + ", '%Y-01-01')"
+	default:
+		return "DATE_FORMAT(" + column + ", '%Y-%m-%d')"
+	}
+}
+
+type Point struct {
+	Start string `json:"start"`
+	Value int64  `json:"value"`
+}
+
+type Series struct {
+	StartDate string  `json:"startDate"`
+	EndDate   string  `json:"endDate"`
+	Interval  string  `json:"interval"`
+	Total     int64   `json:"total"`
+	Points    []Point `json:"points"`
+}
+
+// Fill returns a point for every bucket, the buckets without a value are zero
+func (r *Range) Fill(values map[string]int64) *Series {
+	series := &Series{
+		StartDate: r.Start.Format(BucketFormat),
+		EndDate:   r.End.AddDate(0, 0, -1).Format(BucketFormat),
+		Interval:  r.Interval,
+		Points:    make([]Point, 0),
+	}
+
+	for _, bucket := range r.Buckets() {
+		key := bucket.Format(BucketFormat)
+		series.Total += values[key]
+		series.Points = append(series.Points, Point{Start: key, Value: values[key]})
+	}
+
+	return series
+}
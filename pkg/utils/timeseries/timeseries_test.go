@@ -0,0 +1,95 @@
+package timeseries
+
+import (
+	"office-booking-backend/pkg/custom"
+	err2 "office-booking-backend/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuiteTimeSeries struct {
+	suite.Suite
+	loc *time.Location
+}
+
+func TestTimeSeries(t *testing.T) {
+	suite.Run(t, new(TestSuiteTimeSeries))
+}
+
+func (s *TestSuiteTimeSeries) SetupTest() {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	s.Require().NoError(err)
+	s.loc = loc
+}
+
+func (s *TestSuiteTimeSeries) date(value string) custom.Date {
+	date, err := time.Parse(BucketFormat, value)
+	s.Require().NoError(err)
+	return custom.Date(date)
+}
+
+func (s *TestSuiteTimeSeries) TestRange_Default() {
+	query := &Query{}
+	r, err := query.Range(s.loc)
+	s.NoError(err)
+	s.Equal(Day, r.Interval)
+	s.Len(r.Buckets(), defaultPoints[Day])
+	s.Equal(Truncate(time.Now(), Day, s.loc).AddDate(0, 0, 1), r.End)
+}
+
+func (s *TestSuiteTimeSeries) TestRange_InLocation() {
+	query := &Query{StartDate: s.date("2023-03-01"), EndDate: s.date("2023-03-31")}
+	r, err := query.Range(s.loc)
+	s.NoError(err)
+	s.Equal(time.Date(2023, time.March, 1, 0, 0, 0, 0, s.loc), r.Start)
+	s.Equal(time.Date(2023, time.April, 1, 0, 0, 0, 0, s.loc), r.End)
+	s.Len(r.Buckets(), 31)
+}
+
+func (s *TestSuiteTimeSeries) TestRange_InvalidDateRange() {
+	query := &Query{StartDate: s.date("2023-03-02"), EndDate: s.date("2023-03-01")}
+	_, err := query.Range(s.loc)
+	s.Equal(err2.ErrInvalidDateRange, err)
+}
+
+func (s *TestSuiteTimeSeries) TestRange_TooManyDataPoints() {
+	query := &Query{StartDate: s.date("2020-01-01"), EndDate: s.date("2023-01-01")}
+	_, err := query.Range(s.loc)
+	s.Equal(err2.ErrTooManyDataPoints, err)
+
+	query.Interval = Week
+	_, err = query.Range(s.loc)
+	s.NoError(err)
+}
+
+func (s *TestSuiteTimeSeries) TestTruncate() {
+	// 2023-03-16 is a thursday
+	t := time.Date(2023, time.March, 16, 15, 30, 0, 0, s.loc)
+	s.Equal(time.Date(2023, time.March, 16, 0, 0, 0, 0, s.loc), Truncate(t, Day, s.loc))
+	s.Equal(time.Date(2023, time.March, 13, 0, 0, 0, 0, s.loc), Truncate(t, Week, s.loc))
+	s.Equal(time.Date(2023, time.March, 1, 0, 0, 0, 0, s.loc), Truncate(t, Month, s.loc))
+	s.Equal(time.Date(2023, time.January, 1, 0, 0, 0, 0, s.loc), Truncate(t, Year, s.loc))
+
+	// a sunday belongs to the week of the monday before it
+	sunday := time.Date(2023, time.March, 19, 23, 0, 0, 0, s.loc)
+	s.Equal(time.Date(2023, time.March, 13, 0, 0, 0, 0, s.loc), Truncate(sunday, Week, s.loc))
+}
+
+func (s *TestSuiteTimeSeries) TestFill() {
+	query := &Query{StartDate: s.date("2023-01-15"), EndDate: s.date("2023-04-10"), Interval: Month}
+	r, err := query.Range(s.loc)
+	s.Require().NoError(err)
+
+	series := r.Fill(map[string]int64{"2023-01-01": 3, "2023-03-01": 5})
+	s.Equal("2023-01-15", series.StartDate)
+	s.Equal("2023-04-10", series.EndDate)
+	s.Equal(int64(8), series.Total)
+	s.Equal([]Point{
+		{Start: "2023-01-01", Value: 3},
+		{Start: "2023-02-01", Value: 0},
+		{Start: "2023-03-01", Value: 5},
+		{Start: "2023-04-01", Value: 0},
+	}, series.Points)
+}
@@ -28,6 +28,8 @@ type CustomValidator struct {
 type ErrorResponse struct {
 	Field  string `json:"field"`
 	Reason string `json:"reason"`
+	// Namespace is the full path of the field, e.g. FullBuildingResponse.price.annual
+	Namespace string `json:"-"`
 }
 
 type ErrorsResponse []ErrorResponse
@@ -79,8 +81,9 @@ func (c *CustomValidator) ValidateStruct(s interface{}) *ErrorsResponse {
 	var errors ErrorsResponse
 	for _, err := range err.(validation.ValidationErrors) {
 		errors = append(errors, ErrorResponse{
-			Field:  err.Field(),
-			Reason: err.Translate(c.trans),
+			Field:     err.Field(),
+			Reason:    err.Translate(c.trans),
+			Namespace: err.Namespace(),
 		})
 	}
 